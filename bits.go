@@ -0,0 +1,186 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"example/internal/bitwise"
+)
+
+// runBits продолжает f64: показывает биты числа любого целого типа, меняет отдельные биты
+// и с флагом -steps печатает таблицу сложений, в которой видно, как переполнение из f63 меняет биты.
+// Изменения применяются по порядку: -set, -clear, -toggle, -rotate; -test, -field и -steps
+// работают с получившимся значением.
+func runBits(args []string) error {
+	fs := flag.NewFlagSet("bits", flag.ContinueOnError)
+	kindName := fs.String("type", "int", "целый тип: int8-int64, uint8-uint64, int, uint, byte, rune")
+	set := fs.String("set", "", "установить биты, номера через запятую с младшего бита 0")
+	clear := fs.String("clear", "", "сбросить биты")
+	toggle := fs.String("toggle", "", "инвертировать биты")
+	test := fs.String("test", "", "проверить биты")
+	field := fs.String("field", "", "битовое поле с младшего по старший бит включительно, например 4:7")
+	rotate := fs.Int("rotate", 0, "циклический сдвиг влево на N бит (N < 0 - вправо)")
+	steps := fs.Int("steps", 0, "напечатать N сложений с -add, как в f63")
+	add := fs.Int64("add", 1, "шаг сложения для -steps")
+	if err := fs.Parse(moveNegativeNumbers(fs, args)); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("нужно одно число, например: bits -type uint8 0b1000_0011 или bits -type int8 -- -125")
+	}
+
+	kind, err := bitwise.ParseKind(*kindName)
+	if err != nil {
+		return fmt.Errorf("флаг -type: %w", err)
+	}
+	v, err := bitwise.Parse(fs.Arg(0), kind)
+	if err != nil {
+		return fmt.Errorf("число для %v: %w", kind, err)
+	}
+	printBits(v)
+
+	for _, op := range []struct {
+		flag, list string
+		apply      func(bitwise.Value, int) (bitwise.Value, error)
+	}{
+		{"set", *set, bitwise.Value.Set},
+		{"clear", *clear, bitwise.Value.Clear},
+		{"toggle", *toggle, bitwise.Value.Toggle},
+	} {
+		if op.list == "" {
+			continue
+		}
+		positions, err := parseBitList(op.list)
+		if err != nil {
+			return fmt.Errorf("флаг -%s: %w", op.flag, err)
+		}
+		for _, i := range positions {
+			if v, err = op.apply(v, i); err != nil {
+				return fmt.Errorf("флаг -%s: %w", op.flag, err)
+			}
+		}
+		printChange(fmt.Sprintf("-%s %s", op.flag, op.list), v)
+	}
+	if *rotate != 0 {
+		v = v.RotateLeft(*rotate)
+		printChange(fmt.Sprintf("-rotate %d", *rotate), v)
+	}
+
+	if *test != "" {
+		positions, err := parseBitList(*test)
+		if err != nil {
+			return fmt.Errorf("флаг -test: %w", err)
+		}
+		for _, i := range positions {
+			isSet, err := v.Test(i)
+			if err != nil {
+				return fmt.Errorf("флаг -test: %w", err)
+			}
+			fmt.Printf("%-13s %t\n", fmt.Sprintf("Бит %d:", i), isSet)
+		}
+	}
+	if *field != "" {
+		lo, hi, ok := strings.Cut(*field, ":")
+		from, errLo := strconv.Atoi(lo)
+		to, errHi := strconv.Atoi(hi)
+		if !ok || errLo != nil || errHi != nil {
+			return fmt.Errorf("флаг -field: %q, ожидалось младший:старший, например 4:7", *field)
+		}
+		f, err := v.Field(from, to)
+		if err != nil {
+			return fmt.Errorf("флаг -field: %w", err)
+		}
+		fmt.Printf("%-13s 0b%0*b = %v\n", fmt.Sprintf("Поле %d-%d:", from, to), to-from+1, f.GetBits(), f)
+	}
+
+	if *steps > 0 {
+		fmt.Println()
+		return bitwise.WriteSteps(os.Stdout, bitwise.CalcSteps(v, *add, *steps), *add)
+	}
+	return nil
+}
+
+// moveNegativeNumbers переносит отрицательные числа из args в конец после "--", иначе flag
+// принял бы -125 за неизвестный флаг. Значения флагов, например -3 в "-rotate -3", не трогаются.
+func moveNegativeNumbers(fs *flag.FlagSet, args []string) []string {
+	var rest, numbers []string
+	for i, arg := range args {
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		if isNegativeNumber(arg) && (i == 0 || !isValueFlag(fs, args[i-1])) {
+			numbers = append(numbers, arg)
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	if len(numbers) == 0 {
+		return args
+	}
+	if !slices.Contains(rest, "--") {
+		rest = append(rest, "--")
+	}
+	return append(rest, numbers...)
+}
+
+// isNegativeNumber проверяет, что s - отрицательное число: -125, -0x7f.
+func isNegativeNumber(s string) bool {
+	return len(s) > 1 && s[0] == '-' && s[1] >= '0' && s[1] <= '9'
+}
+
+// isValueFlag проверяет, что arg - флаг из fs, значение которого идет следующим аргументом.
+func isValueFlag(fs *flag.FlagSet, arg string) bool {
+	name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-") // флаг пишется с - или --
+	if !strings.HasPrefix(arg, "-") || strings.Contains(name, "=") {
+		return false
+	}
+	f := fs.Lookup(name)
+	if f == nil {
+		return false
+	}
+	b, isBool := f.Value.(interface{ IsBoolFlag() bool })
+	return !isBool || !b.IsBoolFlag()
+}
+
+// printBits печатает значение во всех системах счисления и его битовые характеристики.
+func printBits(v bitwise.Value) {
+	sign := "беззнаковый"
+	if v.Kind.IsSigned {
+		sign = "знаковый"
+	}
+	fmt.Printf("Тип:          %v (%d бит, %s)\n", v.Kind, v.Kind.Bits, sign)
+	fmt.Printf("Десятичное:   %v\n", v)
+	fmt.Printf("Двоичное:     0b%s\n", v.Format(2, 4))
+	fmt.Printf("Восьмеричное: 0o%s\n", v.Format(8, 0))
+	fmt.Printf("Шестнадц.:    0x%s\n", v.Format(16, 4))
+	fmt.Printf("По битам:     %s\n", v.Explain())
+	// модуль отрицательного числа в дополнительном коде: инвертировать биты и прибавить 1
+	if v.IsNegative() && v.Int64() != v.Kind.GetMin() {
+		abs, _ := v.Not().Add(1)
+		fmt.Printf("Модуль:       ^%s + 1 = %s = %v\n", v.Format(2, 4), abs.Format(2, 4), abs)
+	}
+	fmt.Printf("Единиц: %d, ведущих нулей: %d, конечных нулей: %d\n", v.OnesCount(), v.LeadingZeros(), v.TrailingZeros())
+}
+
+// printChange печатает значение после изменения битов флагом change.
+func printChange(change string, v bitwise.Value) {
+	fmt.Printf("%-13s 0b%s = %v\n", change+":", v.Format(2, 4), v)
+}
+
+// parseBitList разбирает номера битов через запятую.
+func parseBitList(list string) ([]int, error) {
+	var positions []int
+	for _, s := range strings.Split(list, ",") {
+		i, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, i)
+	}
+	return positions, nil
+}
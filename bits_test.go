@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestMoveNegativeNumbers(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"-type", "int64", "-9223372036854775808"}, []string{"-type", "int64", "--", "-9223372036854775808"}},
+		{[]string{"-125", "-type", "int8"}, []string{"-type", "int8", "--", "-125"}},
+		{[]string{"-rotate", "-3", "-type=int8", "-125"}, []string{"-rotate", "-3", "-type=int8", "--", "-125"}},
+		{[]string{"--rotate", "-3", "-0x7f"}, []string{"--rotate", "-3", "--", "-0x7f"}},
+		{[]string{"-rotate=-3", "-1"}, []string{"-rotate=-3", "--", "-1"}},
+		{[]string{"-type", "int8", "--", "-125"}, []string{"-type", "int8", "--", "-125"}},
+		{[]string{"-type", "uint8", "0x83"}, []string{"-type", "uint8", "0x83"}},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("bits", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.String("type", "int", "")
+		fs.Int("rotate", 0, "")
+		got := moveNegativeNumbers(fs, tt.args)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("moveNegativeNumbers(%q) = %q, want %q", tt.args, got, tt.want)
+			continue
+		}
+		if err := fs.Parse(got); err != nil || fs.NArg() != 1 {
+			t.Errorf("Parse(%q): %v, args %q", got, err, fs.Args())
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"example/internal/calendar"
+	co "example/internal/constants"
+)
+
+// runCalendar отвечает на вопрос f19 для обоих календарей, а с флагом -date переводит дату
+// между юлианским и григорианским календарями.
+func runCalendar(args []string) error {
+	fs := flag.NewFlagSet("calendar", flag.ContinueOnError)
+	year := fs.Int("year", co.LeapQuestionYear, "год, который проверяется на високосность")
+	date := fs.String("date", "", "дата ГГГГ-ММ-ДД по календарю, действовавшему в ней (до реформы - по юлианскому)")
+	reformName := fs.String("reform", calendar.Reform1582.Name, "год перехода на григорианский календарь: 1582 или 1918 (Россия)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	reform, err := findReform(*reformName)
+	if err != nil {
+		return err
+	}
+
+	if *date == "" {
+		fmt.Printf("%d год: по григорианскому календарю %s, по юлианскому - %s.\n",
+			*year, describeLeap(calendar.Gregorian.IsLeap(*year)), describeLeap(calendar.Julian.IsLeap(*year)))
+		fmt.Printf("При переходе в %s году он %s: действовал %s календарь.\n",
+			reform.Name, describeLeap(reform.IsLeap(*year)), reform.GetCalendar(*year))
+		return nil
+	}
+
+	d, err := calendar.ParseDate(*date)
+	if err != nil {
+		return fmt.Errorf("флаг -date: %w", err)
+	}
+	civil, err := reform.NewCivilDate(d)
+	if err != nil {
+		return fmt.Errorf("флаг -date: %w", err)
+	}
+	jdn := civil.CalcJDN()
+	fmt.Printf("Дата:             %v\n", civil)
+	fmt.Printf("Юлианский день:   %d\n", jdn)
+	fmt.Printf("Григорианская:    %v\n", calendar.FromJDN(jdn))
+	fmt.Printf("Юлианская:        %v\n", calendar.JulianFromJDN(jdn))
+	fmt.Printf("С эрой:           %s\n", civil.Date.FormatEra())
+	return nil
+}
+
+// findReform возвращает реформу календаря по названию.
+func findReform(name string) (calendar.Reform, error) {
+	for _, r := range calendar.GetReforms() {
+		if r.Name == name {
+			return r, nil
+		}
+	}
+	return calendar.Reform{}, fmt.Errorf("флаг -reform: неизвестная реформа %q, есть 1582 и 1918", name)
+}
+
+// describeLeap возвращает "високосный" или "не високосный".
+func describeLeap(isLeap bool) string {
+	if isLeap {
+		return "високосный"
+	}
+	return "не високосный"
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"example/internal/adventure"
+	co "example/internal/constants"
+)
+
+// runCave запускает текстовое приключение в пещере из f15-f22: команды читаются со стандартного ввода.
+// С флагом -world мир загружается из файла JSON или YAML, а с -check файл только проверяется.
+// С флагом -replay аргументы - журналы партий, которые выполняются заново и сверяются с записанным выводом.
+func runCave(args []string) error {
+	fs := flag.NewFlagSet("cave", flag.ContinueOnError)
+	age := fs.Int("age", co.DefaultPlayerAge, "возраст игрока: в пещеру пускают только совершеннолетних, как в f16")
+	worldPath := fs.String("world", "", "файл мира .json, .yaml или .yml (по умолчанию пещера)")
+	isCheck := fs.Bool("check", false, "только проверить файл мира и вывести ошибки")
+	loadPath := fs.String("load", "", "продолжить партию из файла сохранения")
+	logPath := fs.String("log", "", "записать журнал партии в файл")
+	isReplay := fs.Bool("replay", false, "выполнить журналы партий из аргументов и сверить вывод")
+	isUpdate := fs.Bool("update", false, "вместе с -replay: перезаписать журналы фактическим выводом")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *isReplay {
+		return replayCave(fs.Args(), *isUpdate)
+	}
+
+	world := adventure.NewCaveWorld()
+	if *worldPath != "" {
+		var err error
+		if world, err = adventure.LoadWorldFile(*worldPath); err != nil {
+			return err
+		}
+	}
+	if *isCheck {
+		fmt.Printf("Мир %q в порядке: комнат %d, предметов %d, событий %d.\n",
+			world.Title, len(world.Rooms), len(world.Items), len(world.Events))
+		return nil
+	}
+
+	game, err := adventure.NewGame(world, *age)
+	if err != nil {
+		return err
+	}
+	if *loadPath != "" {
+		state, err := adventure.LoadGameFile(*loadPath, world)
+		if err != nil {
+			return err
+		}
+		if err := game.Restore(state); err != nil {
+			return err
+		}
+	}
+
+	session := adventure.Session{In: os.Stdin, Out: os.Stdout}
+	if *logPath != "" {
+		file, err := os.Create(*logPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		session.Log = file
+		if session.WorldPath, err = calcRelativeWorldPath(*logPath, *worldPath); err != nil {
+			return err
+		}
+	}
+	return session.Run(game)
+}
+
+// calcRelativeWorldPath возвращает путь к файлу мира относительно журнала:
+// так журнал и мир можно переносить вместе.
+func calcRelativeWorldPath(logPath, worldPath string) (string, error) {
+	if worldPath == "" {
+		return "", nil
+	}
+	absLog, err := filepath.Abs(logPath)
+	if err != nil {
+		return "", err
+	}
+	absWorld, err := filepath.Abs(worldPath)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(filepath.Dir(absLog), absWorld)
+	if err != nil {
+		return absWorld, nil
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// replayCave выполняет журналы paths и печатает итог по каждому, как golden для примеров.
+func replayCave(paths []string, isUpdate bool) error {
+	if len(paths) == 0 {
+		return errors.New("укажите файлы журналов, например testdata/cave/*.replay")
+	}
+
+	failed := 0
+	for _, path := range paths {
+		err := replayFile(path, isUpdate)
+		switch {
+		case err == nil && isUpdate:
+			fmt.Println("updated", path)
+		case err == nil:
+			fmt.Println("ok     ", path)
+		default:
+			failed++
+			fmt.Println("FAIL   ", path)
+			fmt.Println(err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: журналов %d из %d", adventure.ErrReplayMismatch, failed, len(paths))
+	}
+	return nil
+}
+
+// replayFile выполняет один журнал и при isUpdate перезаписывает его фактическим выводом.
+func replayFile(path string, isUpdate bool) error {
+	rp, err := adventure.LoadReplayFile(path)
+	if err != nil {
+		return err
+	}
+	world, err := rp.LoadWorld()
+	if err != nil {
+		return err
+	}
+	actual, err := adventure.RunReplay(world, rp)
+	if !isUpdate || actual == nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := actual.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
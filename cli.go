@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrUnknownCommand возвращается, если подкоманда не найдена.
+var ErrUnknownCommand = errors.New("неизвестная команда")
+
+// ErrUnknownExample возвращается, если пример с таким именем не зарегистрирован.
+var ErrUnknownExample = errors.New("неизвестный пример")
+
+// command описывает подкоманду командной строки.
+type command struct {
+	Name  string                    // имя подкоманды, например "run"
+	Usage string                    // строка для справки
+	Run   func(args []string) error // обработчик, получает аргументы после имени
+}
+
+// commands - все подкоманды в порядке вывода в справке.
+var commands = []command{
+	{"list", "list [-tag тема]            список примеров", runList},
+	{"run", "run [-tag тема] [имя ...]   запуск примеров по имени или теме", runExamples},
+	{"golden", "golden [-update] [-tag тема] [имя ...]  сверка вывода примеров с эталонами", runGolden},
+	{"serve", "serve [-addr :8080] [-seed N]  HTTP API билетов на Марс", runServe},
+	{"window", "window [-from дата] [-to дата] [-top N] [-json]  окна запуска к Марсу", runWindow},
+	{"planets", "planets -birth дата [-weight кг] [-now дата]  вес и возраст на телах Солнечной системы", runPlanets},
+	{"piggy", "piggy [-currency USD] [-coins список] [-target сумма] [-trials N] [-seed N] [-rates файл]  статистика копилки", runPiggy},
+	{"calendar", "calendar [-year 2100] [-date ГГГГ-ММ-ДД] [-reform 1582|1918]  високосные годы и юлианский календарь", runCalendar},
+	{"workdays", "workdays [-from дата] [-to дата] [-add N] [-holidays файл] [-weekends список] [-lang ru|en]  производственный календарь", runWorkdays},
+	{"date", "date [-at \"ГГГГ-ММ-ДД ЧЧ:ММ\"] [-now момент] [-lang ru|en] [-locale файл] [-clock 12|24] [-pattern шаблон]  дата и время словами", runDate},
+	{"unix", "unix [-ts N] [-unit s|ms|us|ns] [-at \"ГГГГ-ММ-ДД ЧЧ:ММ\"]  время Unix с проверкой переполнения", runUnix},
+	{"bits", "bits [-type int8] [-set|-clear|-toggle|-test список] [-field 4:7] [-rotate N] [-steps N [-add N]] число  биты целого числа", runBits},
+	{"countdown", "countdown [-from N] [-interval 1s] [-abort N] [-seed N] [-fake]  обратный отсчет с задержками и отменой", runCountdown},
+	{"launch", "launch [-from N] [-interval 1s] [-seed N] [-fake]  предстартовая проверка подсистем", runLaunch},
+	{"cave", "cave [-age N] [-world файл] [-check] [-load файл] [-log файл] [-replay [-update] журнал ...]  текстовое приключение в пещере", runCave},
+}
+
+// runCLI разбирает аргументы командной строки и вызывает подкоманду.
+// Без аргументов выводит справку.
+func runCLI(args []string) error {
+	if len(args) == 0 {
+		printUsage(os.Stdout)
+		return nil
+	}
+	for _, c := range commands {
+		if c.Name == args[0] {
+			return c.Run(args[1:])
+		}
+	}
+	printUsage(os.Stderr)
+	return fmt.Errorf("%w: %s", ErrUnknownCommand, args[0])
+}
+
+// printUsage выводит список подкоманд.
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Использование: go run . <команда> [аргументы]")
+	fmt.Fprintln(w)
+	for _, c := range commands {
+		fmt.Fprintln(w, "  "+c.Usage)
+	}
+}
+
+// runList выводит таблицу примеров: имя, темы и описание.
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	tag := fs.String("tag", "", "показать только примеры этой темы")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	for _, e := range GetExamplesByTag(*tag) {
+		fmt.Printf("%-4v %-32v %v\n", e.Name, strings.Join(e.Tags, ","), e.Description)
+	}
+	return nil
+}
+
+// runExamples запускает примеры, перечисленные по имени, и все примеры темы -tag.
+// Если запускается больше одного примера, перед каждым выводится заголовок.
+func runExamples(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	tag := fs.String("tag", "", "запустить все примеры этой темы")
+	seed := fs.Int64("seed", 0, "зерно генератора случайных чисел (0 - случайное)")
+	now := fs.String("now", "", "поддельное текущее время в формате RFC3339; паузы выполняются мгновенно")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	selected, err := selectExamples(*tag, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	env, err := newRunEnv(*seed, *now)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range selected {
+		if len(selected) > 1 {
+			fmt.Fprintf(env.Out, "== %v: %v ==\n", e.Name, e.Description)
+		}
+		e.Run(env)
+		if len(selected) > 1 {
+			fmt.Fprintln(env.Out)
+		}
+	}
+	return nil
+}
+
+// newRunEnv создает окружение для подкоманды run.
+// Нулевое зерно заменяется случайным, которое выводится в os.Stderr,
+// чтобы запуск можно было повторить флагом -seed.
+// Непустой now включает поддельные часы.
+func newRunEnv(seed int64, now string) (*Env, error) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+		fmt.Fprintf(os.Stderr, "seed: %d\n", seed)
+	}
+	if now == "" {
+		return NewEnv(seed), nil
+	}
+
+	start, err := time.Parse(time.RFC3339, now)
+	if err != nil {
+		return nil, fmt.Errorf("флаг -now: %w", err)
+	}
+	return NewFakeEnv(seed, start, os.Stdout), nil
+}
+
+// selectExamples собирает примеры по теме и по именам без повторов.
+// Если не задано ни темы, ни имен, возвращает ошибку.
+func selectExamples(tag string, names []string) ([]Example, error) {
+	if tag == "" && len(names) == 0 {
+		return nil, errors.New("укажите имя примера или -tag")
+	}
+
+	var selected []Example
+	isSelected := map[string]bool{}
+	if tag != "" {
+		for _, e := range GetExamplesByTag(tag) {
+			selected = append(selected, e)
+			isSelected[e.Name] = true
+		}
+	}
+	for _, name := range names {
+		e, ok := GetExample(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownExample, name)
+		}
+		if !isSelected[name] {
+			selected = append(selected, e)
+			isSelected[name] = true
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"example/internal/clock"
+	co "example/internal/constants"
+	"example/internal/countdown"
+)
+
+// runCountdown ведет отсчет из f23 и f25 с управлением со стандартного ввода:
+// "hold [причина]" приостанавливает отсчет, "resume" продолжает, "abort [причина]" отменяет запуск.
+// Ctrl+C тоже отменяет запуск: сигнал отменяет контекст отсчета из другой горутины.
+func runCountdown(args []string) error {
+	fs := flag.NewFlagSet("countdown", flag.ContinueOnError)
+	from := fs.Int("from", co.CountdownFrom, "с какого числа начинать отсчет")
+	interval := fs.Duration("interval", time.Second, "шаг отсчета")
+	chance := fs.Int("abort", co.CountdownAbortChance, "на каждом шаге запуск отменяется с шансом 1 к N, как в f25 (0 - без случайной отмены)")
+	seed := fs.Int64("seed", 0, "зерно генератора случайных чисел (0 - случайное)")
+	isFake := fs.Bool("fake", false, "поддельные часы: отсчет выполняется мгновенно")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var checks []countdown.Check
+	if *chance > 0 {
+		if *seed == 0 {
+			*seed = time.Now().UnixNano()
+			fmt.Fprintf(os.Stderr, "seed: %d\n", *seed)
+		}
+		checks = append(checks, countdown.RandomAbort(rand.New(rand.NewSource(*seed)), *chance))
+	}
+	var c clock.Clock = clock.Real{}
+	if *isFake {
+		c = clock.NewFake(time.Now())
+	}
+	cd, err := countdown.New(*from, *interval, c, checks...)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go controlCountdown(cd, os.Stdin)
+
+	result := make(chan error, 1)
+	go func() { result <- cd.Run(ctx) }()
+
+	var start time.Time // время первого события, от него считаются остальные
+	for e := range cd.Events() {
+		if start.IsZero() {
+			start = e.Time
+		}
+		fmt.Printf("%6v  %v\n", e.Time.Sub(start).Round(time.Millisecond), e)
+	}
+	if err := <-result; err != nil {
+		fmt.Println("Запуск отменяется.")
+		return err
+	}
+	return nil
+}
+
+// controlCountdown читает команды отсчета из r, пока отсчет не завершится или ввод не кончится.
+func controlCountdown(cd *countdown.Countdown, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		name, reason, _ := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		var err error
+		switch name {
+		case "hold":
+			err = cd.Hold(reason)
+		case "resume":
+			err = cd.Resume()
+		case "abort":
+			if reason == "" {
+				reason = "отменено оператором"
+			}
+			err = cd.Abort(reason)
+		case "":
+			continue
+		default:
+			fmt.Fprintf(os.Stderr, "неизвестная команда %q, есть hold [причина], resume, abort [причина]\n", name)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
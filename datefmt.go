@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"example/internal/datefmt"
+)
+
+// dateLayouts - форматы флагов -at и -now: дата со временем или только дата.
+var dateLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"}
+
+// runDate печатает момент времени словами, как f26 и f31, но на выбранном языке:
+// дату с месяцем в нужном падеже, день недели, время по 12- или 24-часовым часам
+// и сколько до этого момента осталось или прошло.
+func runDate(args []string) error {
+	fs := flag.NewFlagSet("date", flag.ContinueOnError)
+	at := fs.String("at", "", "момент \"ГГГГ-ММ-ДД ЧЧ:ММ\" или дата ГГГГ-ММ-ДД (по умолчанию сейчас)")
+	nowFlag := fs.String("now", "", "момент, относительно которого описывается -at (по умолчанию сейчас)")
+	lang := fs.String("lang", "ru", "встроенный язык: ru или en")
+	localeFile := fs.String("locale", "", "JSON-файл языка вместо встроенного, см. internal/datefmt/locales")
+	hours := fs.Int("clock", 0, "часы: 12 или 24 (по умолчанию принятые в языке)")
+	pattern := fs.String("pattern", "", "свой шаблон, например \"{weekday}, {day} {month:genitive}\"")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		l   *datefmt.Locale
+		err error
+	)
+	if *localeFile != "" {
+		l, err = datefmt.LoadLocaleFile(*localeFile)
+	} else {
+		l, err = datefmt.GetLocale(*lang)
+	}
+	if err != nil {
+		return err
+	}
+	c := datefmt.HourClock(*hours)
+	if c != datefmt.ClockDefault && c != datefmt.Clock12 && c != datefmt.Clock24 {
+		return fmt.Errorf("флаг -clock: %d, ожидалось 12 или 24", *hours)
+	}
+
+	now := time.Now()
+	if *nowFlag != "" {
+		if now, err = parseMoment(*nowFlag); err != nil {
+			return fmt.Errorf("флаг -now: %w", err)
+		}
+	}
+	t := now
+	if *at != "" {
+		if t, err = parseMoment(*at); err != nil {
+			return fmt.Errorf("флаг -at: %w", err)
+		}
+	}
+
+	if *pattern != "" {
+		s, err := l.Format(t, *pattern)
+		if err != nil {
+			return fmt.Errorf("флаг -pattern: %w", err)
+		}
+		fmt.Println(s)
+		return nil
+	}
+	fmt.Printf("Дата:         %s\n", l.FormatDate(t))
+	fmt.Printf("День недели:  %s (%s)\n", l.FormatWeekday(t), l.FormatOnWeekday(t))
+	fmt.Printf("Время:        %s\n", l.FormatTime(t, c))
+	fmt.Printf("Относительно: %s\n", l.FormatRelative(t, now))
+	return nil
+}
+
+// parseMoment разбирает момент в местном часовом поясе в одном из форматов dateLayouts.
+func parseMoment(s string) (time.Time, error) {
+	var err error
+	for _, layout := range dateLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q, ожидалось \"ГГГГ-ММ-ДД ЧЧ:ММ\" или ГГГГ-ММ-ДД: %w", s, err)
+}
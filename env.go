@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io"
+	"math/rand"
+	"os"
+	"time"
+
+	"example/internal/clock"
+)
+
+// Env - окружение, в котором выполняется пример.
+// Все случайные числа, время и вывод примеры получают только через него,
+// поэтому запуск с тем же зерном и поддельными часами повторяется один в один.
+type Env struct {
+	Rand  *rand.Rand  // генератор случайных чисел
+	Clock clock.Clock // источник времени и пауз
+	Out   io.Writer   // куда пример печатает результат
+}
+
+// NewEnv создает окружение с генератором, инициализированным зерном seed,
+// системными часами и выводом в os.Stdout.
+func NewEnv(seed int64) *Env {
+	return &Env{
+		Rand:  rand.New(rand.NewSource(seed)),
+		Clock: clock.Real{},
+		Out:   os.Stdout,
+	}
+}
+
+// NewFakeEnv создает воспроизводимое окружение: генератор с зерном seed,
+// поддельные часы, показывающие now, и вывод в out.
+func NewFakeEnv(seed int64, now time.Time, out io.Writer) *Env {
+	return &Env{
+		Rand:  rand.New(rand.NewSource(seed)),
+		Clock: clock.NewFake(now),
+		Out:   out,
+	}
+}
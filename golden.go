@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// goldenDir - каталог эталонных выводов по умолчанию.
+const goldenDir = "testdata/golden"
+
+// ErrGoldenMismatch возвращается, если вывод хотя бы одного примера разошелся с эталоном.
+var ErrGoldenMismatch = errors.New("вывод примеров не совпал с эталоном")
+
+// goldenSeed - зерно генератора, с которым записаны эталоны.
+const goldenSeed = 1
+
+// goldenNow - показание поддельных часов при записи эталонов:
+// вторник 13 октября 2020 года, день отправления билетов из f43.
+var goldenNow = time.Date(2020, time.October, 13, 9, 30, 0, 0, time.UTC)
+
+// goldenResult - итог сравнения одного примера с эталоном.
+type goldenResult struct {
+	Name   string
+	Status string // ok, FAIL или updated
+	Detail string // описание расхождения
+}
+
+// runGolden сравнивает вывод примеров с эталонными файлами testdata/golden/<имя>.golden.
+// С флагом -update эталоны перезаписываются текущим выводом.
+func runGolden(args []string) error {
+	fs := flag.NewFlagSet("golden", flag.ContinueOnError)
+	isUpdate := fs.Bool("update", false, "перезаписать эталоны текущим выводом")
+	dir := fs.String("dir", goldenDir, "каталог эталонов")
+	tag := fs.String("tag", "", "проверить только примеры этой темы")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	selected := GetExamplesByTag(*tag)
+	if len(fs.Args()) > 0 {
+		var err error
+		if selected, err = selectExamples(*tag, fs.Args()); err != nil {
+			return err
+		}
+	}
+
+	// time.Unix печатает время в локальной зоне, поэтому эталоны пишутся в UTC
+	local := time.Local
+	time.Local = time.UTC
+	defer func() { time.Local = local }()
+
+	isFailed := false
+	for _, e := range selected {
+		res, err := checkGolden(e, *dir, *isUpdate)
+		if err != nil {
+			return err
+		}
+		if res.Status == "FAIL" {
+			isFailed = true
+		}
+		if res.Detail == "" {
+			fmt.Printf("%-7v %v\n", res.Status, res.Name)
+		} else {
+			fmt.Printf("%-7v %v: %v\n", res.Status, res.Name, res.Detail)
+		}
+	}
+
+	if isFailed {
+		return fmt.Errorf("%w (перезапишите эталоны флагом -update, если изменение ожидаемо)", ErrGoldenMismatch)
+	}
+	return nil
+}
+
+// checkGolden запускает пример и сравнивает его вывод с эталоном из dir.
+// Ошибка возвращается только при проблемах с файлами, расхождение - в goldenResult.
+func checkGolden(e Example, dir string, isUpdate bool) (goldenResult, error) {
+	var out bytes.Buffer
+	e.Run(NewFakeEnv(goldenSeed, goldenNow, &out))
+	got := out.Bytes()
+
+	path := filepath.Join(dir, e.Name+".golden")
+	if isUpdate {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return goldenResult{}, err
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			return goldenResult{}, err
+		}
+		return goldenResult{Name: e.Name, Status: "updated"}, nil
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return goldenResult{Name: e.Name, Status: "FAIL", Detail: "нет эталона " + path}, nil
+	}
+	if err != nil {
+		return goldenResult{}, err
+	}
+
+	if diff := calcFirstDiff(string(want), string(got)); diff != "" {
+		return goldenResult{Name: e.Name, Status: "FAIL", Detail: diff}, nil
+	}
+	return goldenResult{Name: e.Name, Status: "ok"}, nil
+}
+
+// calcFirstDiff возвращает описание первой различающейся строки
+// или пустую строку, если тексты совпадают.
+func calcFirstDiff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g || i >= len(wantLines) || i >= len(gotLines) {
+			return fmt.Sprintf("строка %d: ожидалось %q, получено %q", i+1, w, g)
+		}
+	}
+	return ""
+}
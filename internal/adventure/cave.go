@@ -0,0 +1,20 @@
+package adventure
+
+import _ "embed"
+
+// caveYAML - мир по умолчанию: пещера из f15, f16, f18, f20, f21 и f22.
+//
+//go:embed worlds/cave.yaml
+var caveYAML []byte
+
+// NewCaveWorld возвращает мир по умолчанию - пещеру из примеров:
+// у входа висит знак из f16, в пещеру пускают только совершеннолетних,
+// внутри темно, пока не зажжешь факел из f20, а лед на озере проваливается цепочкой событий, как fallthrough в f22.
+// Файл мира встроен в программу и проверен, поэтому ошибка разбора - это ошибка программиста и вызывает панику.
+func NewCaveWorld() *World {
+	w, err := ParseWorld(caveYAML, FormatYAML)
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
@@ -0,0 +1,201 @@
+package adventure
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// decoder переносит дерево node в World. Ошибки не прерывают разбор, а копятся,
+// чтобы автор мира увидел все проблемы файла сразу.
+type decoder struct {
+	errs  []*FieldError
+	lines map[string]int // путь к полю -> строка файла, для ошибок проверки мира
+}
+
+// decodeWorld строит мир из корня документа.
+func decodeWorld(root *node) (*World, map[string]int, []*FieldError) {
+	d := &decoder{lines: map[string]int{}}
+	w := &World{}
+	d.object(root, "", map[string]func(v *node, path string){
+		"title": func(v *node, path string) { w.Title = d.str(v, path) },
+		"start": func(v *node, path string) { w.Start = d.str(v, path) },
+		"rooms": func(v *node, path string) {
+			d.list(v, path, func(v *node, path string) { w.Rooms = append(w.Rooms, d.room(v, path)) })
+		},
+		"items": func(v *node, path string) {
+			d.list(v, path, func(v *node, path string) { w.Items = append(w.Items, d.item(v, path)) })
+		},
+		"events": func(v *node, path string) {
+			d.list(v, path, func(v *node, path string) { w.Events = append(w.Events, d.event(v, path)) })
+		},
+	})
+	return w, d.lines, d.errs
+}
+
+// room разбирает комнату.
+func (d *decoder) room(n *node, path string) Room {
+	var r Room
+	d.object(n, path, map[string]func(v *node, path string){
+		"id":          func(v *node, path string) { r.ID = d.str(v, path) },
+		"description": func(v *node, path string) { r.Description = d.str(v, path) },
+		"dark":        func(v *node, path string) { r.IsDark = d.bool(v, path) },
+		"items":       func(v *node, path string) { r.Items = d.strs(v, path) },
+		"on_enter":    func(v *node, path string) { r.OnEnter = d.str(v, path) },
+		"exits": func(v *node, path string) {
+			d.list(v, path, func(v *node, path string) { r.Exits = append(r.Exits, d.exit(v, path)) })
+		},
+	})
+	return r
+}
+
+// exit разбирает выход.
+func (d *decoder) exit(n *node, path string) Exit {
+	var e Exit
+	d.object(n, path, map[string]func(v *node, path string){
+		"names":     func(v *node, path string) { e.Names = d.strs(v, path) },
+		"to":        func(v *node, path string) { e.To = d.str(v, path) },
+		"message":   func(v *node, path string) { e.Message = d.str(v, path) },
+		"condition": func(v *node, path string) { e.Condition = d.condition(v, path) },
+		"denied":    func(v *node, path string) { e.Denied = d.str(v, path) },
+		"lock":      func(v *node, path string) { e.Lock = d.str(v, path) },
+		"key":       func(v *node, path string) { e.Key = d.str(v, path) },
+	})
+	return e
+}
+
+// condition разбирает условие выхода или события.
+func (d *decoder) condition(n *node, path string) Condition {
+	var c Condition
+	d.object(n, path, map[string]func(v *node, path string){
+		"min_age":  func(v *node, path string) { c.MinAge = d.int(v, path) },
+		"has_item": func(v *node, path string) { c.HasItem = d.str(v, path) },
+		"is_lit":   func(v *node, path string) { c.IsLit = d.str(v, path) },
+		"flag":     func(v *node, path string) { c.Flag = d.str(v, path) },
+	})
+	return c
+}
+
+// item разбирает предмет.
+func (d *decoder) item(n *node, path string) Item {
+	var it Item
+	d.object(n, path, map[string]func(v *node, path string){
+		"id":          func(v *node, path string) { it.ID = d.str(v, path) },
+		"name":        func(v *node, path string) { it.Name = d.str(v, path) },
+		"aliases":     func(v *node, path string) { it.Aliases = d.strs(v, path) },
+		"description": func(v *node, path string) { it.Description = d.str(v, path) },
+		"text":        func(v *node, path string) { it.Text = d.str(v, path) },
+		"portable":    func(v *node, path string) { it.IsPortable = d.bool(v, path) },
+		"can_light":   func(v *node, path string) { it.CanLight = d.bool(v, path) },
+	})
+	return it
+}
+
+// event разбирает событие.
+func (d *decoder) event(n *node, path string) Event {
+	var e Event
+	d.object(n, path, map[string]func(v *node, path string){
+		"id":        func(v *node, path string) { e.ID = d.str(v, path) },
+		"text":      func(v *node, path string) { e.Text = d.str(v, path) },
+		"condition": func(v *node, path string) { e.Condition = d.condition(v, path) },
+		"set_flag":  func(v *node, path string) { e.SetFlag = d.str(v, path) },
+		"move_to":   func(v *node, path string) { e.MoveTo = d.str(v, path) },
+		"next":      func(v *node, path string) { e.Next = d.str(v, path) },
+	})
+	return e
+}
+
+// object вызывает обработчик для каждого поля объекта n и запоминает строки полей.
+// Поле без обработчика - ошибка: опечатка в ключе не должна молча пропадать.
+func (d *decoder) object(n *node, path string, handlers map[string]func(v *node, path string)) {
+	if n.kind != mapNode {
+		d.fail(n.line, path, fmt.Errorf("%w: ожидался объект, получено %v", ErrInvalidValue, n.kind))
+		return
+	}
+	for _, f := range n.fields {
+		fieldPath := joinPath(path, f.key)
+		d.lines[fieldPath] = f.line
+		handle, ok := handlers[f.key]
+		if !ok {
+			d.fail(f.line, fieldPath, fmt.Errorf("%w, ожидалось одно из: %s", ErrUnknownField, listKeys(handlers)))
+			continue
+		}
+		handle(f.value, fieldPath)
+	}
+}
+
+// list вызывает each для каждого элемента списка n.
+func (d *decoder) list(n *node, path string, each func(v *node, path string)) {
+	if n.kind == scalarNode && n.value == "" {
+		return // пустое значение - пустой список
+	}
+	if n.kind != listNode {
+		d.fail(n.line, path, fmt.Errorf("%w: ожидался список, получено %v", ErrInvalidValue, n.kind))
+		return
+	}
+	for i, item := range n.items {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		d.lines[itemPath] = item.line
+		each(item, itemPath)
+	}
+}
+
+// str возвращает строковое значение.
+func (d *decoder) str(n *node, path string) string {
+	if n.kind != scalarNode {
+		d.fail(n.line, path, fmt.Errorf("%w: ожидалась строка, получено %v", ErrInvalidValue, n.kind))
+		return ""
+	}
+	return n.value
+}
+
+// strs возвращает список строк. Одна строка тоже считается списком: names: наружу.
+func (d *decoder) strs(n *node, path string) []string {
+	if n.kind == scalarNode {
+		if n.value == "" {
+			return nil
+		}
+		return []string{n.value}
+	}
+	var values []string
+	d.list(n, path, func(v *node, path string) { values = append(values, d.str(v, path)) })
+	return values
+}
+
+// bool возвращает логическое значение true или false.
+func (d *decoder) bool(n *node, path string) bool {
+	value, err := strconv.ParseBool(d.str(n, path))
+	if err != nil && n.kind == scalarNode {
+		d.fail(n.line, path, fmt.Errorf("%w: ожидалось true или false, получено %q", ErrInvalidValue, n.value))
+	}
+	return value
+}
+
+// int возвращает целое число.
+func (d *decoder) int(n *node, path string) int {
+	value, err := strconv.Atoi(d.str(n, path))
+	if err != nil && n.kind == scalarNode {
+		d.fail(n.line, path, fmt.Errorf("%w: ожидалось целое число, получено %q", ErrInvalidValue, n.value))
+	}
+	return value
+}
+
+// fail запоминает ошибку в строке line для поля path.
+func (d *decoder) fail(line int, path string, err error) {
+	d.errs = append(d.errs, &FieldError{Line: line, Field: path, Err: err})
+}
+
+// joinPath добавляет к пути поле key: "rooms[0]" + "id" = "rooms[0].id".
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// listKeys перечисляет допустимые ключи через запятую в алфавитном порядке.
+func listKeys(handlers map[string]func(v *node, path string)) string {
+	return strings.Join(slices.Sorted(maps.Keys(handlers)), ", ")
+}
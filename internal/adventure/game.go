@@ -0,0 +1,418 @@
+package adventure
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// ErrUnknownRoom возвращается, если комнаты с таким ID нет в мире.
+var ErrUnknownRoom = errors.New("неизвестная комната")
+
+// Inventory - место предмета, который игрок несет с собой.
+const Inventory = "@inventory"
+
+// Сообщения игры.
+const (
+	msgDark          = "Ничего не видно."
+	msgUnknown       = "Пока не совсем понятно."
+	msgNoExit        = "Туда не пройти."
+	msgNoItem        = "Здесь нет такого предмета."
+	msgNotCarried    = "У вас нет такого предмета."
+	msgNotPortable   = "Это не унести."
+	msgNothingToRead = "Здесь нечего читать."
+	msgCannotLight   = "Это не зажечь."
+	msgAlreadyLit    = "Уже горит."
+	msgEmptyHands    = "У вас ничего нет."
+	msgBye           = "До встречи!"
+	msgLocked        = "Заперто."
+	msgNotLocked     = "Здесь нечего отпирать."
+	msgNoKey         = "Нечем отпереть."
+	msgUnlocked      = "Вы отперли дверь."
+	msgNoSave        = "Сохранять и загружать игру можно только в интерактивной партии."
+	msgHint          = "Может быть, вы имели в виду «%s»?"
+	msgHelp          = "Команды: идти <куда>, осмотреться, осмотреть <что>, взять <что>, бросить <что>, зажечь <что>, " +
+		"прочитать <что>, открыть <куда>, инвентарь, сохранить [файл], загрузить [файл], помощь, конец. " +
+		"Понятны и английские команды: go east, take torch, look."
+)
+
+// State - состояние игрока. В отличие от World меняется после каждой команды.
+// Поля помечены тегами JSON, потому что состояние хранится в файлах сохранений и журналах.
+type State struct {
+	Room      string            `json:"room"`      // ID комнаты, где находится игрок
+	Age       int               `json:"age"`       // возраст игрока для условий вроде f16
+	Locations map[string]string `json:"locations"` // ID предмета -> ID комнаты или Inventory
+	Lit       map[string]bool   `json:"lit"`       // ID предмета -> горит ли он
+	Flags     map[string]bool   `json:"flags"`     // флаги событий и отпертых дверей
+	Moves     int               `json:"moves"`     // число выполненных команд
+}
+
+// NewState возвращает начальное состояние игрока возраста age в мире w.
+func NewState(w *World, age int) State {
+	s := State{
+		Room:      w.Start,
+		Age:       age,
+		Locations: map[string]string{},
+		Lit:       map[string]bool{},
+		Flags:     map[string]bool{},
+	}
+	for _, room := range w.Rooms {
+		for _, id := range room.Items {
+			s.Locations[id] = room.ID
+		}
+	}
+	return s
+}
+
+// HasItem проверяет, несет ли игрок предмет id.
+func (s State) HasItem(id string) bool {
+	return s.Locations[id] == Inventory
+}
+
+// HasLight проверяет, несет ли игрок горящий предмет.
+func (s State) HasLight() bool {
+	for id, isLit := range s.Lit {
+		if isLit && s.HasItem(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Clone возвращает копию состояния, которую можно менять независимо от исходного.
+func (s State) Clone() State {
+	s.Locations = maps.Clone(s.Locations)
+	s.Lit = maps.Clone(s.Lit)
+	s.Flags = maps.Clone(s.Flags)
+	return s
+}
+
+// Result - итог одной команды.
+type Result struct {
+	Output string // текст для игрока, строки разделены \n
+	IsOver bool   // игрок завершил игру
+}
+
+// Game - партия в мире World.
+// Не является потокобезопасной: команды одной партии выполняются по очереди.
+type Game struct {
+	world *World
+	state State
+}
+
+// NewGame начинает партию в мире w для игрока возраста age.
+func NewGame(w *World, age int) (*Game, error) {
+	if _, ok := w.GetRoom(w.Start); !ok {
+		return nil, fmt.Errorf("%w: начальная комната %q", ErrUnknownRoom, w.Start)
+	}
+	return &Game{world: w, state: NewState(w, age)}, nil
+}
+
+// GetWorld возвращает мир партии.
+func (g *Game) GetWorld() *World { return g.world }
+
+// GetState возвращает копию текущего состояния игрока.
+func (g *Game) GetState() State { return g.state.Clone() }
+
+// Look описывает комнату, где находится игрок: описание, предметы и выходы.
+// В темной комнате без горящего предмета видно только "Ничего не видно.", как в f20.
+func (g *Game) Look() string {
+	room, _ := g.world.GetRoom(g.state.Room)
+	if room.IsDark && !g.state.HasLight() {
+		return msgDark
+	}
+
+	lines := []string{room.Description}
+	if names := g.calcItemNames(room.ID); len(names) > 0 {
+		lines = append(lines, "Здесь есть: "+strings.Join(names, ", ")+".")
+	}
+	var exits []string
+	for _, e := range room.Exits {
+		if len(e.Names) > 0 {
+			exits = append(exits, e.Names[0])
+		}
+	}
+	if len(exits) > 0 {
+		lines = append(lines, "Выходы: "+strings.Join(exits, ", ")+".")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Exec выполняет строку, введенную игроком.
+func (g *Game) Exec(line string) Result {
+	cmd := ParseCommand(line)
+	g.state.Moves++
+
+	switch cmd.Verb {
+	case VerbGo:
+		return Result{Output: g.goTo(cmd.Object)}
+	case VerbLook:
+		if cmd.Object != "" {
+			return Result{Output: g.examine(cmd.Object)}
+		}
+		return Result{Output: g.Look()}
+	case VerbTake:
+		return Result{Output: g.take(cmd.Object)}
+	case VerbDrop:
+		return Result{Output: g.drop(cmd.Object)}
+	case VerbLight:
+		return Result{Output: g.light(cmd.Object)}
+	case VerbRead:
+		return Result{Output: g.read(cmd.Object)}
+	case VerbOpen:
+		return Result{Output: g.open(cmd.Object)}
+	case VerbInventory:
+		return Result{Output: g.listInventory()}
+	case VerbHelp:
+		return Result{Output: msgHelp}
+	case VerbQuit:
+		return Result{Output: msgBye, IsOver: true}
+	case VerbSave, VerbLoad:
+		// работа с файлами - забота Session, сама партия о них не знает
+		return Result{Output: msgNoSave}
+	}
+
+	// "наружу" без глагола - тоже переход, если так называется выход
+	if _, ok := g.findExit(cmd.Object); ok {
+		return Result{Output: g.goTo(cmd.Object)}
+	}
+	if hint, ok := calcVerbHint(cmd.Words); ok {
+		return Result{Output: msgUnknown + " " + fmt.Sprintf(msgHint, hint)}
+	}
+	return Result{Output: withHint(msgUnknown, cmd.Object, g.calcExitNames())}
+}
+
+// goTo переводит игрока через выход name, если выполнено его условие.
+func (g *Game) goTo(name string) string {
+	exit, ok := g.findExit(name)
+	if !ok {
+		return withHint(msgNoExit, name, g.calcExitNames())
+	}
+	if _, ok := g.world.GetRoom(exit.To); !ok {
+		return msgNoExit
+	}
+	if exit.IsLocked(g.state) {
+		return msgLocked
+	}
+	if !exit.Condition.IsMet(g.state) {
+		if exit.Denied == "" {
+			return msgNoExit
+		}
+		return exit.Denied
+	}
+
+	var lines []string
+	if exit.Message != "" {
+		lines = append(lines, exit.Message)
+	}
+	g.state.Room = exit.To
+	lines = append(lines, g.Look())
+
+	room, _ := g.world.GetRoom(exit.To)
+	if room.OnEnter != "" {
+		lines = append(lines, g.runEvents(room.OnEnter)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// runEvents выполняет цепочку событий, начиная с id, и возвращает их текст.
+// Цепочка обрывается на событии с невыполненным условием. Если событие перенесло игрока,
+// в конце выводится описание новой комнаты; ее собственное OnEnter не запускается.
+// Число шагов ограничено числом событий мира, чтобы цикл в непроверенном мире не зациклил игру.
+func (g *Game) runEvents(id string) []string {
+	var lines []string
+	isMoved := false
+	for step := 0; id != "" && step < len(g.world.Events); step++ {
+		event, ok := g.world.GetEvent(id)
+		if !ok || !event.Condition.IsMet(g.state) {
+			break
+		}
+		if event.Text != "" {
+			lines = append(lines, event.Text)
+		}
+		if event.SetFlag != "" {
+			g.state.Flags[event.SetFlag] = true
+		}
+		if _, ok := g.world.GetRoom(event.MoveTo); ok {
+			g.state.Room = event.MoveTo
+			isMoved = true
+		}
+		id = event.Next
+	}
+	if isMoved {
+		lines = append(lines, g.Look())
+	}
+	return lines
+}
+
+// open отпирает запертую дверь выхода name ключом из инвентаря.
+func (g *Game) open(name string) string {
+	exit, ok := g.findExit(name)
+	if !ok {
+		return withHint(msgNoExit, name, g.calcExitNames())
+	}
+	if !exit.IsLocked(g.state) {
+		return msgNotLocked
+	}
+	if exit.Key == "" || !g.state.HasItem(exit.Key) {
+		return msgNoKey
+	}
+	g.state.Flags[exit.Lock] = true
+	return msgUnlocked
+}
+
+// take кладет предмет из комнаты в инвентарь.
+func (g *Game) take(name string) string {
+	item, ok := g.findItem(name, g.state.Room)
+	if !ok || g.isHidden() {
+		return withHint(msgNoItem, name, g.calcItemHints(g.state.Room))
+	}
+	if !item.IsPortable {
+		return msgNotPortable
+	}
+	g.state.Locations[item.ID] = Inventory
+	return "Вы взяли: " + item.Name + "."
+}
+
+// drop оставляет предмет из инвентаря в текущей комнате.
+func (g *Game) drop(name string) string {
+	item, ok := g.findItem(name, Inventory)
+	if !ok {
+		return withHint(msgNotCarried, name, g.calcItemHints(Inventory))
+	}
+	g.state.Locations[item.ID] = g.state.Room
+	return "Вы оставили: " + item.Name + "."
+}
+
+// light зажигает предмет из инвентаря, например факел из f20.
+func (g *Game) light(name string) string {
+	item, ok := g.findItem(name, Inventory)
+	if !ok {
+		return withHint(msgNotCarried, name, g.calcItemHints(Inventory))
+	}
+	if !item.CanLight {
+		return msgCannotLight
+	}
+	if g.state.Lit[item.ID] {
+		return msgAlreadyLit
+	}
+	g.state.Lit[item.ID] = true
+	return "Вы зажгли: " + item.Name + "."
+}
+
+// examine описывает предмет в комнате или в инвентаре.
+func (g *Game) examine(name string) string {
+	item, ok := g.findVisibleItem(name)
+	if !ok {
+		return withHint(msgNoItem, name, append(g.calcItemHints(Inventory), g.calcItemHints(g.state.Room)...))
+	}
+	if item.Description == "" {
+		return "Обычный предмет: " + item.Name + "."
+	}
+	return item.Description
+}
+
+// read читает надпись на предмете в комнате или в инвентаре, как знак из f21.
+func (g *Game) read(name string) string {
+	item, ok := g.findVisibleItem(name)
+	if !ok {
+		return withHint(msgNoItem, name, append(g.calcItemHints(Inventory), g.calcItemHints(g.state.Room)...))
+	}
+	if item.Text == "" {
+		return msgNothingToRead
+	}
+	return item.Text
+}
+
+// listInventory перечисляет предметы игрока.
+func (g *Game) listInventory() string {
+	names := g.calcItemNames(Inventory)
+	if len(names) == 0 {
+		return msgEmptyHands
+	}
+	return "У вас есть: " + strings.Join(names, ", ") + "."
+}
+
+// isHidden проверяет, что игрок в темной комнате без света и не видит предметов.
+func (g *Game) isHidden() bool {
+	room, _ := g.world.GetRoom(g.state.Room)
+	return room.IsDark && !g.state.HasLight()
+}
+
+// findExit ищет выход текущей комнаты по названию.
+func (g *Game) findExit(name string) (Exit, bool) {
+	room, _ := g.world.GetRoom(g.state.Room)
+	for _, e := range room.Exits {
+		if e.HasName(name) {
+			return e, true
+		}
+	}
+	return Exit{}, false
+}
+
+// findItem ищет предмет с названием name в месте location: комнате или Inventory.
+func (g *Game) findItem(name, location string) (*Item, bool) {
+	for i := range g.world.Items {
+		item := &g.world.Items[i]
+		if item.HasName(name) && g.state.Locations[item.ID] == location {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// findVisibleItem ищет предмет в инвентаре, а затем в комнате, если в ней светло.
+func (g *Game) findVisibleItem(name string) (*Item, bool) {
+	if item, ok := g.findItem(name, Inventory); ok {
+		return item, true
+	}
+	if g.isHidden() {
+		return nil, false
+	}
+	return g.findItem(name, g.state.Room)
+}
+
+// calcExitNames возвращает названия выходов текущей комнаты для подсказок.
+// В темноте выходов не видно, и подсказывать их нельзя.
+func (g *Game) calcExitNames() []string {
+	if g.isHidden() {
+		return nil
+	}
+	room, _ := g.world.GetRoom(g.state.Room)
+	var names []string
+	for _, e := range room.Exits {
+		names = append(names, e.Names...)
+	}
+	return names
+}
+
+// calcItemHints возвращает названия и синонимы видимых предметов в месте location для подсказок.
+func (g *Game) calcItemHints(location string) []string {
+	if location != Inventory && g.isHidden() {
+		return nil
+	}
+	var names []string
+	for _, item := range g.world.Items {
+		if g.state.Locations[item.ID] == location {
+			names = append(names, item.Name)
+			names = append(names, item.Aliases...)
+		}
+	}
+	return names
+}
+
+// calcItemNames возвращает названия предметов в месте location в порядке описания мира.
+func (g *Game) calcItemNames(location string) []string {
+	var names []string
+	for _, item := range g.world.Items {
+		if g.state.Locations[item.ID] == location {
+			name := item.Name
+			if g.state.Lit[item.ID] {
+				name += " (горит)"
+			}
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,145 @@
+package adventure
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// jsonParser строит дерево node из JSON по токенам json.Decoder,
+// запоминая строку каждого токена.
+type jsonParser struct {
+	dec  *json.Decoder
+	data []byte
+}
+
+// parseJSON разбирает JSON-документ в дерево node.
+func parseJSON(data []byte) (*node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	p := &jsonParser{dec: dec, data: data}
+
+	tok, line, err := p.next()
+	if err != nil {
+		return nil, p.wrapEOF(err, line)
+	}
+	root, err := p.parseValue(tok, line)
+	if err != nil {
+		return nil, err
+	}
+	if _, line, err := p.next(); err != io.EOF {
+		if err != nil {
+			return nil, err
+		}
+		return nil, &FieldError{Line: line, Err: fmt.Errorf("%w: лишние данные после конца документа", ErrSyntax)}
+	}
+	return root, nil
+}
+
+// next читает следующий токен и номер строки, с которой он начинается.
+func (p *jsonParser) next() (json.Token, int, error) {
+	offset := p.dec.InputOffset()
+	tok, err := p.dec.Token()
+	if err == io.EOF {
+		return nil, calcLine(p.data, offset), io.EOF
+	}
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			offset = syntaxErr.Offset
+		}
+		return nil, calcLine(p.data, offset), &FieldError{Line: calcLine(p.data, offset), Err: fmt.Errorf("%w: %v", ErrSyntax, err)}
+	}
+	return tok, calcLine(p.data, skipJSONSpace(p.data, offset)), nil
+}
+
+// parseValue строит узел из токена tok и, для объектов и массивов, следующих за ним токенов.
+func (p *jsonParser) parseValue(tok json.Token, line int) (*node, error) {
+	switch v := tok.(type) {
+	case json.Delim:
+		if v == '{' {
+			return p.parseObject(line)
+		}
+		return p.parseArray(line)
+	case string:
+		return &node{kind: scalarNode, line: line, value: v}, nil
+	case json.Number:
+		return &node{kind: scalarNode, line: line, value: v.String()}, nil
+	case bool:
+		return &node{kind: scalarNode, line: line, value: fmt.Sprint(v)}, nil
+	}
+	return &node{kind: scalarNode, line: line}, nil // null
+}
+
+// parseObject читает поля объекта до закрывающей скобки.
+func (p *jsonParser) parseObject(line int) (*node, error) {
+	n := &node{kind: mapNode, line: line}
+	for {
+		tok, keyLine, err := p.next()
+		if err != nil {
+			return nil, p.wrapEOF(err, keyLine)
+		}
+		if tok == json.Delim('}') {
+			return n, nil
+		}
+		key := tok.(string) // Decoder гарантирует, что ключ объекта - строка
+
+		tok, valueLine, err := p.next()
+		if err != nil {
+			return nil, p.wrapEOF(err, valueLine)
+		}
+		value, err := p.parseValue(tok, valueLine)
+		if err != nil {
+			return nil, err
+		}
+		n.fields = append(n.fields, field{key: key, line: keyLine, value: value})
+	}
+}
+
+// parseArray читает элементы массива до закрывающей скобки.
+func (p *jsonParser) parseArray(line int) (*node, error) {
+	n := &node{kind: listNode, line: line}
+	for {
+		tok, itemLine, err := p.next()
+		if err != nil {
+			return nil, p.wrapEOF(err, itemLine)
+		}
+		if tok == json.Delim(']') {
+			return n, nil
+		}
+		item, err := p.parseValue(tok, itemLine)
+		if err != nil {
+			return nil, err
+		}
+		n.items = append(n.items, item)
+	}
+}
+
+// wrapEOF превращает io.EOF посреди документа в синтаксическую ошибку.
+func (p *jsonParser) wrapEOF(err error, line int) error {
+	if err == io.EOF {
+		return &FieldError{Line: line, Err: fmt.Errorf("%w: неожиданный конец файла", ErrSyntax)}
+	}
+	return err
+}
+
+// skipJSONSpace пропускает пробелы и разделители , и :, которые Decoder съедает вместе с токеном.
+func skipJSONSpace(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// calcLine возвращает номер строки (с 1) для смещения offset в data.
+func calcLine(data []byte, offset int64) int {
+	offset = min(max(offset, 0), int64(len(data)))
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
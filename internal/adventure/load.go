@@ -0,0 +1,110 @@
+package adventure
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Format - формат файла мира.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// GetFormat определяет формат файла по расширению: .json, .yaml или .yml.
+func GetFormat(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	}
+	return "", fmt.Errorf("%w: формат файла %q, ожидалось .json, .yaml или .yml", ErrInvalidValue, path)
+}
+
+// ParseWorld разбирает и проверяет мир из данных в формате f.
+// Ошибки возвращаются все сразу через errors.Join, каждая - *FieldError со строкой файла.
+func ParseWorld(data []byte, f Format) (*World, error) {
+	w, errs := parseWorld(data, f)
+	if len(errs) > 0 {
+		return nil, joinFieldErrors(errs)
+	}
+	return w, nil
+}
+
+// LoadWorldFile загружает мир из файла path, формат определяется по расширению.
+func LoadWorldFile(path string) (*World, error) {
+	f, err := GetFormat(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w, errs := parseWorld(data, f)
+	if len(errs) > 0 {
+		for _, err := range errs {
+			err.File = path
+		}
+		return nil, joinFieldErrors(errs)
+	}
+	return w, nil
+}
+
+// parseWorld разбирает, строит и проверяет мир.
+// Ошибкам проверки, у которых нет строки, достается строка ближайшего известного поля.
+func parseWorld(data []byte, f Format) (*World, []*FieldError) {
+	var (
+		root *node
+		err  error
+	)
+	switch f {
+	case FormatJSON:
+		root, err = parseJSON(data)
+	case FormatYAML:
+		root, err = parseYAML(data)
+	default:
+		return nil, []*FieldError{{Err: fmt.Errorf("%w: формат %q", ErrInvalidValue, f)}}
+	}
+	if err != nil {
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) {
+			fieldErr = &FieldError{Err: err}
+		}
+		return nil, []*FieldError{fieldErr}
+	}
+
+	// проверяем мир, даже если часть полей не разобралась: автор файла увидит все ошибки сразу
+	w, lines, errs := decodeWorld(root)
+	for _, err := range w.validate() {
+		err.Line = findLine(lines, err.Field)
+		errs = append(errs, err)
+	}
+	slices.SortStableFunc(errs, func(a, b *FieldError) int { return cmp.Compare(a.Line, b.Line) })
+	return w, errs
+}
+
+// findLine ищет строку поля path, поднимаясь к родителю, если самого поля в файле нет:
+// для пропущенного rooms[2].id вернется строка rooms[2].
+func findLine(lines map[string]int, path string) int {
+	for path != "" {
+		if line, ok := lines[path]; ok {
+			return line
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+package adventure
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrSyntax возвращается, если файл мира не удалось разобрать.
+	ErrSyntax = errors.New("синтаксическая ошибка")
+	// ErrUnknownField возвращается для поля, которого нет в формате мира.
+	ErrUnknownField = errors.New("неизвестное поле")
+	// ErrMissingField возвращается, если не заполнено обязательное поле.
+	ErrMissingField = errors.New("не заполнено обязательное поле")
+	// ErrInvalidValue возвращается для значения неподходящего типа или смысла.
+	ErrInvalidValue = errors.New("неверное значение")
+	// ErrDuplicateID возвращается, если ID комнаты, предмета или события повторяется.
+	ErrDuplicateID = errors.New("повторяющийся ID")
+	// ErrDanglingExit возвращается для выхода или перемещения в несуществующую комнату.
+	ErrDanglingExit = errors.New("выход ведет в несуществующую комнату")
+	// ErrUnreachableRoom возвращается для комнаты, в которую нельзя попасть из начальной.
+	ErrUnreachableRoom = errors.New("в комнату нельзя попасть")
+	// ErrUnknownItem возвращается для ссылки на несуществующий предмет.
+	ErrUnknownItem = errors.New("неизвестный предмет")
+	// ErrUnknownEvent возвращается для ссылки на несуществующее событие.
+	ErrUnknownEvent = errors.New("неизвестное событие")
+	// ErrEventCycle возвращается, если цепочка событий замыкается в кольцо.
+	ErrEventCycle = errors.New("цепочка событий зациклена")
+)
+
+// FieldError - ошибка в описании мира с указанием места: файла, строки и пути к полю,
+// например "cave.yaml:12: rooms[1].exits[0].to: выход ведет в несуществующую комнату: "грот"".
+type FieldError struct {
+	File  string // имя файла, если мир загружен из файла
+	Line  int    // номер строки, 0 - неизвестен
+	Field string // путь к полю: rooms[1].exits[0].to
+	Err   error
+}
+
+// Error форматирует ошибку в стиле компилятора: файл:строка: поле: текст.
+func (e *FieldError) Error() string {
+	var prefix string
+	switch {
+	case e.File != "" && e.Line > 0:
+		prefix = fmt.Sprintf("%s:%d: ", e.File, e.Line)
+	case e.File != "":
+		prefix = e.File + ": "
+	case e.Line > 0:
+		prefix = fmt.Sprintf("строка %d: ", e.Line)
+	}
+	if e.Field != "" {
+		prefix += e.Field + ": "
+	}
+	return prefix + e.Err.Error()
+}
+
+// Unwrap позволяет проверять причину через errors.Is.
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// nodeKind - вид узла разобранного файла.
+type nodeKind int
+
+const (
+	scalarNode nodeKind = iota // строка, число или логическое значение
+	mapNode                    // объект JSON или отображение YAML
+	listNode                   // массив JSON или последовательность YAML
+)
+
+// String возвращает название вида узла для сообщений об ошибках.
+func (k nodeKind) String() string {
+	switch k {
+	case mapNode:
+		return "объект"
+	case listNode:
+		return "список"
+	}
+	return "значение"
+}
+
+// node - узел дерева, в которое разбираются и JSON, и YAML.
+// Общее дерево с номерами строк позволяет одинаково проверять мир из файлов обоих форматов.
+type node struct {
+	kind   nodeKind
+	line   int
+	value  string  // для scalarNode
+	fields []field // для mapNode, в порядке файла
+	items  []*node // для listNode
+}
+
+// field - пара ключ-значение объекта.
+type field struct {
+	key   string
+	line  int // строка ключа
+	value *node
+}
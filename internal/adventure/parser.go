@@ -0,0 +1,251 @@
+package adventure
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Verb - действие команды.
+type Verb string
+
+const (
+	VerbGo        Verb = "go"
+	VerbLook      Verb = "look"
+	VerbTake      Verb = "take"
+	VerbDrop      Verb = "drop"
+	VerbLight     Verb = "light"
+	VerbRead      Verb = "read"
+	VerbOpen      Verb = "open"
+	VerbInventory Verb = "inventory"
+	VerbHelp      Verb = "help"
+	VerbQuit      Verb = "quit"
+	VerbSave      Verb = "save"
+	VerbLoad      Verb = "load"
+)
+
+// verbs - синонимы действий на русском и английском. Ключи записаны в нормализованном
+// виде (см. Tokenize), составные глаголы вроде "pick up" - через пробел.
+var verbs = map[string]Verb{
+	"идти": VerbGo, "иди": VerbGo, "пойти": VerbGo, "пойди": VerbGo, "зайти": VerbGo, "зайди": VerbGo,
+	"войти": VerbGo, "войди": VerbGo, "выйти": VerbGo, "выйди": VerbGo, "бежать": VerbGo, "беги": VerbGo,
+	"go": VerbGo, "walk": VerbGo, "run": VerbGo, "enter": VerbGo, "move": VerbGo,
+
+	"осмотреться": VerbLook, "оглядеться": VerbLook, "осмотреть": VerbLook, "осмотри": VerbLook,
+	"смотреть": VerbLook, "смотри": VerbLook, "изучить": VerbLook,
+	"look": VerbLook, "l": VerbLook, "examine": VerbLook, "x": VerbLook, "look at": VerbLook, "inspect": VerbLook,
+
+	"взять": VerbTake, "возьми": VerbTake, "брать": VerbTake, "подобрать": VerbTake, "подними": VerbTake, "поднять": VerbTake,
+	"take": VerbTake, "get": VerbTake, "grab": VerbTake, "pick up": VerbTake, "pick": VerbTake,
+
+	"бросить": VerbDrop, "брось": VerbDrop, "положить": VerbDrop, "положи": VerbDrop, "оставить": VerbDrop, "оставь": VerbDrop,
+	"drop": VerbDrop, "put down": VerbDrop,
+
+	"зажечь": VerbLight, "зажги": VerbLight, "поджечь": VerbLight, "подожги": VerbLight,
+	"light": VerbLight, "ignite": VerbLight,
+
+	"прочитать": VerbRead, "прочти": VerbRead, "прочесть": VerbRead, "читать": VerbRead, "читай": VerbRead,
+	"read": VerbRead,
+
+	"открыть": VerbOpen, "открой": VerbOpen, "отпереть": VerbOpen, "отопри": VerbOpen,
+	"open": VerbOpen, "unlock": VerbOpen,
+
+	"инвентарь": VerbInventory, "вещи": VerbInventory, "сумка": VerbInventory,
+	"inventory": VerbInventory, "inv": VerbInventory, "i": VerbInventory,
+
+	"помощь": VerbHelp, "справка": VerbHelp, "help": VerbHelp,
+
+	"конец": VerbQuit, "хватит": VerbQuit, "quit": VerbQuit, "q": VerbQuit,
+
+	"сохранить": VerbSave, "сохрани": VerbSave, "save": VerbSave,
+	"загрузить": VerbLoad, "загрузи": VerbLoad, "load": VerbLoad, "restore": VerbLoad,
+}
+
+// directions - синонимы направлений. Названия выходов в мире пишутся по-русски,
+// поэтому английские и короткие варианты приводятся к ним: "go east" = "идти на восток".
+var directions = map[string]string{
+	"east": "восток", "e": "восток", "west": "запад", "w": "запад",
+	"north": "север", "n": "север", "south": "юг", "s": "юг",
+	"in": "внутрь", "inside": "внутрь", "out": "наружу", "outside": "наружу",
+	"back": "назад", "up": "наверх", "down": "вниз", "deeper": "вглубь",
+}
+
+// stopWords - предлоги и артикли, которые не влияют на смысл команды:
+// "зайти в пещеру" и "зайти пещеру", "go to the east" и "go east" равнозначны.
+var stopWords = map[string]bool{
+	"в": true, "во": true, "на": true, "к": true, "ко": true, "с": true, "со": true, "до": true, "за": true,
+	"the": true, "a": true, "an": true, "to": true, "at": true, "into": true,
+}
+
+// Command - разобранная команда игрока: "Идти на восток!" -> {VerbGo, "восток"}.
+// Пустой Verb означает, что действие не распознано; тогда Object - вся команда,
+// потому что "наружу" без глагола может быть названием выхода.
+type Command struct {
+	Verb   Verb
+	Object string   // объект без предлогов, направления приведены к русским названиям
+	Words  []string // все слова команды после Tokenize, для подсказок
+}
+
+// Tokenize делит строку на слова: приводит к нижнему регистру, заменяет ё на е
+// и отбрасывает знаки препинания, поэтому "Зажечь факел!" и "зажечь  факел" дают одно и то же.
+func Tokenize(line string) []string {
+	normalized := strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		switch {
+		case r == 'ё':
+			return 'е'
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return r
+		}
+		return ' '
+	}, line)
+	return strings.Fields(normalized)
+}
+
+// ParseCommand разбирает строку игрока: действие ищется сначала среди составных глаголов
+// из двух слов, затем среди одиночных; остальные слова - объект.
+func ParseCommand(line string) Command {
+	words := Tokenize(line)
+	if len(words) == 0 {
+		return Command{}
+	}
+	if len(words) > 1 {
+		if verb, ok := verbs[words[0]+" "+words[1]]; ok {
+			return Command{Verb: verb, Object: normalizeObject(words[2:]), Words: words}
+		}
+	}
+	if verb, ok := verbs[words[0]]; ok {
+		return Command{Verb: verb, Object: normalizeObject(words[1:]), Words: words}
+	}
+	return Command{Object: normalizeObject(words), Words: words}
+}
+
+// normalizeObject убирает из слов объекта предлоги и заменяет синонимы направлений.
+func normalizeObject(words []string) string {
+	var kept []string
+	for _, w := range words {
+		if stopWords[w] {
+			continue
+		}
+		if direction, ok := directions[w]; ok {
+			w = direction
+		}
+		kept = append(kept, w)
+	}
+	return strings.Join(kept, " ")
+}
+
+// matchName проверяет, что объект команды object называет то же, что и name из мира.
+// Слова сравниваются по основе, поэтому "открыть решетку" находит выход "решетка".
+func matchName(name, object string) bool {
+	a := strings.Fields(normalizeObject(Tokenize(name)))
+	b := strings.Fields(object)
+	if len(a) == 0 || len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if calcStem(a[i]) != calcStem(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// endings - падежные окончания, которые отбрасывает calcStem, сначала длинные.
+var endings = []string{
+	"ами", "ями", "ого", "его", "ому", "ему",
+	"ой", "ей", "ом", "ем", "ую", "юю", "ая", "яя", "ое", "ее", "ые", "ие", "ых", "их",
+	"а", "я", "у", "ю", "ы", "и", "е", "о", "ь", "й",
+}
+
+// calcStem возвращает грубую основу русского слова без окончания.
+// Основа не короче трех букв, чтобы "юг" и "лед" не превратились в одну букву.
+func calcStem(word string) string {
+	for _, ending := range endings {
+		if stem, ok := strings.CutSuffix(word, ending); ok && utf8.RuneCountInString(stem) >= 3 {
+			return stem
+		}
+	}
+	return word
+}
+
+// suggest ищет среди candidates ближайший к word вариант по расстоянию Левенштейна.
+// Для коротких слов допускается одна опечатка, для длинных - две; в словах из одной-двух букв
+// вроде "и" опечатку не отличить от другого слова, и подсказки нет.
+func suggest(word string, candidates []string) (string, bool) {
+	length := utf8.RuneCountInString(word)
+	if length < 3 {
+		return "", false
+	}
+	limit := 1
+	if length > 4 {
+		limit = 2
+	}
+
+	best, bestDistance := "", limit+1
+	for _, c := range slices.Sorted(slices.Values(candidates)) { // сортировка делает выбор при равенстве стабильным
+		if d := calcDistance(word, c); d < bestDistance && d > 0 {
+			best, bestDistance = c, d
+		}
+	}
+	return best, best != ""
+}
+
+// calcDistance считает расстояние Левенштейна между строками в символах, а не байтах.
+func calcDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// withHint дописывает к сообщению msg подсказку, если object похож на одно из candidates:
+// "Здесь нет такого предмета. Может быть, вы имели в виду «факел»?"
+func withHint(msg, object string, candidates []string) string {
+	if object == "" {
+		return msg
+	}
+	normalized := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		normalized = append(normalized, normalizeObject(Tokenize(c)))
+	}
+	if best, ok := suggest(object, normalized); ok {
+		return msg + " " + fmt.Sprintf(msgHint, best)
+	}
+	return msg
+}
+
+// calcVerbHint подсказывает команду, если первое слово похоже на известный глагол:
+// "взть факел" -> "взять факел". Составные глаголы не предлагаются.
+func calcVerbHint(words []string) (string, bool) {
+	if len(words) == 0 {
+		return "", false
+	}
+	var candidates []string
+	for name := range verbs {
+		if !strings.Contains(name, " ") {
+			candidates = append(candidates, name)
+		}
+	}
+	best, ok := suggest(words[0], candidates)
+	if !ok {
+		return "", false
+	}
+	return strings.Join(append([]string{best}, words[1:]...), " "), true
+}
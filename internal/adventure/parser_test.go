@@ -0,0 +1,235 @@
+package adventure
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"", nil},
+		{"  ?!  ", nil},
+		{"Зажечь факел!", []string{"зажечь", "факел"}},
+		{"зажечь   факел", []string{"зажечь", "факел"}},
+		{"ИДТИ на СЁВЕР", []string{"идти", "на", "север"}},
+		{"Ёлка, ёж и всё.", []string{"елка", "еж", "и", "все"}},
+		{"go east, 2 times", []string{"go", "east", "2", "times"}},
+		{"pick-up torch", []string{"pick", "up", "torch"}},
+	}
+	for _, tt := range tests {
+		if got := Tokenize(tt.line); !slices.Equal(got, tt.want) {
+			t.Errorf("Tokenize(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		line   string
+		verb   Verb
+		object string
+	}{
+		{"", "", ""},
+		{"Идти на восток!", VerbGo, "восток"},
+		{"идти на сёвер", VerbGo, "север"},
+		{"ИДИ НА СЕВЕР", VerbGo, "север"},
+		{"go to the east", VerbGo, "восток"},
+		{"go e", VerbGo, "восток"},
+		{"зайти в пещеру", VerbGo, "пещеру"},
+		{"pick up the torch", VerbTake, "torch"},
+		{"pick torch", VerbTake, "torch"},
+		{"look at знак", VerbLook, "знак"},
+		{"look", VerbLook, ""},
+		{"осмотреться", VerbLook, ""},
+		{"Зажечь факел!", VerbLight, "факел"},
+		{"взять ёлку", VerbTake, "елку"},
+		{"put down ключ", VerbDrop, "ключ"},
+		{"i", VerbInventory, ""},
+		{"сумка", VerbInventory, ""},
+		{"q", VerbQuit, ""},
+		{"сохранить игра.json", VerbSave, "игра json"},
+		// без глагола вся команда - объект: это может быть название выхода
+		{"наружу", "", "наружу"},
+		{"n", "", "север"},
+		{"в пещеру", "", "пещеру"},
+		{"танцевать вальс", "", "танцевать вальс"},
+	}
+	for _, tt := range tests {
+		got := ParseCommand(tt.line)
+		if got.Verb != tt.verb || got.Object != tt.object {
+			t.Errorf("ParseCommand(%q) = {%q, %q}, want {%q, %q}", tt.line, got.Verb, got.Object, tt.verb, tt.object)
+		}
+		if want := Tokenize(tt.line); !slices.Equal(got.Words, want) {
+			t.Errorf("ParseCommand(%q).Words = %q, want %q", tt.line, got.Words, want)
+		}
+	}
+}
+
+// TestVerbsNormalized проверяет, что ключи таблиц записаны так, как их выдает Tokenize:
+// ключ с ё или заглавной буквой никогда бы не совпал.
+func TestVerbsNormalized(t *testing.T) {
+	for name := range verbs {
+		if got := strings.Join(Tokenize(name), " "); got != name {
+			t.Errorf("глагол %q после Tokenize = %q", name, got)
+		}
+	}
+	for name := range directions {
+		if got := strings.Join(Tokenize(name), " "); got != name {
+			t.Errorf("направление %q после Tokenize = %q", name, got)
+		}
+	}
+}
+
+func TestMatchName(t *testing.T) {
+	tests := []struct {
+		name, object string
+		want         bool
+	}{
+		{"решетка", "решетку", true},
+		{"Решётка", "решетку", true},
+		{"на восток", "восток", true},
+		{"east", "восток", true},
+		{"старого ключа", "старому ключу", true},
+		{"факел", "факелом", true},
+		{"юг", "юга", false}, // основа не короче трех букв
+		{"факел", "ключ", false},
+		{"старый ключ", "ключ", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := matchName(tt.name, tt.object); got != tt.want {
+			t.Errorf("matchName(%q, %q) = %t, want %t", tt.name, tt.object, got, tt.want)
+		}
+	}
+}
+
+func TestCalcStem(t *testing.T) {
+	tests := []struct {
+		word, want string
+	}{
+		{"решетку", "решетк"},
+		{"решетка", "решетк"},
+		{"старого", "стар"},
+		{"пещерами", "пещер"},
+		{"юг", "юг"},
+		{"лед", "лед"},
+		{"озеро", "озер"},
+		{"torch", "torch"},
+	}
+	for _, tt := range tests {
+		if got := calcStem(tt.word); got != tt.want {
+			t.Errorf("calcStem(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestCalcDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"факел", "факел", 0},
+		{"факл", "факел", 1},
+		{"фокел", "факел", 1},
+		{"факелл", "факел", 1},
+		{"вастог", "восток", 2},
+		{"", "юг", 2},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := calcDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("calcDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := calcDistance(tt.b, tt.a); got != tt.want {
+			t.Errorf("calcDistance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"факел", "ключ", "знак", "восток", "запад"}
+	tests := []struct {
+		word string
+		want string // пусто - подсказки нет
+	}{
+		{"факл", "факел"},
+		{"клюс", "ключ"},
+		{"вастог", "восток"},
+		{"запат", "запад"},
+		// в коротком слове допускается только одна опечатка
+		{"кич", ""},
+		{"зн", ""},
+		// точное совпадение - не опечатка
+		{"факел", ""},
+		{"сундук", ""},
+	}
+	for _, tt := range tests {
+		got, ok := suggest(tt.word, candidates)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("suggest(%q) = %q, %t, want %q", tt.word, got, ok, tt.want)
+		}
+	}
+
+	// при равном расстоянии выбор не зависит от порядка кандидатов
+	for range 10 {
+		if got, _ := suggest("кот", []string{"кок", "код", "кит"}); got != "кит" {
+			t.Errorf("suggest(кот) = %q, want первый по алфавиту «кит»", got)
+		}
+	}
+}
+
+func TestHints(t *testing.T) {
+	if got, want := withHint(msgNoItem, "факл", []string{"Факел", "ключ"}), msgNoItem+" Может быть, вы имели в виду «факел»?"; got != want {
+		t.Errorf("withHint = %q, want %q", got, want)
+	}
+	if got := withHint(msgNoItem, "", []string{"факел"}); got != msgNoItem {
+		t.Errorf("withHint без объекта = %q", got)
+	}
+	if got := withHint(msgNoExit, "вастог", []string{"на восток"}); !strings.HasSuffix(got, "«восток»?") {
+		t.Errorf("withHint с предлогом в названии = %q", got)
+	}
+
+	tests := []struct {
+		words []string
+		want  string
+	}{
+		{[]string{"взть", "факел"}, "взять факел"},
+		{[]string{"зажеч", "факел"}, "зажечь факел"},
+		{[]string{"осмотрется"}, "осмотреться"},
+		{[]string{"танцевать"}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		got, ok := calcVerbHint(tt.words)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("calcVerbHint(%q) = %q, %t, want %q", tt.words, got, ok, tt.want)
+		}
+	}
+}
+
+// TestExecHints проверяет подсказки в партии по пещере.
+func TestExecHints(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"взть знак", msgUnknown + " Может быть, вы имели в виду «взять знак»?"},
+		{"взять знк", msgNoItem + " Может быть, вы имели в виду «знак»?"},
+		{"идти на вастог", msgNoExit + " Может быть, вы имели в виду «восток»?"},
+		{"идти на сёвер", msgNoExit},
+	}
+	for _, tt := range tests {
+		g, err := NewGame(NewCaveWorld(), 41)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.Exec(tt.line).Output; got != tt.want {
+			t.Errorf("Exec(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
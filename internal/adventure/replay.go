@@ -0,0 +1,334 @@
+package adventure
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	co "example/internal/constants"
+)
+
+// ErrReplayMismatch возвращается, если вывод игры разошелся с записанным в журнале.
+var ErrReplayMismatch = errors.New("вывод не совпадает с журналом")
+
+// Журнал партии - текстовый файл, который одновременно служит сценарием проверки:
+//
+//	# age: 41
+//	# world: ../worlds/cave.yaml
+//	Пещера
+//	Здесь вход в пещеру и путь на восток.
+//	> взять факел
+//	Вы взяли: факел.
+//
+// Строки "> команда" - ввод игрока, строки после них - ожидаемый вывод.
+// Строки вывода, которые начинаются с #, "> " или \, записываются с \ в начале,
+// чтобы их не приняли за комментарий или команду: "\# 1" в журнале - это вывод "# 1".
+// Строки с # - комментарии и директивы: age (возраст игрока, по умолчанию 41), world (файл мира
+// относительно журнала, по умолчанию пещера) и state (состояние в JSON,
+// которое восстанавливается в этом месте, как при загрузке сохранения).
+// Игра не использует случайные числа, поэтому журнал воспроизводится один в один.
+
+// escape отмечает строку вывода, которую иначе приняли бы за комментарий или команду.
+const escape = `\`
+
+// Директивы журнала.
+const (
+	directiveAge   = "age"
+	directiveWorld = "world"
+	directiveState = "state"
+)
+
+// Replay - разобранный журнал партии.
+type Replay struct {
+	Path     string   // файл журнала, от него отсчитывается World
+	Comments []string // комментарии в начале файла, например описание сценария
+	Age      int
+	World    string // файл мира; пусто - пещера по умолчанию
+	State    *State // состояние в начале партии; nil - новая партия
+	Intro    string // ожидаемое вступление: название мира и описание первой комнаты
+	Steps    []Step
+}
+
+// Step - шаг журнала: команда игрока или восстановление состояния и ожидаемый вывод.
+type Step struct {
+	Line    int    // строка шага в файле
+	Command string // команда игрока; пусто, если шаг восстанавливает State
+	State   *State
+	Output  string
+}
+
+// ParseReplay разбирает журнал из r.
+func ParseReplay(r io.Reader) (*Replay, error) {
+	rp := &Replay{Age: co.DefaultPlayerAge} // без директивы age играет взрослый из f16
+	var (
+		output    []string // вывод текущего шага или вступления
+		isStarted bool     // встретился вывод или шаг: директивы age и world уже нельзя
+	)
+	flush := func() {
+		text := joinOutput(output)
+		if len(rp.Steps) == 0 {
+			rp.Intro = text
+		} else {
+			rp.Steps[len(rp.Steps)-1].Output = text
+		}
+		output = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if text, ok := strings.CutPrefix(line, escape); ok {
+			isStarted = true
+			output = append(output, text)
+			continue
+		}
+		if command, ok := strings.CutPrefix(line, prompt); ok {
+			flush()
+			isStarted = true
+			rp.Steps = append(rp.Steps, Step{Line: number, Command: command})
+			continue
+		}
+		if !strings.HasPrefix(line, "#") {
+			isStarted = true
+			output = append(output, line)
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimSpace(strings.TrimPrefix(line, "#")), ":")
+		if !ok || !isDirective(key) {
+			if !isStarted && len(rp.Steps) == 0 {
+				rp.Comments = append(rp.Comments, line)
+			}
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case directiveAge, directiveWorld:
+			if isStarted {
+				return nil, &FieldError{Line: number, Field: key, Err: fmt.Errorf("%w: директива должна стоять в начале журнала", ErrInvalidValue)}
+			}
+			if key == directiveWorld {
+				rp.World = value
+				continue
+			}
+			age, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, &FieldError{Line: number, Field: key, Err: fmt.Errorf("%w: %q", ErrInvalidValue, value)}
+			}
+			rp.Age = age
+		case directiveState:
+			var s State
+			if err := json.Unmarshal([]byte(value), &s); err != nil {
+				return nil, &FieldError{Line: number, Field: key, Err: fmt.Errorf("%w: %v", ErrInvalidValue, err)}
+			}
+			if !isStarted {
+				rp.State = &s
+				continue
+			}
+			flush()
+			rp.Steps = append(rp.Steps, Step{Line: number, State: &s})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return rp, nil
+}
+
+// LoadReplayFile читает журнал из файла path.
+func LoadReplayFile(path string) (*Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	rp, err := ParseReplay(file)
+	if err != nil {
+		var fieldErr *FieldError
+		if errors.As(err, &fieldErr) {
+			fieldErr.File = path
+		}
+		return nil, err
+	}
+	rp.Path = path
+	return rp, nil
+}
+
+// LoadWorld возвращает мир журнала: файл из директивы world относительно журнала или пещеру.
+func (rp *Replay) LoadWorld() (*World, error) {
+	if rp.World == "" {
+		return NewCaveWorld(), nil
+	}
+	path := rp.World
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(rp.Path), path)
+	}
+	return LoadWorldFile(path)
+}
+
+// Write записывает журнал в w. Из комментариев сохраняются только стоящие в начале файла.
+func (rp *Replay) Write(w io.Writer) error {
+	for _, comment := range rp.Comments {
+		if _, err := fmt.Fprintln(w, comment); err != nil {
+			return err
+		}
+	}
+	if err := writeReplayHeader(w, rp.Age, rp.World, rp.State); err != nil {
+		return err
+	}
+	if err := writeOutput(w, rp.Intro); err != nil {
+		return err
+	}
+	for _, step := range rp.Steps {
+		var err error
+		if step.State != nil {
+			err = writeState(w, *step.State)
+		} else {
+			_, err = fmt.Fprintf(w, "%s%s\n", prompt, step.Command)
+		}
+		if err != nil {
+			return err
+		}
+		if err := writeOutput(w, step.Output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunReplay заново выполняет журнал rp в мире world и сравнивает вывод с записанным.
+// Возвращает журнал с фактическим выводом (его можно записать вместо устаревшего)
+// и ошибку *FieldError с ErrReplayMismatch для первого расхождения.
+func RunReplay(world *World, rp *Replay) (*Replay, error) {
+	g, err := NewGame(world, rp.Age)
+	if err != nil {
+		return nil, err
+	}
+	if rp.State != nil {
+		if err := g.Restore(*rp.State); err != nil {
+			return nil, &FieldError{File: rp.Path, Field: directiveState, Err: err}
+		}
+	}
+
+	actual := *rp
+	actual.Steps = make([]Step, len(rp.Steps))
+	actual.Intro = normalizeOutput(describeIntro(g))
+	var mismatch error
+	if actual.Intro != rp.Intro {
+		mismatch = newMismatch(rp.Path, 1, "", rp.Intro, actual.Intro)
+	}
+
+	for i, step := range rp.Steps {
+		actual.Steps[i] = step
+		if step.State != nil {
+			if err := g.Restore(*step.State); err != nil {
+				return nil, &FieldError{File: rp.Path, Line: step.Line, Field: directiveState, Err: err}
+			}
+			actual.Steps[i].Output = normalizeOutput(describeRestored(g))
+		} else {
+			actual.Steps[i].Output = normalizeOutput(g.Exec(step.Command).Output)
+		}
+		if mismatch == nil && actual.Steps[i].Output != step.Output {
+			mismatch = newMismatch(rp.Path, step.Line, step.Command, step.Output, actual.Steps[i].Output)
+		}
+	}
+	return &actual, mismatch
+}
+
+// newMismatch описывает расхождение вывода на шаге command.
+func newMismatch(path string, line int, command, want, got string) error {
+	field := ""
+	if command != "" {
+		field = prompt + command
+	}
+	return &FieldError{
+		File:  path,
+		Line:  line,
+		Field: field,
+		Err:   fmt.Errorf("%w\nожидалось:\n%s\nполучено:\n%s", ErrReplayMismatch, want, got),
+	}
+}
+
+// isDirective проверяет, что key - известная директива журнала.
+func isDirective(key string) bool {
+	return key == directiveAge || key == directiveWorld || key == directiveState
+}
+
+// describeIntro возвращает вступление партии: название мира и описание текущей комнаты.
+func describeIntro(g *Game) string {
+	return g.world.Title + "\n" + g.Look()
+}
+
+// describeRestored возвращает вывод после загрузки состояния.
+func describeRestored(g *Game) string {
+	return msgLoaded + "\n" + g.Look()
+}
+
+// writeReplayHeader записывает директивы начала журнала.
+func writeReplayHeader(w io.Writer, age int, world string, s *State) error {
+	if _, err := fmt.Fprintf(w, "# %s: %d\n", directiveAge, age); err != nil {
+		return err
+	}
+	if world != "" {
+		if _, err := fmt.Fprintf(w, "# %s: %s\n", directiveWorld, world); err != nil {
+			return err
+		}
+	}
+	if s != nil {
+		return writeState(w, *s)
+	}
+	return nil
+}
+
+// writeState записывает директиву state.
+func writeState(w io.Writer, s State) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "# %s: %s\n", directiveState, data)
+	return err
+}
+
+// writeOutput записывает вывод шага, если он не пустой, экранируя строки,
+// похожие на комментарий или команду.
+func writeOutput(w io.Writer, output string) error {
+	if output == "" {
+		return nil
+	}
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, prompt) || strings.HasPrefix(line, escape) {
+			lines[i] = escape + line
+		}
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// normalizeOutput приводит вывод игры к виду, в котором он хранится в журнале:
+// без пробелов в конце строк и пустых строк в конце.
+func normalizeOutput(output string) string {
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return joinOutput(lines)
+}
+
+// joinOutput склеивает строки вывода, отбрасывая пустые строки в конце.
+func joinOutput(lines []string) string {
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
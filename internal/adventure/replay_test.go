@@ -0,0 +1,70 @@
+package adventure
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+// TestReplays воспроизводит журналы партий из testdata/cave, как cave -replay.
+func TestReplays(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "..", "testdata", "cave", "*.replay"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("нет журналов в testdata/cave")
+	}
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			rp, err := LoadReplayFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			world, err := rp.LoadWorld()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := RunReplay(world, rp); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestReplayEscape проверяет, что вывод, похожий на комментарий, директиву или команду,
+// переживает запись и разбор журнала.
+func TestReplayEscape(t *testing.T) {
+	want := &Replay{
+		Comments: []string{"# описание"},
+		Age:      41,
+		Intro:    "# Пещера\nВход.",
+		Steps: []Step{
+			{Command: "читать", Output: "# age: 12\n> не команда\n\\ обратная черта\nобычная строка"},
+			{Command: "осмотреться", Output: "#"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := want.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseReplay(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Age != want.Age {
+		t.Errorf("Age = %d, want %d", got.Age, want.Age)
+	}
+	if got.Intro != want.Intro {
+		t.Errorf("Intro = %q, want %q", got.Intro, want.Intro)
+	}
+	if len(got.Steps) != len(want.Steps) {
+		t.Fatalf("шагов %d, want %d:\n%s", len(got.Steps), len(want.Steps), buf.String())
+	}
+	for i, step := range want.Steps {
+		if got.Steps[i].Command != step.Command || got.Steps[i].Output != step.Output {
+			t.Errorf("шаг %d = %q %q, want %q %q", i, got.Steps[i].Command, got.Steps[i].Output, step.Command, step.Output)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package adventure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+var (
+	// ErrInvalidState возвращается, если состояние ссылается на то, чего нет в мире.
+	ErrInvalidState = errors.New("состояние не подходит к миру")
+	// ErrWrongWorld возвращается при загрузке сохранения из другого мира.
+	ErrWrongWorld = errors.New("сохранение из другого мира")
+)
+
+// saveVersion - версия формата файла сохранения.
+const saveVersion = 1
+
+// SaveFile - файл сохранения: название мира, чтобы не загрузить чужое сохранение, и состояние игрока.
+type SaveFile struct {
+	Version int    `json:"version"`
+	World   string `json:"world"`
+	State   State  `json:"state"`
+}
+
+// WriteSave записывает сохранение партии g в w в формате JSON.
+func WriteSave(w io.Writer, g *Game) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(SaveFile{Version: saveVersion, World: g.world.Title, State: g.GetState()})
+}
+
+// ReadSave читает сохранение из r и возвращает состояние, проверенное на соответствие миру world.
+func ReadSave(r io.Reader, world *World) (State, error) {
+	var save SaveFile
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&save); err != nil {
+		return State{}, fmt.Errorf("чтение сохранения: %w", err)
+	}
+	if save.Version != saveVersion {
+		return State{}, fmt.Errorf("%w: версия сохранения %d, поддерживается %d", ErrInvalidState, save.Version, saveVersion)
+	}
+	if save.World != world.Title {
+		return State{}, fmt.Errorf("%w: %q, а игра идет в мире %q", ErrWrongWorld, save.World, world.Title)
+	}
+	if err := world.ValidateState(save.State); err != nil {
+		return State{}, err
+	}
+	return save.State, nil
+}
+
+// SaveGameFile сохраняет партию g в файл path.
+func SaveGameFile(path string, g *Game) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteSave(file, g); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// LoadGameFile читает состояние из файла сохранения path для мира world.
+func LoadGameFile(path string, world *World) (State, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return State{}, err
+	}
+	defer file.Close()
+	return ReadSave(file, world)
+}
+
+// ValidateState проверяет, что состояние s ссылается только на комнаты и предметы мира.
+func (w *World) ValidateState(s State) error {
+	if _, ok := w.GetRoom(s.Room); !ok {
+		return fmt.Errorf("%w: комната %q", ErrInvalidState, s.Room)
+	}
+	for item, location := range s.Locations {
+		if _, ok := w.GetItem(item); !ok {
+			return fmt.Errorf("%w: предмет %q", ErrInvalidState, item)
+		}
+		if _, ok := w.GetRoom(location); location != Inventory && !ok {
+			return fmt.Errorf("%w: предмет %q лежит в комнате %q", ErrInvalidState, item, location)
+		}
+	}
+	for item := range s.Lit {
+		if _, ok := w.GetItem(item); !ok {
+			return fmt.Errorf("%w: горит предмет %q", ErrInvalidState, item)
+		}
+	}
+	return nil
+}
+
+// Restore заменяет состояние партии на s, например загруженное из сохранения.
+func (g *Game) Restore(s State) error {
+	if err := g.world.ValidateState(s); err != nil {
+		return err
+	}
+	s = s.Clone()
+	// в JSON пустые словари могут прийти как null
+	if s.Locations == nil {
+		s.Locations = map[string]string{}
+	}
+	if s.Lit == nil {
+		s.Lit = map[string]bool{}
+	}
+	if s.Flags == nil {
+		s.Flags = map[string]bool{}
+	}
+	g.state = s
+	return nil
+}
@@ -0,0 +1,136 @@
+package adventure
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// prompt - приглашение к вводу команды, им же в журнале отмечены команды игрока.
+const prompt = "> "
+
+// DefaultSavePath - файл сохранения, если в команде "сохранить" он не указан.
+const DefaultSavePath = "cave.save.json"
+
+// Сообщения о сохранении и загрузке.
+const (
+	msgSaved      = "Игра сохранена в %s."
+	msgSaveFailed = "Не удалось сохранить игру: %v"
+	msgLoaded     = "Игра загружена."
+	msgLoadFailed = "Не удалось загрузить игру: %v"
+)
+
+// Session - интерактивная партия: читает команды построчно из In, печатает ответы в Out
+// и, если задан Log, ведет журнал в формате ParseReplay.
+// Команды "сохранить" и "загрузить" работают с файлами и поэтому выполняются здесь, а не в Game.
+type Session struct {
+	In        io.Reader
+	Out       io.Writer
+	Log       io.Writer // журнал партии; nil - без журнала
+	WorldPath string    // файл мира для директивы world журнала; пусто - пещера
+}
+
+// Run играет партию g, пока игрок не завершит игру или не закончится ввод.
+func (s Session) Run(g *Game) error {
+	log := s.Log
+	if log == nil {
+		log = io.Discard
+	}
+
+	var start *State
+	if state := g.GetState(); !reflect.DeepEqual(state, NewState(g.world, state.Age)) {
+		start = &state // партия продолжается из сохранения: журналу нужно состояние
+	}
+	if err := writeReplayHeader(log, g.state.Age, s.WorldPath, start); err != nil {
+		return err
+	}
+	if err := s.print(log, describeIntro(g)); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(s.In)
+	for {
+		if _, err := fmt.Fprint(s.Out, prompt); err != nil {
+			return err
+		}
+		if !scanner.Scan() {
+			if _, err := fmt.Fprintln(s.Out); err != nil {
+				return err
+			}
+			return scanner.Err()
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		switch ParseCommand(line).Verb {
+		case VerbSave:
+			path := calcSavePath(line)
+			output := fmt.Sprintf(msgSaved, path)
+			if err := SaveGameFile(path, g); err != nil {
+				output = fmt.Sprintf(msgSaveFailed, err)
+			}
+			// сохранение не меняет партию, в журнале оно только комментарий
+			if _, err := fmt.Fprintln(s.Out, output); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(log, "# %s\n", output); err != nil {
+				return err
+			}
+			continue
+
+		case VerbLoad:
+			state, err := LoadGameFile(calcSavePath(line), g.world)
+			if err == nil {
+				err = g.Restore(state)
+			}
+			if err != nil {
+				if _, err := fmt.Fprintf(s.Out, msgLoadFailed+"\n", err); err != nil {
+					return err
+				}
+				continue
+			}
+			// в журнал попадает само состояние, чтобы воспроизведение не зависело от файла сохранения
+			if err := writeState(log, g.GetState()); err != nil {
+				return err
+			}
+			if err := s.print(log, describeRestored(g)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		res := g.Exec(line)
+		if _, err := fmt.Fprintf(log, "%s%s\n", prompt, line); err != nil {
+			return err
+		}
+		if err := s.print(log, res.Output); err != nil {
+			return err
+		}
+		if res.IsOver {
+			return nil
+		}
+	}
+}
+
+// print выводит текст игроку и в журнал.
+func (s Session) print(log io.Writer, text string) error {
+	if _, err := fmt.Fprintln(s.Out, text); err != nil {
+		return err
+	}
+	return writeOutput(log, text)
+}
+
+// calcSavePath возвращает файл из команды "сохранить файл" без изменения регистра
+// или DefaultSavePath, если файл не указан.
+func calcSavePath(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return DefaultSavePath
+	}
+	return strings.TrimSpace(strings.TrimPrefix(line, fields[0]))
+}
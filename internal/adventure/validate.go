@@ -0,0 +1,228 @@
+package adventure
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Validate проверяет мир целиком и возвращает все найденные ошибки через errors.Join.
+// Каждая ошибка - *FieldError с путем к полю: повторяющиеся ID, выходы в несуществующие
+// комнаты, ссылки на неизвестные предметы и события, зацикленные цепочки событий
+// и комнаты, в которые нельзя попасть из начальной.
+func (w *World) Validate() error {
+	errs := w.validate()
+	if len(errs) == 0 {
+		return nil
+	}
+	return joinFieldErrors(errs)
+}
+
+// validate возвращает ошибки мира списком, чтобы загрузчик мог дописать к ним строки файла.
+func (w *World) validate() []*FieldError {
+	v := &validator{world: w}
+	v.checkIDs()
+	v.checkStart()
+	v.checkRooms()
+	v.checkEvents()
+	if len(v.errs) == 0 {
+		// недостижимость имеет смысл искать только в мире с целыми ссылками
+		v.checkReachable()
+	}
+	return v.errs
+}
+
+// validator копит ошибки проверки мира.
+type validator struct {
+	world *World
+	errs  []*FieldError
+}
+
+// fail запоминает ошибку err для поля path.
+func (v *validator) fail(path string, err error) {
+	v.errs = append(v.errs, &FieldError{Field: path, Err: err})
+}
+
+// checkIDs проверяет, что ID комнат, предметов и событий заполнены и не повторяются.
+func (v *validator) checkIDs() {
+	rooms := map[string]int{}
+	for i, r := range v.world.Rooms {
+		v.checkID(r.ID, fmt.Sprintf("rooms[%d]", i), rooms, i)
+	}
+	items := map[string]int{}
+	for i, it := range v.world.Items {
+		path := fmt.Sprintf("items[%d]", i)
+		v.checkID(it.ID, path, items, i)
+		if it.Name == "" {
+			v.fail(path+".name", ErrMissingField)
+		}
+	}
+	events := map[string]int{}
+	for i, e := range v.world.Events {
+		v.checkID(e.ID, fmt.Sprintf("events[%d]", i), events, i)
+	}
+}
+
+// checkID проверяет один ID и запоминает его индекс в seen.
+func (v *validator) checkID(id, path string, seen map[string]int, i int) {
+	if id == "" {
+		v.fail(path+".id", ErrMissingField)
+		return
+	}
+	if first, ok := seen[id]; ok {
+		v.fail(path+".id", fmt.Errorf("%w: %q уже объявлен под номером %d", ErrDuplicateID, id, first))
+		return
+	}
+	seen[id] = i
+}
+
+// checkStart проверяет начальную комнату.
+func (v *validator) checkStart() {
+	if v.world.Start == "" {
+		v.fail("start", ErrMissingField)
+		return
+	}
+	if _, ok := v.world.GetRoom(v.world.Start); !ok {
+		v.fail("start", fmt.Errorf("%w: %q", ErrUnknownRoom, v.world.Start))
+	}
+}
+
+// checkRooms проверяет выходы, предметы и события комнат.
+func (v *validator) checkRooms() {
+	placed := map[string]string{} // ID предмета -> комната, где он лежит
+	for i, r := range v.world.Rooms {
+		path := fmt.Sprintf("rooms[%d]", i)
+		for j, id := range r.Items {
+			itemPath := fmt.Sprintf("%s.items[%d]", path, j)
+			v.checkItem(id, itemPath)
+			if room, ok := placed[id]; ok {
+				v.fail(itemPath, fmt.Errorf("%w: предмет %q уже лежит в комнате %q", ErrInvalidValue, id, room))
+			}
+			placed[id] = r.ID
+		}
+		for j, e := range r.Exits {
+			v.checkExit(e, fmt.Sprintf("%s.exits[%d]", path, j))
+		}
+		v.checkEvent(r.OnEnter, path+".on_enter")
+	}
+}
+
+// checkExit проверяет один выход.
+func (v *validator) checkExit(e Exit, path string) {
+	if len(e.Names) == 0 {
+		v.fail(path+".names", ErrMissingField)
+	}
+	switch _, ok := v.world.GetRoom(e.To); {
+	case e.To == "":
+		v.fail(path+".to", ErrMissingField)
+	case !ok:
+		v.fail(path+".to", fmt.Errorf("%w: %q", ErrDanglingExit, e.To))
+	}
+	if e.Key != "" && e.Lock == "" {
+		v.fail(path+".key", fmt.Errorf("%w: ключ без замка lock", ErrInvalidValue))
+	}
+	v.checkItem(e.Key, path+".key")
+	v.checkCondition(e.Condition, path+".condition")
+}
+
+// checkCondition проверяет ссылки условия на предметы.
+func (v *validator) checkCondition(c Condition, path string) {
+	if c.MinAge < 0 {
+		v.fail(path+".min_age", fmt.Errorf("%w: возраст %d", ErrInvalidValue, c.MinAge))
+	}
+	v.checkItem(c.HasItem, path+".has_item")
+	v.checkItem(c.IsLit, path+".is_lit")
+}
+
+// checkItem проверяет, что непустой ID ссылается на предмет мира.
+func (v *validator) checkItem(id, path string) {
+	if _, ok := v.world.GetItem(id); id != "" && !ok {
+		v.fail(path, fmt.Errorf("%w: %q", ErrUnknownItem, id))
+	}
+}
+
+// checkEvent проверяет, что непустой ID ссылается на событие мира.
+func (v *validator) checkEvent(id, path string) {
+	if _, ok := v.world.GetEvent(id); id != "" && !ok {
+		v.fail(path, fmt.Errorf("%w: %q", ErrUnknownEvent, id))
+	}
+}
+
+// checkEvents проверяет ссылки событий и ищет кольца в цепочках Next.
+func (v *validator) checkEvents() {
+	inCycle := map[string]bool{} // события уже найденных колец, чтобы сообщить о кольце один раз
+	for i, e := range v.world.Events {
+		path := fmt.Sprintf("events[%d]", i)
+		v.checkCondition(e.Condition, path+".condition")
+		if _, ok := v.world.GetRoom(e.MoveTo); e.MoveTo != "" && !ok {
+			v.fail(path+".move_to", fmt.Errorf("%w: %q", ErrDanglingExit, e.MoveTo))
+		}
+		v.checkEvent(e.Next, path+".next")
+
+		// идем по цепочке от события: вернуться в него можно только по кольцу
+		if inCycle[e.ID] {
+			continue
+		}
+		chain := []string{e.ID}
+		for id := e.Next; id != "" && len(chain) <= len(v.world.Events); {
+			if id == e.ID {
+				v.fail(path+".next", fmt.Errorf("%w: %s -> %s", ErrEventCycle, strings.Join(chain, " -> "), e.ID))
+				for _, id := range chain {
+					inCycle[id] = true
+				}
+				break
+			}
+			next, ok := v.world.GetEvent(id)
+			if !ok {
+				break
+			}
+			chain = append(chain, id)
+			id = next.Next
+		}
+	}
+}
+
+// checkReachable ищет комнаты, в которые нельзя попасть из начальной ни через выходы,
+// ни через перемещения событий. Условия и замки не учитываются: их можно выполнить по ходу игры.
+func (v *validator) checkReachable() {
+	visited := map[string]bool{v.world.Start: true}
+	queue := []string{v.world.Start}
+	for len(queue) > 0 {
+		room, _ := v.world.GetRoom(queue[0])
+		queue = queue[1:]
+
+		var next []string
+		for _, e := range room.Exits {
+			next = append(next, e.To)
+		}
+		for id := room.OnEnter; id != ""; {
+			event, _ := v.world.GetEvent(id)
+			if event.MoveTo != "" {
+				next = append(next, event.MoveTo)
+			}
+			id = event.Next
+		}
+
+		for _, id := range next {
+			if !visited[id] {
+				visited[id] = true
+				queue = append(queue, id)
+			}
+		}
+	}
+
+	for i, r := range v.world.Rooms {
+		if !visited[r.ID] {
+			v.fail(fmt.Sprintf("rooms[%d].id", i), fmt.Errorf("%w: %q", ErrUnreachableRoom, r.ID))
+		}
+	}
+}
+
+// joinFieldErrors объединяет ошибки в одну, сохраняя каждую для errors.Is и errors.As.
+func joinFieldErrors(errs []*FieldError) error {
+	joined := make([]error, len(errs))
+	for i, err := range errs {
+		joined[i] = err
+	}
+	return errors.Join(joined...)
+}
@@ -0,0 +1,144 @@
+// Package adventure - текстовое приключение по мотивам пещеры из f15, f16, f18, f20 и f21.
+//
+// В примерах комнаты и команды зашиты в отдельные if и switch. Здесь мир описывается
+// данными: комнаты (Room) с выходами (Exit) и предметами (Item), а Game хранит
+// состояние игрока и выполняет его команды (Command).
+package adventure
+
+// World - описание мира: комнаты, предметы и комната, с которой начинается игра.
+// World не меняется во время игры, все изменения хранятся в State.
+type World struct {
+	Title  string
+	Start  string // ID начальной комнаты
+	Rooms  []Room
+	Items  []Item
+	Events []Event
+}
+
+// Room - комната мира.
+type Room struct {
+	ID          string
+	Description string   // что видит игрок, войдя в комнату
+	IsDark      bool     // без горящего источника света видно только "Ничего не видно." (f20)
+	Exits       []Exit   // выходы в другие комнаты
+	Items       []string // ID предметов, которые лежат в комнате в начале игры
+	OnEnter     string   // ID события, которое происходит при входе в комнату
+}
+
+// Exit - выход из комнаты.
+type Exit struct {
+	Names     []string  // как игрок может назвать выход: "на восток", "внутрь"
+	To        string    // ID комнаты, куда ведет выход
+	Message   string    // что выводится при переходе, например "Вы направляетесь к горе."
+	Condition Condition // условие, без которого пройти нельзя
+	Denied    string    // что выводится, если условие не выполнено
+	Lock      string    // флаг запертой двери: пока он не поднят, выход закрыт
+	Key       string    // ID предмета, которым дверь отпирается командой "открыть"
+}
+
+// Item - предмет. Непереносимые предметы вроде знака у входа можно только осмотреть или прочитать.
+type Item struct {
+	ID          string
+	Name        string   // название в выводе и в командах: "факел"
+	Aliases     []string // другие названия в командах, например английские: "torch"
+	Description string   // что видно при осмотре
+	Text        string   // что выводит команда "прочитать", например текст знака из f21
+	IsPortable  bool     // предмет можно взять
+	CanLight    bool     // предмет можно зажечь, горящий предмет освещает темные комнаты
+}
+
+// Condition - условие прохода через выход. Нулевое значение выполняется всегда.
+type Condition struct {
+	MinAge  int    // минимальный возраст игрока, как проверка совершеннолетия в f16
+	HasItem string // ID предмета, который должен быть у игрока
+	IsLit   string // ID предмета, который должен гореть
+	Flag    string // флаг, который должен быть поднят событием или отпертой дверью
+}
+
+// Event - событие при входе в комнату. Next позволяет связать события в цепочку,
+// как fallthrough в f22: за "Лед кажется достаточно крепким." сразу следует
+// "Вода такая холодная, что сводит кости.".
+type Event struct {
+	ID        string
+	Text      string    // что выводится, когда событие происходит
+	Condition Condition // без выполненного условия событие и вся цепочка после него пропускаются
+	SetFlag   string    // флаг, который поднимает событие
+	MoveTo    string    // ID комнаты, куда событие переносит игрока
+	Next      string    // ID следующего события цепочки
+}
+
+// IsMet проверяет условие для состояния игрока s.
+func (c Condition) IsMet(s State) bool {
+	if s.Age < c.MinAge {
+		return false
+	}
+	if c.HasItem != "" && !s.HasItem(c.HasItem) {
+		return false
+	}
+	if c.IsLit != "" && !s.Lit[c.IsLit] {
+		return false
+	}
+	if c.Flag != "" && !s.Flags[c.Flag] {
+		return false
+	}
+	return true
+}
+
+// GetRoom возвращает комнату по ID.
+func (w *World) GetRoom(id string) (*Room, bool) {
+	for i := range w.Rooms {
+		if w.Rooms[i].ID == id {
+			return &w.Rooms[i], true
+		}
+	}
+	return nil, false
+}
+
+// GetItem возвращает предмет по ID.
+func (w *World) GetItem(id string) (*Item, bool) {
+	for i := range w.Items {
+		if w.Items[i].ID == id {
+			return &w.Items[i], true
+		}
+	}
+	return nil, false
+}
+
+// GetEvent возвращает событие по ID.
+func (w *World) GetEvent(id string) (*Event, bool) {
+	for i := range w.Events {
+		if w.Events[i].ID == id {
+			return &w.Events[i], true
+		}
+	}
+	return nil, false
+}
+
+// IsLocked проверяет, заперта ли дверь выхода для состояния s.
+func (e Exit) IsLocked(s State) bool {
+	return e.Lock != "" && !s.Flags[e.Lock]
+}
+
+// HasName проверяет, называется ли выход name. Сравнение не учитывает регистр, ё/е,
+// предлоги и падежные окончания: "в пещеру" и "пещера" - одно и то же.
+func (e Exit) HasName(name string) bool {
+	object := normalizeObject(Tokenize(name))
+	for _, n := range e.Names {
+		if matchName(n, object) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasName проверяет, называется ли предмет name: по названию, синониму или ID.
+// Сравнение такое же, как у Exit.HasName.
+func (it Item) HasName(name string) bool {
+	object := normalizeObject(Tokenize(name))
+	for _, n := range append([]string{it.Name, it.ID}, it.Aliases...) {
+		if matchName(n, object) {
+			return true
+		}
+	}
+	return false
+}
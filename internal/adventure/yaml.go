@@ -0,0 +1,322 @@
+package adventure
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Разбор YAML. Сторонних библиотек в проекте нет, поэтому поддерживается подмножество,
+// которого хватает для файлов мира:
+//   - отображения "ключ: значение" и последовательности "- элемент" с отступами пробелами;
+//   - элементы последовательности, которые сами являются отображениями ("- id: вход");
+//   - простые скаляры, строки в "двойных" (с экранированием как в Go) и 'одинарных' кавычках;
+//   - однострочные списки скаляров [на восток, восток];
+//   - многострочный текст после | (строки сохраняются) и > (строки склеиваются через пробел);
+//   - комментарии # и разделитель документа ---.
+// Якоря, ссылки, теги, однострочные отображения {...} и несколько документов в файле не поддерживаются.
+
+// yamlLine - значимая строка YAML без комментария.
+type yamlLine struct {
+	number int    // номер строки в файле, с 1
+	indent int    // число пробелов в начале
+	text   string // содержимое после отступа
+}
+
+// yamlParser разбирает строки рекурсивным спуском по отступам.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+	raw   []string // исходные строки для многострочного текста
+}
+
+// parseYAML разбирает YAML-документ в дерево node.
+func parseYAML(data []byte) (*node, error) {
+	p := &yamlParser{raw: strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")}
+	for i, raw := range p.raw {
+		text := strings.TrimRight(stripYAMLComment(raw), " \t")
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || (trimmed == "---" && len(p.lines) == 0) {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, p.errorAt(i+1, "табуляция в отступе, используйте пробелы")
+		}
+		p.lines = append(p.lines, yamlLine{number: i + 1, indent: len(text) - len(trimmed), text: trimmed})
+	}
+	if len(p.lines) == 0 {
+		return &node{kind: mapNode, line: 1}, nil
+	}
+
+	root, err := p.parseBlock(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, p.errorAt(p.lines[p.pos].number, "неожиданный отступ")
+	}
+	return root, nil
+}
+
+// parseBlock разбирает отображение или последовательность, строки которых начинаются с отступа indent.
+func (p *yamlParser) parseBlock(indent int) (*node, error) {
+	if isYAMLSequenceItem(p.lines[p.pos].text) {
+		return p.parseSequence(indent)
+	}
+	return p.parseMapping(indent)
+}
+
+// parseSequence разбирает строки "- элемент" с отступом indent.
+func (p *yamlParser) parseSequence(indent int) (*node, error) {
+	n := &node{kind: listNode, line: p.lines[p.pos].number}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLSequenceItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		content := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+
+		if content == "" {
+			// элемент на следующих строках с большим отступом
+			p.pos++
+			item, err := p.parseNested(indent, line.number)
+			if err != nil {
+				return nil, err
+			}
+			n.items = append(n.items, item)
+			continue
+		}
+
+		if _, _, isMapping := cutYAMLKey(content); isMapping {
+			// "- id: вход": продолжаем разбирать ту же строку как начало отображения со сдвигом
+			p.lines[p.pos] = yamlLine{number: line.number, indent: len(line.text) - len(content) + indent, text: content}
+			item, err := p.parseMapping(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			n.items = append(n.items, item)
+			continue
+		}
+
+		item, err := p.parseScalar(content, line.number)
+		if err != nil {
+			return nil, err
+		}
+		n.items = append(n.items, item)
+		p.pos++
+	}
+	return n, nil
+}
+
+// parseMapping разбирает строки "ключ: значение" с отступом indent.
+func (p *yamlParser) parseMapping(indent int) (*node, error) {
+	n := &node{kind: mapNode, line: p.lines[p.pos].number}
+	seen := map[string]bool{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && !isYAMLSequenceItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		key, rest, ok := cutYAMLKey(line.text)
+		if !ok {
+			return nil, p.errorAt(line.number, "ожидалось \"ключ: значение\"")
+		}
+		if seen[key] {
+			return nil, p.errorAt(line.number, fmt.Sprintf("ключ %q повторяется", key))
+		}
+		seen[key] = true
+		p.pos++
+
+		var (
+			value *node
+			err   error
+		)
+		switch {
+		case rest == "":
+			value, err = p.parseNested(indent, line.number)
+		case rest == "|" || rest == ">":
+			value = p.parseBlockText(indent, line.number, rest == ">")
+		default:
+			value, err = p.parseScalar(rest, line.number)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.fields = append(n.fields, field{key: key, line: line.number, value: value})
+	}
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		return nil, p.errorAt(p.lines[p.pos].number, "неожиданный отступ")
+	}
+	return n, nil
+}
+
+// parseNested разбирает значение, начинающееся со следующей строки:
+// блок с большим отступом или последовательность на том же отступе, что и ключ.
+// Если такого блока нет, значение пустое.
+func (p *yamlParser) parseNested(indent, number int) (*node, error) {
+	if p.pos < len(p.lines) {
+		next := p.lines[p.pos]
+		if next.indent > indent || (next.indent == indent && isYAMLSequenceItem(next.text)) {
+			return p.parseBlock(next.indent)
+		}
+	}
+	return &node{kind: scalarNode, line: number}, nil
+}
+
+// parseBlockText собирает многострочный текст после | или >: все строки с отступом больше indent.
+// Текст берется из исходных строк, поэтому # внутри него - не комментарий.
+func (p *yamlParser) parseBlockText(indent, number int, isFolded bool) *node {
+	var texts []string
+	textIndent := -1
+	for i := number; i < len(p.raw); i++ { // p.raw[number] - строка сразу после ключа
+		raw := strings.TrimRight(p.raw[i], " \t\r")
+		trimmed := strings.TrimLeft(raw, " ")
+		if trimmed == "" {
+			texts = append(texts, "")
+			continue
+		}
+		lineIndent := len(raw) - len(trimmed)
+		if lineIndent <= indent {
+			break
+		}
+		if textIndent < 0 {
+			textIndent = lineIndent
+		}
+		texts = append(texts, raw[min(textIndent, lineIndent):])
+	}
+	// пустые строки в конце не относятся к тексту
+	for len(texts) > 0 && texts[len(texts)-1] == "" {
+		texts = texts[:len(texts)-1]
+	}
+
+	// пропускаем значимые строки, попавшие в текст
+	last := number + len(texts)
+	for p.pos < len(p.lines) && p.lines[p.pos].number <= last {
+		p.pos++
+	}
+
+	sep := "\n"
+	if isFolded {
+		sep = " "
+	}
+	return &node{kind: scalarNode, line: number, value: strings.Join(texts, sep)}
+}
+
+// parseScalar разбирает скаляр или однострочный список [a, b].
+func (p *yamlParser) parseScalar(text string, number int) (*node, error) {
+	if strings.HasPrefix(text, "{") {
+		return nil, p.errorAt(number, "однострочные отображения {...} не поддерживаются")
+	}
+	if strings.HasPrefix(text, "[") {
+		if !strings.HasSuffix(text, "]") {
+			return nil, p.errorAt(number, "список не закрыт скобкой ]")
+		}
+		n := &node{kind: listNode, line: number}
+		inner := strings.TrimSpace(text[1 : len(text)-1])
+		if inner == "" {
+			return n, nil
+		}
+		for _, part := range splitYAMLFlow(inner) {
+			value, err := unquoteYAML(strings.TrimSpace(part))
+			if err != nil {
+				return nil, p.errorAt(number, err.Error())
+			}
+			n.items = append(n.items, &node{kind: scalarNode, line: number, value: value})
+		}
+		return n, nil
+	}
+
+	value, err := unquoteYAML(text)
+	if err != nil {
+		return nil, p.errorAt(number, err.Error())
+	}
+	return &node{kind: scalarNode, line: number, value: value}, nil
+}
+
+// errorAt возвращает синтаксическую ошибку в строке number.
+func (p *yamlParser) errorAt(number int, msg string) error {
+	return &FieldError{Line: number, Err: fmt.Errorf("%w: %s", ErrSyntax, msg)}
+}
+
+// isYAMLSequenceItem проверяет, что строка - элемент последовательности "- ...".
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// cutYAMLKey отделяет ключ от значения по первому ": " (или ":" в конце) вне кавычек.
+func cutYAMLKey(text string) (string, string, bool) {
+	quote := rune(0)
+	for i, r := range text {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			if i == 0 {
+				quote = r
+			}
+		case r == ':' && (i == len(text)-1 || text[i+1] == ' '):
+			key, err := unquoteYAML(strings.TrimSpace(text[:i]))
+			if err != nil || key == "" {
+				return "", "", false
+			}
+			return key, strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// stripYAMLComment отрезает комментарий: # в начале строки или после пробела, вне кавычек.
+func stripYAMLComment(line string) string {
+	quote := rune(0)
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			if i == 0 || line[i-1] == ' ' || line[i-1] == '[' || line[i-1] == '-' {
+				quote = r
+			}
+		case r == '#' && (i == 0 || line[i-1] == ' '):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// splitYAMLFlow делит содержимое [a, "b, c"] по запятым вне кавычек.
+func splitYAMLFlow(text string) []string {
+	var parts []string
+	quote := rune(0)
+	start := 0
+	for i, r := range text {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',':
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// unquoteYAML снимает кавычки со скаляра. В "двойных" кавычках работают экранирования Go,
+// в 'одинарных' одинарная кавычка внутри строки удваивается.
+func unquoteYAML(text string) (string, error) {
+	switch {
+	case strings.HasPrefix(text, `"`):
+		value, err := strconv.Unquote(text)
+		if err != nil {
+			return "", fmt.Errorf("неверная строка в кавычках %s", text)
+		}
+		return value, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return "", fmt.Errorf("строка не закрыта кавычкой: %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	}
+	return text, nil
+}
@@ -0,0 +1,214 @@
+package adventure
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// dumpNode записывает дерево в одну строку: {ключ: значение}, [элемент], "скаляр",
+// а с isLines - с номером строки каждого узла: 3{...}.
+func dumpNode(n *node, isLines bool) string {
+	var b strings.Builder
+	if isLines {
+		b.WriteString(strconv.Itoa(n.line))
+	}
+	switch n.kind {
+	case scalarNode:
+		b.WriteString(strconv.Quote(n.value))
+	case mapNode:
+		b.WriteString("{")
+		for i, f := range n.fields {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(f.key + ": " + dumpNode(f.value, isLines))
+		}
+		b.WriteString("}")
+	case listNode:
+		b.WriteString("[")
+		for i, item := range n.items {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(dumpNode(item, isLines))
+		}
+		b.WriteString("]")
+	}
+	return b.String()
+}
+
+// yamlDoc склеивает строки документа.
+func yamlDoc(lines ...string) string {
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{"пустой документ", "", `{}`},
+		{"только комментарии", yamlDoc("# мир", "---", "  # пусто"), `{}`},
+		{"скаляры", yamlDoc("title: Пещера", "start: вход"), `{title: "Пещера", start: "вход"}`},
+		{"вложенное отображение", yamlDoc(
+			"room:",
+			"  id: вход",
+			"  exit:",
+			"    to: грот",
+			"name: мир",
+		), `{room: {id: "вход", exit: {to: "грот"}}, name: "мир"}`},
+		{"последовательность отображений", yamlDoc(
+			"rooms:",
+			"  - id: a",
+			"    name: A",
+			"  - id: b",
+			"  -",
+			"    id: c",
+		), `{rooms: [{id: "a", name: "A"}, {id: "b"}, {id: "c"}]}`},
+		{"последовательность на отступе ключа", yamlDoc(
+			"items:",
+			"- нож",
+			"- фонарь",
+			"start: вход",
+		), `{items: ["нож", "фонарь"], start: "вход"}`},
+		{"вложенные последовательности", yamlDoc(
+			"-",
+			"  - a",
+			"  - b",
+			"- c",
+		), `[["a", "b"], "c"]`},
+		{"однострочные списки", yamlDoc(
+			`words: [на восток, "b, c", 'd''e', ""]`,
+			"empty: []",
+		), `{words: ["на восток", "b, c", "d'e", ""], empty: []}`},
+		{"кавычки", yamlDoc(
+			`a: "строка # не комментарий"`,
+			`b: 'it''s'`,
+			`c: "таб\tи \"кавычки\""`,
+			`"ключ: с двоеточием": v`,
+			`d: "  пробелы  "`,
+			`e: 'a: b'`,
+		), `{a: "строка # не комментарий", b: "it's", c: "таб\tи \"кавычки\"", ключ: с двоеточием: "v", d: "  пробелы  ", e: "a: b"}`},
+		{"комментарии", yamlDoc(
+			"# заголовок",
+			"a: 1 # число",
+			"b: x#y",
+			"  # комментарий с отступом",
+			"c: 'не # комментарий'",
+			"d: http://example.com",
+		), `{a: "1", b: "x#y", c: "не # комментарий", d: "http://example.com"}`},
+		{"пустое значение", yamlDoc("a:", "b: 2"), `{a: "", b: "2"}`},
+		{"многострочный текст", yamlDoc(
+			"text: |",
+			"  Первая строка.",
+			"    # с отступом, не комментарий",
+			"",
+			"  Последняя.",
+			"",
+			"folded: >",
+			"  склеить",
+			"  строки",
+			"end: 1",
+		), `{text: "Первая строка.\n  # с отступом, не комментарий\n\nПоследняя.", folded: "склеить строки", end: "1"}`},
+		{"CRLF", "a: 1\r\nb:\r\n  - x\r\n", `{a: "1", b: ["x"]}`},
+		{"отступ всего документа", yamlDoc("  a: 1", "  b: 2"), `{a: "1", b: "2"}`},
+	}
+	for _, tt := range tests {
+		root, err := parseYAML([]byte(tt.doc))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := dumpNode(root, false); got != tt.want {
+			t.Errorf("%s:\n got %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestParseYAMLLines проверяет номера строк узлов: по ним проверка мира показывает место ошибки.
+func TestParseYAMLLines(t *testing.T) {
+	doc := yamlDoc(
+		"# мир",
+		"title: Пещера",
+		"",
+		"rooms:",
+		"  - id: a",
+		"    exits: [b]",
+		"  # комментарий",
+		"  - id: b",
+		"    text: |",
+		"      строка",
+		"    name: B",
+	)
+	root, err := parseYAML([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `2{title: 2"Пещера", rooms: 5[5{id: 5"a", exits: 6[6"b"]}, 8{id: 8"b", text: 9"строка", name: 11"B"}]}`
+	if got := dumpNode(root, true); got != want {
+		t.Errorf("\n got %s\nwant %s", got, want)
+	}
+	for _, f := range root.fields {
+		if f.key == "rooms" && f.line != 4 {
+			t.Errorf("строка ключа rooms = %d, want 4", f.line)
+		}
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		line int
+		text string // часть сообщения
+	}{
+		{"табуляция", yamlDoc("a: 1", "\tb: 2"), 2, "табуляция"},
+		{"табуляция после пробелов", yamlDoc("a:", "  \tb: 2"), 2, "табуляция"},
+		{"повтор ключа", yamlDoc("a: 1", "b: 2", "a: 3"), 3, `ключ "a" повторяется`},
+		{"лишний отступ", yamlDoc("a: 1", "  b: 2"), 2, "неожиданный отступ"},
+		{"отступ между уровнями", yamlDoc("a:", "    b: 1", "  c: 2"), 3, "неожиданный отступ"},
+		{"нет двоеточия", yamlDoc("a: 1", "просто текст"), 2, "ключ: значение"},
+		{"пустой ключ", yamlDoc(`"": 1`), 1, "ключ: значение"},
+		{"однострочное отображение", yamlDoc("a: 1", "b: {c: 2}"), 2, "{...}"},
+		{"незакрытый список", yamlDoc("a: [1, 2"), 1, "]"},
+		{"незакрытая двойная кавычка", yamlDoc("a: 1", "", `b: "abc`), 3, "кавычках"},
+		{"незакрытая одинарная кавычка", yamlDoc("a: 'abc"), 1, "не закрыта"},
+		{"плохая кавычка в списке", yamlDoc(`a: [x, "y]`), 1, "кавычках"},
+		{"отображение после последовательности", yamlDoc("- a", "b: 1"), 2, "неожиданный отступ"},
+	}
+	for _, tt := range tests {
+		root, err := parseYAML([]byte(tt.doc))
+		var fe *FieldError
+		if !errors.Is(err, ErrSyntax) || !errors.As(err, &fe) {
+			t.Errorf("%s: parseYAML = %v, %v, want ErrSyntax", tt.name, root, err)
+			continue
+		}
+		if fe.Line != tt.line || !strings.Contains(err.Error(), tt.text) {
+			t.Errorf("%s: ошибка %q в строке %d, want строка %d и %q", tt.name, err, fe.Line, tt.line, tt.text)
+		}
+	}
+}
+
+func TestStripYAMLComment(t *testing.T) {
+	tests := []struct {
+		line, want string
+	}{
+		{"# весь", ""},
+		{"a: 1 # хвост", "a: 1 "},
+		{"a: x#y", "a: x#y"},
+		{`a: "x # y" # z`, `a: "x # y" `},
+		{`a: 'x # y'`, `a: 'x # y'`},
+		{`- "#"`, `- "#"`},
+		{`a: [x, "#"] # z`, `a: [x, "#"] `},
+		// кавычка внутри слова не открывает строку
+		{`a: don't # z`, `a: don't `},
+	}
+	for _, tt := range tests {
+		if got := stripYAMLComment(tt.line); got != tt.want {
+			t.Errorf("stripYAMLComment(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+// Package bitwise - просмотр и изменение битов целых чисел, продолжение f64.
+//
+// f64 печатает биты uint8 через %08b до и после увеличения на 1. Здесь то же самое
+// работает для любого целого типа: Value хранит битовый шаблон вместе с типом (Kind),
+// поэтому знает ширину для выравнивания, знаковый бит для дополнительного кода
+// и границы, за которыми сложение переполняется, как в f63.
+package bitwise
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"example/internal/safeint"
+)
+
+var (
+	// ErrUnknownKind возвращается для неизвестного имени целого типа.
+	ErrUnknownKind = errors.New("неизвестный целый тип")
+	// ErrBitRange возвращается для номера бита за пределами типа.
+	ErrBitRange = errors.New("номер бита вне типа")
+)
+
+// Kind - целый тип: ширина в битах и знаковость.
+type Kind struct {
+	Name     string
+	Bits     int
+	IsSigned bool
+}
+
+var (
+	Int8   = Kind{Name: "int8", Bits: 8, IsSigned: true}
+	Int16  = Kind{Name: "int16", Bits: 16, IsSigned: true}
+	Int32  = Kind{Name: "int32", Bits: 32, IsSigned: true}
+	Int64  = Kind{Name: "int64", Bits: 64, IsSigned: true}
+	Int    = Kind{Name: "int", Bits: strconv.IntSize, IsSigned: true}
+	Uint8  = Kind{Name: "uint8", Bits: 8}
+	Uint16 = Kind{Name: "uint16", Bits: 16}
+	Uint32 = Kind{Name: "uint32", Bits: 32}
+	Uint64 = Kind{Name: "uint64", Bits: 64}
+	Uint   = Kind{Name: "uint", Bits: strconv.IntSize}
+)
+
+// GetKinds возвращает все целые типы: сначала знаковые, потом беззнаковые.
+func GetKinds() []Kind {
+	return []Kind{Int8, Int16, Int32, Int64, Int, Uint8, Uint16, Uint32, Uint64, Uint}
+}
+
+// ParseKind возвращает тип по имени Go, в том числе byte (uint8) и rune (int32).
+func ParseKind(name string) (Kind, error) {
+	switch name {
+	case "byte":
+		return Uint8, nil
+	case "rune":
+		return Int32, nil
+	}
+	var names []string
+	for _, k := range GetKinds() {
+		if k.Name == name {
+			return k, nil
+		}
+		names = append(names, k.Name)
+	}
+	return Kind{}, fmt.Errorf("%w: %q, есть %s", ErrUnknownKind, name, strings.Join(names, ", "))
+}
+
+// KindOf возвращает Kind типа T: int и uint узнаются по имени, остальные типы,
+// в том числе типы на основе целых, - по размеру и знаковости.
+func KindOf[T safeint.Integer]() Kind {
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		return Int
+	case uint:
+		return Uint
+	}
+	for _, k := range GetKinds() {
+		if k.Bits == safeint.BitSize[T]() && k.IsSigned == safeint.IsSigned[T]() {
+			return k
+		}
+	}
+	panic("bitwise: нет Kind для типа") // у каждого целого типа есть Kind той же ширины
+}
+
+// String возвращает имя типа.
+func (k Kind) String() string { return k.Name }
+
+// GetMask возвращает маску из Bits единиц.
+func (k Kind) GetMask() uint64 {
+	return ^uint64(0) >> (64 - k.Bits)
+}
+
+// GetMin возвращает наименьшее значение типа: -128 для int8, 0 для беззнаковых.
+func (k Kind) GetMin() int64 {
+	if !k.IsSigned {
+		return 0
+	}
+	return -int64(k.GetMask()>>1) - 1
+}
+
+// GetMax возвращает наибольшее значение типа: 127 для int8, 255 для uint8.
+func (k Kind) GetMax() uint64 {
+	if k.IsSigned {
+		return k.GetMask() >> 1
+	}
+	return k.GetMask()
+}
+
+// Value - битовый шаблон значения типа Kind. Биты выше ширины типа всегда нулевые.
+type Value struct {
+	Kind Kind
+	raw  uint64
+}
+
+// FromBits возвращает значение с битовым шаблоном raw; лишние старшие биты отбрасываются.
+func FromBits(k Kind, raw uint64) Value {
+	return Value{Kind: k, raw: raw & k.GetMask()}
+}
+
+// Of возвращает значение v вместе с его типом.
+func Of[T safeint.Integer](v T) Value {
+	return FromBits(KindOf[T](), uint64(v)) // для отрицательных uint64 сохраняет дополнительный код
+}
+
+// Parse разбирает число типа k в десятичной записи или с префиксом 0b, 0o, 0x, как в исходниках Go,
+// в том числе с подчеркиваниями: 0b1000_0011. Для знаковых типов двоичную, восьмеричную
+// и шестнадцатеричную запись можно задать битовым шаблоном: 0x83 в int8 - это -125.
+func Parse(s string, k Kind) (Value, error) {
+	if k.IsSigned {
+		n, err := strconv.ParseInt(s, 0, k.Bits)
+		if err == nil {
+			return FromBits(k, uint64(n)), nil
+		}
+		if !hasBasePrefix(s) {
+			return Value{}, err
+		}
+	}
+	n, err := strconv.ParseUint(s, 0, k.Bits)
+	if err != nil {
+		return Value{}, err
+	}
+	return FromBits(k, n), nil
+}
+
+// hasBasePrefix проверяет, что число записано с префиксом основания: 0b, 0o или 0x.
+func hasBasePrefix(s string) bool {
+	s = strings.ToLower(s)
+	return strings.HasPrefix(s, "0b") || strings.HasPrefix(s, "0o") || strings.HasPrefix(s, "0x")
+}
+
+// GetBits возвращает битовый шаблон.
+func (v Value) GetBits() uint64 { return v.raw }
+
+// IsNegative проверяет, что у знакового значения установлен знаковый бит.
+func (v Value) IsNegative() bool {
+	return v.Kind.IsSigned && v.raw>>(v.Kind.Bits-1) == 1
+}
+
+// Int64 возвращает значение знакового типа с расширением знака. Для uint64 больше MaxInt64 результат отрицательный.
+func (v Value) Int64() int64 {
+	if v.IsNegative() {
+		return int64(v.raw | ^v.Kind.GetMask())
+	}
+	return int64(v.raw)
+}
+
+// String возвращает значение в десятичной записи с учетом знака.
+func (v Value) String() string {
+	if v.Kind.IsSigned {
+		return strconv.FormatInt(v.Int64(), 10)
+	}
+	return strconv.FormatUint(v.raw, 10)
+}
+
+// Format возвращает битовый шаблон в системе счисления base (2, 8, 16) с ведущими нулями
+// до ширины типа, как %08b в f64. При group > 0 цифры делятся подчеркиванием на группы
+// по group справа, как в литералах Go: 1000_0011.
+func (v Value) Format(base, group int) string {
+	return formatRaw(v.raw, v.Kind, base, group)
+}
+
+// formatRaw форматирует raw как битовый шаблон типа k.
+func formatRaw(raw uint64, k Kind, base, group int) string {
+	width := len(strconv.FormatUint(k.GetMask(), base))
+	digits := strconv.FormatUint(raw, base)
+	digits = strings.Repeat("0", width-len(digits)) + digits
+	if group <= 0 {
+		return digits
+	}
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%group == 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// checkBit проверяет, что бит i есть в типе. Биты нумеруются с младшего, с 0.
+func (v Value) checkBit(i int) error {
+	if i < 0 || i >= v.Kind.Bits {
+		return fmt.Errorf("%w: бит %d, в %v биты 0-%d", ErrBitRange, i, v.Kind, v.Kind.Bits-1)
+	}
+	return nil
+}
+
+// Test проверяет, установлен ли бит i.
+func (v Value) Test(i int) (bool, error) {
+	if err := v.checkBit(i); err != nil {
+		return false, err
+	}
+	return v.raw&(1<<i) != 0, nil
+}
+
+// Set возвращает значение с установленным битом i.
+func (v Value) Set(i int) (Value, error) {
+	if err := v.checkBit(i); err != nil {
+		return Value{}, err
+	}
+	return FromBits(v.Kind, v.raw|1<<i), nil
+}
+
+// Clear возвращает значение со сброшенным битом i.
+func (v Value) Clear(i int) (Value, error) {
+	if err := v.checkBit(i); err != nil {
+		return Value{}, err
+	}
+	return FromBits(v.Kind, v.raw&^(1<<i)), nil
+}
+
+// Toggle возвращает значение с инвертированным битом i.
+func (v Value) Toggle(i int) (Value, error) {
+	if err := v.checkBit(i); err != nil {
+		return Value{}, err
+	}
+	return FromBits(v.Kind, v.raw^1<<i), nil
+}
+
+// Not возвращает значение со всеми инвертированными битами, как ^x.
+func (v Value) Not() Value {
+	return FromBits(v.Kind, ^v.raw)
+}
+
+// Field возвращает битовое поле с бита lo по бит hi включительно, сдвинутое к младшим битам:
+// поле 4-7 числа 1010_0011 - это 1010. Результат - значение беззнакового типа ширины hi-lo+1,
+// округленной вверх до 8, 16, 32 или 64 бит.
+func (v Value) Field(lo, hi int) (Value, error) {
+	for _, i := range []int{lo, hi} {
+		if err := v.checkBit(i); err != nil {
+			return Value{}, err
+		}
+	}
+	if lo > hi {
+		return Value{}, fmt.Errorf("%w: поле %d-%d, младший бит больше старшего", ErrBitRange, lo, hi)
+	}
+	width := hi - lo + 1
+	k := Uint64
+	for _, candidate := range []Kind{Uint8, Uint16, Uint32} {
+		if width <= candidate.Bits {
+			k = candidate
+			break
+		}
+	}
+	return FromBits(k, v.raw>>lo&(^uint64(0)>>(64-width))), nil
+}
+
+// OnesCount возвращает число установленных битов.
+func (v Value) OnesCount() int { return bits.OnesCount64(v.raw) }
+
+// LeadingZeros возвращает число нулевых битов перед старшей единицей, в ширине типа.
+func (v Value) LeadingZeros() int { return bits.LeadingZeros64(v.raw) - (64 - v.Kind.Bits) }
+
+// TrailingZeros возвращает число нулевых битов после младшей единицы; для нуля - ширину типа.
+func (v Value) TrailingZeros() int { return min(bits.TrailingZeros64(v.raw), v.Kind.Bits) }
+
+// Len возвращает число битов, нужных для записи шаблона без ведущих нулей.
+func (v Value) Len() int { return bits.Len64(v.raw) }
+
+// RotateLeft циклически сдвигает биты влево на k в ширине типа; при k < 0 - вправо.
+// Выдвинутые слева биты возвращаются справа, а не теряются, как при v << k.
+func (v Value) RotateLeft(k int) Value {
+	var raw uint64
+	switch v.Kind.Bits {
+	case 8:
+		raw = uint64(bits.RotateLeft8(uint8(v.raw), k))
+	case 16:
+		raw = uint64(bits.RotateLeft16(uint16(v.raw), k))
+	case 32:
+		raw = uint64(bits.RotateLeft32(uint32(v.raw), k))
+	default:
+		raw = bits.RotateLeft64(v.raw, k)
+	}
+	return FromBits(v.Kind, raw)
+}
+
+// Reverse возвращает значение с битами в обратном порядке.
+func (v Value) Reverse() Value {
+	return FromBits(v.Kind, bits.Reverse64(v.raw)>>(64-v.Kind.Bits))
+}
+
+// Add возвращает v + delta с переполнением по модулю 2^n, как встроенный оператор в f63,
+// и сообщает, было ли переполнение.
+func (v Value) Add(delta int64) (sum Value, isOverflow bool) {
+	sum = FromBits(v.Kind, v.raw+uint64(delta)) // в дополнительном коде сложение одно для всех типов
+	if v.Kind.IsSigned {
+		n, err := safeint.Add(v.Int64(), delta)
+		return sum, err != nil || n < v.Kind.GetMin() || n > int64(v.Kind.GetMax())
+	}
+	if delta >= 0 {
+		n, err := safeint.Add(v.raw, uint64(delta))
+		return sum, err != nil || n > v.Kind.GetMax()
+	}
+	// модуль delta без переполнения для math.MinInt64
+	return sum, uint64(-(delta+1))+1 > v.raw
+}
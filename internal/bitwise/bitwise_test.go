@@ -0,0 +1,184 @@
+package bitwise
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s       string
+		kind    Kind
+		want    string // десятичная запись
+		wantRaw uint64
+		isErr   bool
+	}{
+		{s: "0x83", kind: Int8, want: "-125", wantRaw: 0x83},
+		{s: "0b1000_0011", kind: Int8, want: "-125", wantRaw: 0x83},
+		{s: "0x83", kind: Uint8, want: "131", wantRaw: 0x83},
+		{s: "-125", kind: Int8, want: "-125", wantRaw: 0x83},
+		{s: "127", kind: Int8, want: "127", wantRaw: 0x7f},
+		{s: "0o377", kind: Int8, want: "-1", wantRaw: 0xff},
+		{s: "0xffff", kind: Int16, want: "-1", wantRaw: 0xffff},
+		{s: "-9223372036854775808", kind: Int64, want: "-9223372036854775808", wantRaw: 1 << 63},
+		{s: "0xffffffffffffffff", kind: Uint64, want: "18446744073709551615", wantRaw: math.MaxUint64},
+		{s: "128", kind: Int8, isErr: true},   // десятичная запись не битовый шаблон
+		{s: "-129", kind: Int8, isErr: true},  // меньше наименьшего int8
+		{s: "0x100", kind: Int8, isErr: true}, // шаблон шире типа
+		{s: "256", kind: Uint8, isErr: true},
+		{s: "-1", kind: Uint8, isErr: true},
+		{s: "x", kind: Int, isErr: true},
+	}
+	for _, tt := range tests {
+		v, err := Parse(tt.s, tt.kind)
+		if tt.isErr {
+			if err == nil {
+				t.Errorf("Parse(%q, %v) = %v, want error", tt.s, tt.kind, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q, %v): %v", tt.s, tt.kind, err)
+			continue
+		}
+		if v.String() != tt.want || v.GetBits() != tt.wantRaw {
+			t.Errorf("Parse(%q, %v) = %v (0x%x), want %s (0x%x)", tt.s, tt.kind, v, v.GetBits(), tt.want, tt.wantRaw)
+		}
+	}
+}
+
+func TestField(t *testing.T) {
+	tests := []struct {
+		v        Value
+		lo, hi   int
+		want     uint64
+		wantKind Kind
+	}{
+		{FromBits(Uint8, 0b1010_0011), 4, 7, 0b1010, Uint8},
+		{FromBits(Uint8, 0b1010_0011), 0, 1, 0b11, Uint8},
+		{FromBits(Uint8, 0b1010_0011), 0, 7, 0b1010_0011, Uint8},
+		{FromBits(Int8, 0x83), 7, 7, 1, Uint8}, // знаковый бит как поле
+		{FromBits(Uint16, 0xabcd), 4, 11, 0xbc, Uint8},
+		{FromBits(Uint16, 0xabcd), 3, 11, 0x179, Uint16},
+		{FromBits(Uint32, 0xdeadbeef), 8, 23, 0xadbe, Uint16},
+		{FromBits(Uint32, 0xdeadbeef), 0, 16, 0x1beef, Uint32},
+		{FromBits(Uint64, math.MaxUint64), 0, 63, math.MaxUint64, Uint64},
+		{FromBits(Uint64, 1<<63|1<<30), 30, 63, 1<<33 | 1, Uint64},
+	}
+	for _, tt := range tests {
+		got, err := tt.v.Field(tt.lo, tt.hi)
+		if err != nil {
+			t.Errorf("%v.Field(%d, %d): %v", tt.v.Format(2, 4), tt.lo, tt.hi, err)
+			continue
+		}
+		if got.GetBits() != tt.want || got.Kind != tt.wantKind {
+			t.Errorf("%v.Field(%d, %d) = 0x%x %v, want 0x%x %v", tt.v.Format(2, 4), tt.lo, tt.hi, got.GetBits(), got.Kind, tt.want, tt.wantKind)
+		}
+	}
+
+	for _, bad := range [][2]int{{-1, 3}, {0, 8}, {5, 4}} {
+		if _, err := FromBits(Uint8, 0xff).Field(bad[0], bad[1]); !errors.Is(err, ErrBitRange) {
+			t.Errorf("Field(%d, %d) в uint8: err = %v, want ErrBitRange", bad[0], bad[1], err)
+		}
+	}
+}
+
+func TestRotateLeft(t *testing.T) {
+	tests := []struct {
+		v    Value
+		k    int
+		want uint64
+	}{
+		{FromBits(Uint8, 0b1000_0001), 1, 0b0000_0011},
+		{FromBits(Uint8, 0b1000_0001), -1, 0b1100_0000},
+		{FromBits(Uint8, 0b1000_0001), 8, 0b1000_0001},
+		{FromBits(Uint8, 0b1000_0001), 9, 0b0000_0011},
+		{FromBits(Int8, 0x83), 4, 0x38},
+		{FromBits(Uint16, 0x8001), 4, 0x0018},
+		{FromBits(Uint32, 0x8000_0001), -4, 0x1800_0000},
+		{FromBits(Int32, 0xf000_0000), 4, 0x0000_000f},
+		{FromBits(Uint64, 1<<63), 1, 1},
+		{FromBits(Int64, 1), -1, 1 << 63},
+	}
+	for _, tt := range tests {
+		got := tt.v.RotateLeft(tt.k)
+		if got.GetBits() != tt.want || got.Kind != tt.v.Kind {
+			t.Errorf("%v %v.RotateLeft(%d) = 0x%x, want 0x%x", tt.v.Kind, tt.v.Format(2, 4), tt.k, got.GetBits(), tt.want)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		v    Value
+		want uint64
+	}{
+		{FromBits(Uint8, 0b1000_0011), 0b1100_0001},
+		{FromBits(Int8, 0b0000_0001), 0b1000_0000},
+		{FromBits(Uint16, 0x0001), 0x8000},
+		{FromBits(Uint16, 0x00ff), 0xff00},
+		{FromBits(Uint32, 0x0000_000f), 0xf000_0000},
+		{FromBits(Uint64, 1), 1 << 63},
+		{FromBits(Uint64, 0), 0},
+	}
+	for _, tt := range tests {
+		got := tt.v.Reverse()
+		if got.GetBits() != tt.want {
+			t.Errorf("%v %v.Reverse() = 0x%x, want 0x%x", tt.v.Kind, tt.v.Format(2, 4), got.GetBits(), tt.want)
+		}
+		if back := got.Reverse(); back != tt.v {
+			t.Errorf("%v %v: двойной Reverse = %v", tt.v.Kind, tt.v.Format(2, 4), back.Format(2, 4))
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		v          Value
+		delta      int64
+		want       string
+		isOverflow bool
+	}{
+		{Of(uint8(255)), 1, "0", true}, // f63
+		{Of(uint8(254)), 1, "255", false},
+		{Of(uint8(0)), -1, "255", true},
+		{Of(uint8(10)), -10, "0", false},
+		{Of(int8(127)), 1, "-128", true},
+		{Of(int8(127)), 3, "-126", true}, // f65
+		{Of(int8(-128)), -1, "127", true},
+		{Of(int8(-100)), 200, "100", false},
+		{Of(int8(0)), 256, "0", true},     // delta шире типа
+		{Of(uint16(65535)), 1, "0", true}, // f67
+		{Of(int64(math.MaxInt64)), 1, "-9223372036854775808", true},
+		{Of(int64(math.MinInt64)), -1, "9223372036854775807", true},
+		{Of(int64(math.MinInt64)), math.MaxInt64, "-1", false},
+		{Of(uint64(math.MaxUint64)), 1, "0", true},
+		{Of(uint64(5)), math.MinInt64, "9223372036854775813", true},
+		{Of(uint64(1 << 63)), math.MinInt64, "0", false},
+	}
+	for _, tt := range tests {
+		got, isOverflow := tt.v.Add(tt.delta)
+		if got.String() != tt.want || isOverflow != tt.isOverflow || got.Kind != tt.v.Kind {
+			t.Errorf("%v %v + %d = %v, %t, want %s, %t", tt.v.Kind, tt.v, tt.delta, got, isOverflow, tt.want, tt.isOverflow)
+		}
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	type celsius int16
+	for _, tt := range []struct {
+		got, want Kind
+	}{
+		{KindOf[int8](), Int8},
+		{KindOf[uint8](), Uint8},
+		{KindOf[int](), Int},
+		{KindOf[uint](), Uint},
+		{KindOf[int64](), Int64},
+		{KindOf[celsius](), Int16},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("KindOf = %v, want %v", tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package bitwise
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Explain раскладывает значение по весам установленных битов: "-128 + 2 + 1 = -125".
+// В дополнительном коде старший бит знакового типа весит не 2^(n-1), а -2^(n-1):
+// поэтому 1000_0011 в uint8 - это 131, а в int8 - -125.
+func (v Value) Explain() string {
+	var terms []string
+	for i := v.Kind.Bits - 1; i >= 0; i-- {
+		if v.raw&(1<<i) == 0 {
+			continue
+		}
+		weight := strconv.FormatUint(1<<i, 10)
+		if v.Kind.IsSigned && i == v.Kind.Bits-1 {
+			weight = "-" + weight
+		}
+		terms = append(terms, weight)
+	}
+	if len(terms) == 0 {
+		terms = []string{"0"}
+	}
+	return strings.Join(terms, " + ") + " = " + v.String()
+}
+
+// Step - строка таблицы переполнения: значение после очередного сложения.
+type Step struct {
+	Value      Value
+	Flipped    uint64 // биты, изменившиеся после предыдущего шага
+	IsOverflow bool   // сложение вышло за границы типа и перешло на другой край
+}
+
+// CalcSteps возвращает start и n следующих значений, каждое на delta больше предыдущего,
+// с переполнением, как в f63: для uint8 255 + 1 = 0, для int8 127 + 10 = -119.
+func CalcSteps(start Value, delta int64, n int) []Step {
+	steps := []Step{{Value: start}}
+	v := start
+	for range n {
+		next, isOverflow := v.Add(delta)
+		steps = append(steps, Step{Value: next, Flipped: v.raw ^ next.raw, IsOverflow: isOverflow})
+		v = next
+	}
+	return steps
+}
+
+// WriteSteps печатает шаги таблицей: десятичное значение, битовый шаблон по 4 бита
+// и под ним отметки ^ у изменившихся битов. Переполнение обычно меняет почти все биты:
+// 1111_1111 + 1 = 0000_0000 в uint8, 0111_1111 + 1 = 1000_0000 в int8.
+func WriteSteps(w io.Writer, steps []Step, delta int64) error {
+	if len(steps) == 0 {
+		return nil
+	}
+	k := steps[0].Value.Kind
+	numberWidth := max(len(strconv.FormatInt(k.GetMin(), 10)), len(strconv.FormatUint(k.GetMax(), 10)), len(k.Name))
+	op := fmt.Sprintf("%+d", delta)
+	indent := strings.Repeat(" ", len(op))
+	if _, err := fmt.Fprintf(w, "%s  %*s  %s\n", indent, numberWidth, k.Name, "биты"); err != nil {
+		return err
+	}
+
+	for i, s := range steps {
+		prefix := indent
+		if i > 0 {
+			prefix = op
+		}
+		line := fmt.Sprintf("%s  %*s  %s", prefix, numberWidth, s.Value, s.Value.Format(2, 4))
+		if s.IsOverflow {
+			line += "  переполнение"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		if s.Flipped == 0 {
+			continue
+		}
+		marks := strings.NewReplacer("1", "^", "0", " ", "_", " ").Replace(formatRaw(s.Flipped, k, 2, 4))
+		if _, err := fmt.Fprintf(w, "%s  %*s  %s\n", indent, numberWidth, "", strings.TrimRight(marks, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Package booking хранит бронирования билетов на Марс из internal/tickets.
+// Бронь создается в статусе Reserved, затем подтверждается или отменяется.
+// Хранилище описано интерфейсом Repository: MemoryRepository для тестов
+// и SQLRepository поверх database/sql со схемой, совместимой с SQLite.
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example/internal/tickets"
+)
+
+var (
+	// ErrNotFound возвращается, если брони с таким ID нет.
+	ErrNotFound = errors.New("бронь не найдена")
+	// ErrDuplicateID возвращается при попытке сохранить бронь с уже занятым ID.
+	ErrDuplicateID = errors.New("бронь с таким ID уже существует")
+	// ErrStatusChanged возвращается, если статус брони изменился между чтением и записью.
+	ErrStatusChanged = errors.New("статус брони изменился")
+	// ErrInvalidTransition возвращается при недопустимой смене статуса,
+	// например при подтверждении отмененной брони.
+	ErrInvalidTransition = errors.New("недопустимая смена статуса брони")
+	// ErrInvalidStatus возвращается для неизвестного статуса.
+	ErrInvalidStatus = errors.New("неизвестный статус брони")
+)
+
+// Status - статус брони.
+type Status string
+
+const (
+	Reserved  Status = "reserved"  // билет зарезервирован, но не подтвержден
+	Confirmed Status = "confirmed" // бронь подтверждена
+	Cancelled Status = "cancelled" // бронь отменена
+)
+
+// IsValid проверяет, что статус известен.
+func (s Status) IsValid() bool {
+	return s == Reserved || s == Confirmed || s == Cancelled
+}
+
+// ParseStatus разбирает статус из строки.
+func ParseStatus(s string) (Status, error) {
+	status := Status(s)
+	if !status.IsValid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidStatus, s)
+	}
+	return status, nil
+}
+
+// Booking - бронь билета пассажиром.
+type Booking struct {
+	ID        string         `json:"id"`
+	Passenger string         `json:"passenger"`
+	Status    Status         `json:"status"`
+	Ticket    tickets.Ticket `json:"ticket"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// Repository описывает хранилище броней.
+type Repository interface {
+	// Create сохраняет новую бронь. Возвращает ErrDuplicateID, если ID занят.
+	Create(ctx context.Context, b Booking) error
+	// Get возвращает бронь по ID или ErrNotFound.
+	Get(ctx context.Context, id string) (Booking, error)
+	// SetStatus меняет статус брони с from на to, если текущий статус равен from.
+	// Возвращает ErrNotFound, если брони нет, и ErrStatusChanged, если статус уже не from.
+	SetStatus(ctx context.Context, id string, from, to Status, at time.Time) error
+	// ListByPassenger возвращает брони пассажира в порядке создания.
+	ListByPassenger(ctx context.Context, passenger string) ([]Booking, error)
+}
@@ -0,0 +1,76 @@
+package booking
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryRepository - хранилище броней в памяти. Безопасно для использования из нескольких горутин.
+type MemoryRepository struct {
+	mu       sync.RWMutex
+	bookings map[string]Booking
+	order    []string // ID в порядке создания
+}
+
+// NewMemoryRepository создает пустое хранилище в памяти.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{bookings: map[string]Booking{}}
+}
+
+// Create сохраняет новую бронь.
+func (r *MemoryRepository) Create(_ context.Context, b Booking) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.bookings[b.ID]; ok {
+		return ErrDuplicateID
+	}
+	r.bookings[b.ID] = b
+	r.order = append(r.order, b.ID)
+	return nil
+}
+
+// Get возвращает бронь по ID.
+func (r *MemoryRepository) Get(_ context.Context, id string) (Booking, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	b, ok := r.bookings[id]
+	if !ok {
+		return Booking{}, ErrNotFound
+	}
+	return b, nil
+}
+
+// SetStatus меняет статус брони с from на to.
+func (r *MemoryRepository) SetStatus(_ context.Context, id string, from, to Status, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.bookings[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if b.Status != from {
+		return ErrStatusChanged
+	}
+	b.Status = to
+	b.UpdatedAt = at
+	r.bookings[id] = b
+	return nil
+}
+
+// ListByPassenger возвращает брони пассажира в порядке создания.
+func (r *MemoryRepository) ListByPassenger(_ context.Context, passenger string) ([]Booking, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var list []Booking
+	for _, id := range r.order {
+		if b := r.bookings[id]; b.Passenger == passenger {
+			list = append(list, b)
+		}
+	}
+	return list, nil
+}
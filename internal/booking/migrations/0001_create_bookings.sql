@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS bookings (
+    id         TEXT    PRIMARY KEY,
+    passenger  TEXT    NOT NULL,
+    status     TEXT    NOT NULL,
+    spaceline  TEXT    NOT NULL,
+    speed      INTEGER NOT NULL,
+    trip       TEXT    NOT NULL,
+    days       INTEGER NOT NULL,
+    price      REAL    NOT NULL,
+    departure  TEXT    NOT NULL,
+    distance   INTEGER NOT NULL,
+    created_at TEXT    NOT NULL,
+    updated_at TEXT    NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bookings_passenger_idx ON bookings (passenger, created_at);
@@ -0,0 +1,29 @@
+-- Цена билета хранится в минимальных единицах валюты (центах) вместо миллионов долларов в REAL.
+-- SQLite не умеет менять тип колонки, поэтому таблица пересоздается.
+CREATE TABLE bookings_new (
+    id          TEXT    PRIMARY KEY,
+    passenger   TEXT    NOT NULL,
+    status      TEXT    NOT NULL,
+    spaceline   TEXT    NOT NULL,
+    speed       INTEGER NOT NULL,
+    trip        TEXT    NOT NULL,
+    days        INTEGER NOT NULL,
+    price_minor INTEGER NOT NULL,
+    currency    TEXT    NOT NULL,
+    departure   TEXT    NOT NULL,
+    distance    INTEGER NOT NULL,
+    created_at  TEXT    NOT NULL,
+    updated_at  TEXT    NOT NULL
+);
+
+INSERT INTO bookings_new
+    (id, passenger, status, spaceline, speed, trip, days, price_minor, currency, departure, distance, created_at, updated_at)
+SELECT id, passenger, status, spaceline, speed, trip, days, CAST(ROUND(price * 100000000) AS INTEGER), 'USD',
+       departure, distance, created_at, updated_at
+FROM bookings;
+
+DROP TABLE bookings;
+
+ALTER TABLE bookings_new RENAME TO bookings;
+
+CREATE INDEX IF NOT EXISTS bookings_passenger_idx ON bookings (passenger, created_at);
@@ -0,0 +1,152 @@
+package booking
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"example/internal/money"
+
+	_ "modernc.org/sqlite" // драйвер SQLite на чистом Go, только для тестов
+)
+
+// openTestDB открывает пустую базу SQLite в памяти, которая закрывается в конце теста.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// у каждого соединения своя база в памяти, поэтому соединение должно быть одно
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// repositories возвращает по новому пустому хранилищу каждой реализации Repository.
+func repositories(t *testing.T) map[string]func(t *testing.T) Repository {
+	return map[string]func(t *testing.T) Repository{
+		"memory": func(t *testing.T) Repository { return NewMemoryRepository() },
+		"sql": func(t *testing.T) Repository {
+			db := openTestDB(t)
+			if err := Migrate(context.Background(), db); err != nil {
+				t.Fatal(err)
+			}
+			return NewSQLRepository(db)
+		},
+	}
+}
+
+// testBooking возвращает бронь с ценой в центах и временем с наносекундами,
+// чтобы проверить, что хранилище не теряет точность.
+func testBooking(id, passenger string, created time.Time) Booking {
+	ticket := testTicket()
+	ticket.Price = money.New(3_500_000_005, money.USD)
+	return Booking{
+		ID:        id,
+		Passenger: passenger,
+		Status:    Reserved,
+		Ticket:    ticket,
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+}
+
+// TestRepositoryContract проверяет одни и те же требования Repository на каждой реализации.
+func TestRepositoryContract(t *testing.T) {
+	for name, newRepo := range repositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := newRepo(t)
+			created := testNow.Add(123456789 * time.Nanosecond)
+
+			want := testBooking("b1", "Гагарин", created)
+			if err := repo.Create(ctx, want); err != nil {
+				t.Fatal(err)
+			}
+			if err := repo.Create(ctx, testBooking("b1", "Титов", created)); !errors.Is(err, ErrDuplicateID) {
+				t.Errorf("Create с занятым ID: err = %v, want ErrDuplicateID", err)
+			}
+
+			got, err := repo.Get(ctx, "b1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.ID != want.ID || got.Passenger != want.Passenger || got.Status != want.Status ||
+				got.Ticket.Quote != want.Ticket.Quote || got.Ticket.Distance != want.Ticket.Distance ||
+				!got.Ticket.Departure.Equal(want.Ticket.Departure) ||
+				!got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+				t.Errorf("Get = %+v, want %+v", got, want)
+			}
+			if _, err := repo.Get(ctx, "нет"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get несуществующей: err = %v, want ErrNotFound", err)
+			}
+
+			confirmed := testNow.Add(time.Hour)
+			if err := repo.SetStatus(ctx, "b1", Reserved, Confirmed, confirmed); err != nil {
+				t.Fatal(err)
+			}
+			if err := repo.SetStatus(ctx, "b1", Reserved, Cancelled, confirmed); !errors.Is(err, ErrStatusChanged) {
+				t.Errorf("SetStatus со старым статусом: err = %v, want ErrStatusChanged", err)
+			}
+			if err := repo.SetStatus(ctx, "нет", Reserved, Confirmed, confirmed); !errors.Is(err, ErrNotFound) {
+				t.Errorf("SetStatus несуществующей: err = %v, want ErrNotFound", err)
+			}
+			got, err = repo.Get(ctx, "b1")
+			if err != nil || got.Status != Confirmed || !got.UpdatedAt.Equal(confirmed) || !got.CreatedAt.Equal(created) {
+				t.Errorf("после SetStatus: %+v, %v", got, err)
+			}
+
+			// порядок создания, а не ID и не порядок вставки
+			for _, b := range []Booking{
+				testBooking("b3", "Титов", testNow.Add(2*time.Minute)),
+				testBooking("b2", "Гагарин", testNow.Add(time.Minute)),
+				testBooking("b4", "Гагарин", testNow.Add(3*time.Minute)),
+			} {
+				if err := repo.Create(ctx, b); err != nil {
+					t.Fatal(err)
+				}
+			}
+			list, err := repo.ListByPassenger(ctx, "Гагарин")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, b := range list {
+				ids = append(ids, b.ID)
+			}
+			if len(ids) != 3 || ids[0] != "b1" || ids[1] != "b2" || ids[2] != "b4" {
+				t.Errorf("ListByPassenger = %v, want [b1 b2 b4]", ids)
+			}
+			if list, err := repo.ListByPassenger(ctx, "Леонов"); err != nil || len(list) != 0 {
+				t.Errorf("ListByPassenger без броней = %v, %v", list, err)
+			}
+		})
+	}
+}
+
+// TestServiceOverSQL проводит бронь через Service поверх SQLRepository.
+func TestServiceOverSQL(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories(t)["sql"](t)
+	s, _, c := newTestService()
+	s.repo = repo
+
+	b, err := s.Reserve(ctx, "Гагарин", testBooking("", "", testNow).Ticket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Advance(time.Minute)
+	confirmed, err := s.Confirm(ctx, b.ID)
+	if err != nil || confirmed.Status != Confirmed {
+		t.Fatalf("Confirm = %+v, %v", confirmed, err)
+	}
+	if _, err := s.Cancel(ctx, b.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Confirm(ctx, b.ID); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Confirm отмененной: err = %v, want ErrInvalidTransition", err)
+	}
+}
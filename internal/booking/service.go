@@ -0,0 +1,128 @@
+package booking
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"example/internal/clock"
+	"example/internal/tickets"
+)
+
+// maxIDAttempts - сколько раз Reserve пробует новый ID, если сгенерированный уже занят.
+const maxIDAttempts = 5
+
+// Service - бизнес-логика броней: резервирование, подтверждение и отмена.
+type Service struct {
+	repo  Repository
+	clock clock.Clock
+	newID func() (string, error)
+}
+
+// NewService создает сервис поверх хранилища repo. Время создания и изменения броней берется из c.
+func NewService(repo Repository, c clock.Clock) *Service {
+	return &Service{repo: repo, clock: c, newID: newRandomID}
+}
+
+// newRandomID возвращает случайный ID из 16 байт в шестнадцатеричной записи.
+func newRandomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Reserve резервирует билет ticket для пассажира passenger.
+// Если сгенерированный ID уже занят, пробует другой.
+func (s *Service) Reserve(ctx context.Context, passenger string, ticket tickets.Ticket) (Booking, error) {
+	if passenger == "" {
+		return Booking{}, errors.New("не указан пассажир")
+	}
+
+	now := s.clock.Now()
+	for range maxIDAttempts {
+		id, err := s.newID()
+		if err != nil {
+			return Booking{}, err
+		}
+
+		b := Booking{
+			ID:        id,
+			Passenger: passenger,
+			Status:    Reserved,
+			Ticket:    ticket,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		err = s.repo.Create(ctx, b)
+		if errors.Is(err, ErrDuplicateID) {
+			continue
+		}
+		if err != nil {
+			return Booking{}, err
+		}
+		return b, nil
+	}
+	return Booking{}, fmt.Errorf("%w: %d попыток", ErrDuplicateID, maxIDAttempts)
+}
+
+// Confirm подтверждает бронь. Повторное подтверждение ничего не меняет и возвращает ту же бронь.
+// Отмененную бронь подтвердить нельзя: возвращается ErrInvalidTransition.
+func (s *Service) Confirm(ctx context.Context, id string) (Booking, error) {
+	return s.transition(ctx, id, Confirmed)
+}
+
+// Cancel отменяет бронь. Повторная отмена ничего не меняет и возвращает ту же бронь.
+func (s *Service) Cancel(ctx context.Context, id string) (Booking, error) {
+	return s.transition(ctx, id, Cancelled)
+}
+
+// ListByPassenger возвращает брони пассажира в порядке создания.
+func (s *Service) ListByPassenger(ctx context.Context, passenger string) ([]Booking, error) {
+	return s.repo.ListByPassenger(ctx, passenger)
+}
+
+// transition переводит бронь в статус to. Если статус изменился параллельно,
+// решение принимается заново по свежему состоянию брони.
+func (s *Service) transition(ctx context.Context, id string, to Status) (Booking, error) {
+	for {
+		b, err := s.repo.Get(ctx, id)
+		if err != nil {
+			return Booking{}, err
+		}
+		if b.Status == to {
+			return b, nil
+		}
+		if !canTransition(b.Status, to) {
+			return Booking{}, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, b.Status, to)
+		}
+
+		now := s.clock.Now()
+		err = s.repo.SetStatus(ctx, id, b.Status, to, now)
+		if errors.Is(err, ErrStatusChanged) {
+			continue
+		}
+		if err != nil {
+			return Booking{}, err
+		}
+
+		b.Status = to
+		b.UpdatedAt = now
+		return b, nil
+	}
+}
+
+// canTransition проверяет, можно ли перевести бронь из статуса from в to.
+// Из Reserved можно подтвердить или отменить, подтвержденную - только отменить.
+func canTransition(from, to Status) bool {
+	switch from {
+	case Reserved:
+		return to == Confirmed || to == Cancelled
+	case Confirmed:
+		return to == Cancelled
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"example/internal/clock"
+	"example/internal/tickets"
+)
+
+var testNow = time.Date(2020, time.October, 13, 9, 30, 0, 0, time.UTC)
+
+// newTestService создает сервис поверх MemoryRepository с поддельными часами
+// и последовательными ID: b1, b2, ...
+func newTestService() (*Service, *MemoryRepository, *clock.Fake) {
+	repo := NewMemoryRepository()
+	c := clock.NewFake(testNow)
+	s := NewService(repo, c)
+	n := 0
+	s.newID = func() (string, error) {
+		n++
+		return fmt.Sprintf("b%d", n), nil
+	}
+	return s, repo, c
+}
+
+func testTicket() tickets.Ticket {
+	return tickets.Ticket{
+		Quote:     tickets.Quote{Spaceline: "SpaceX", Speed: 20, Trip: tickets.RoundTrip, Days: 35},
+		Departure: testNow,
+		Distance:  62_100_000,
+	}
+}
+
+func TestReserve(t *testing.T) {
+	s, repo, _ := newTestService()
+	ctx := context.Background()
+
+	b, err := s.Reserve(ctx, "Ada", testTicket())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ID != "b1" || b.Passenger != "Ada" || b.Status != Reserved {
+		t.Errorf("booking = %+v", b)
+	}
+	if !b.CreatedAt.Equal(testNow) || !b.UpdatedAt.Equal(testNow) {
+		t.Errorf("times = %v, %v, want %v", b.CreatedAt, b.UpdatedAt, testNow)
+	}
+	got, err := repo.Get(ctx, b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != b {
+		t.Errorf("stored %+v, want %+v", got, b)
+	}
+
+	if _, err := s.Reserve(ctx, "", testTicket()); err == nil {
+		t.Error("Reserve without passenger: want error")
+	}
+}
+
+func TestReserveDuplicateID(t *testing.T) {
+	s, repo, _ := newTestService()
+	ctx := context.Background()
+	if err := repo.Create(ctx, Booking{ID: "b1", Passenger: "Grace", Status: Reserved}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Create(ctx, Booking{ID: "b1", Passenger: "Ada"}); !errors.Is(err, ErrDuplicateID) {
+		t.Fatalf("Create duplicate: err = %v, want ErrDuplicateID", err)
+	}
+
+	// занятый b1 пропускается, бронь получает следующий ID
+	b, err := s.Reserve(ctx, "Ada", testTicket())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ID != "b2" {
+		t.Errorf("ID = %q, want b2", b.ID)
+	}
+
+	// если все попытки заняты, Reserve сдается
+	s.newID = func() (string, error) { return "b1", nil }
+	if _, err := s.Reserve(ctx, "Ada", testTicket()); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("err = %v, want ErrDuplicateID", err)
+	}
+}
+
+func TestConfirmIsIdempotent(t *testing.T) {
+	s, _, c := newTestService()
+	ctx := context.Background()
+	b, err := s.Reserve(ctx, "Ada", testTicket())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Advance(time.Hour)
+	first, err := s.Confirm(ctx, b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Status != Confirmed || !first.UpdatedAt.Equal(testNow.Add(time.Hour)) {
+		t.Errorf("first confirm = %+v", first)
+	}
+
+	c.Advance(time.Hour)
+	second, err := s.Confirm(ctx, b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second != first {
+		t.Errorf("second confirm = %+v, want unchanged %+v", second, first)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	tests := []struct {
+		name    string
+		confirm bool
+	}{
+		{"reserved", false},
+		{"confirmed", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo, _ := newTestService()
+			ctx := context.Background()
+			b, err := s.Reserve(ctx, "Ada", testTicket())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.confirm {
+				if _, err := s.Confirm(ctx, b.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cancelled, err := s.Cancel(ctx, b.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cancelled.Status != Cancelled {
+				t.Errorf("status = %s, want cancelled", cancelled.Status)
+			}
+			if again, err := s.Cancel(ctx, b.ID); err != nil || again != cancelled {
+				t.Errorf("second cancel = %+v, %v", again, err)
+			}
+			if _, err := s.Confirm(ctx, b.ID); !errors.Is(err, ErrInvalidTransition) {
+				t.Errorf("confirm cancelled: err = %v, want ErrInvalidTransition", err)
+			}
+			stored, err := repo.Get(ctx, b.ID)
+			if err != nil || stored.Status != Cancelled {
+				t.Errorf("stored = %+v, %v", stored, err)
+			}
+		})
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	s, _, _ := newTestService()
+	ctx := context.Background()
+	if _, err := s.Confirm(ctx, "nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Confirm: err = %v, want ErrNotFound", err)
+	}
+	if _, err := s.Cancel(ctx, "nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Cancel: err = %v, want ErrNotFound", err)
+	}
+}
+
+// racingRepository меняет статус брони между Get и SetStatus сервиса,
+// как параллельный запрос.
+type racingRepository struct {
+	*MemoryRepository
+	race func()
+}
+
+func (r *racingRepository) SetStatus(ctx context.Context, id string, from, to Status, at time.Time) error {
+	if r.race != nil {
+		race := r.race
+		r.race = nil
+		race()
+	}
+	return r.MemoryRepository.SetStatus(ctx, id, from, to, at)
+}
+
+func TestTransitionRetriesOnStatusChange(t *testing.T) {
+	s, mem, _ := newTestService()
+	repo := &racingRepository{MemoryRepository: mem}
+	s.repo = repo
+	ctx := context.Background()
+	b, err := s.Reserve(ctx, "Ada", testTicket())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// пока подтверждение читает бронь, ее отменяют: подтверждение должно увидеть отмену
+	repo.race = func() {
+		if err := mem.SetStatus(ctx, b.ID, Reserved, Cancelled, testNow); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := s.Confirm(ctx, b.ID); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("err = %v, want ErrInvalidTransition", err)
+	}
+}
+
+func TestListByPassenger(t *testing.T) {
+	s, _, _ := newTestService()
+	ctx := context.Background()
+	for _, passenger := range []string{"Ada", "Grace", "Ada"} {
+		if _, err := s.Reserve(ctx, passenger, testTicket()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := s.ListByPassenger(ctx, "Ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].ID != "b1" || list[1].ID != "b3" {
+		t.Errorf("list = %+v, want b1, b3", list)
+	}
+	if list, _ := s.ListByPassenger(ctx, "Linus"); len(list) != 0 {
+		t.Errorf("unknown passenger: %+v", list)
+	}
+}
@@ -0,0 +1,315 @@
+package booking
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"example/internal/money"
+	"example/internal/tickets"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// timeLayout - формат хранения времени в TEXT-колонках: строки в нем сортируются как время.
+const timeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// SQLRepository - хранилище броней поверх database/sql.
+// Запросы используют плейсхолдеры ? и синтаксис SQLite; драйвер подключает вызывающий код,
+// например sql.Open("sqlite", "bookings.db").
+type SQLRepository struct {
+	db *sql.DB
+}
+
+// NewSQLRepository создает хранилище поверх db. Схему нужно создать заранее через Migrate.
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// Migrate применяет к db еще не примененные миграции из migrations/ в порядке имен файлов.
+// Примененные версии хранятся в таблице schema_migrations, поэтому повторный вызов безопасен.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("создание schema_migrations: %w", err)
+	}
+
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := applyMigration(ctx, db, name); err != nil {
+			return fmt.Errorf("миграция %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// applyMigration выполняет одну миграцию в транзакции, если она еще не применена.
+func applyMigration(ctx context.Context, db *sql.DB, name string) error {
+	version := strings.TrimSuffix(strings.TrimPrefix(name, "migrations/"), ".sql")
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var count int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations WHERE version = ?", version).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	script, err := migrations.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	// не все драйверы выполняют несколько выражений за один Exec, поэтому скрипт делится на выражения
+	for _, stmt := range splitStatements(string(script)) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)",
+		version, time.Now().UTC().Format(timeLayout))
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// splitStatements делит SQL-скрипт на выражения по ;. Точка с запятой внутри строк,
+// идентификаторов в кавычках, комментариев и тела CREATE TRIGGER ... BEGIN ... END
+// выражение не завершает. Пустые выражения и выражения из одних комментариев пропускаются.
+func splitStatements(script string) []string {
+	var (
+		stmts     []string
+		start     int
+		hasCode   bool // в текущем выражении есть что-то кроме пробелов и комментариев
+		isTrigger bool // текущее выражение - CREATE TRIGGER
+		depth     int  // вложенность BEGIN ... END в теле триггера
+		words     []string
+	)
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '-' && strings.HasPrefix(script[i:], "--"):
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				end = len(script) - i
+			}
+			i += end
+			continue
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += 2 + end + 1
+			}
+			continue
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			// удвоенная кавычка внутри строки - экранированная кавычка, поиск просто продолжится за ней
+			end := strings.IndexByte(script[i+1:], closing)
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += 1 + end
+			}
+			hasCode = true
+			continue
+		case c == ';':
+			if depth > 0 {
+				continue
+			}
+			if hasCode {
+				stmts = append(stmts, strings.TrimSpace(script[start:i]))
+			}
+			start, hasCode, isTrigger, words = i+1, false, false, nil
+			continue
+		case isWordByte(c):
+			j := i
+			for j < len(script) && isWordByte(script[j]) {
+				j++
+			}
+			word := strings.ToUpper(script[i:j])
+			if len(words) < 4 {
+				words = append(words, word)
+				// CREATE [TEMP] TRIGGER: тело триггера содержит свои ;
+				isTrigger = isTrigger || (words[0] == "CREATE" && word == "TRIGGER")
+			}
+			if isTrigger {
+				switch word {
+				case "BEGIN", "CASE":
+					depth++
+				case "END":
+					depth--
+				}
+			}
+			hasCode = true
+			i = j - 1
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			hasCode = true
+		}
+	}
+	if hasCode {
+		stmts = append(stmts, strings.TrimSpace(script[start:]))
+	}
+	return stmts
+}
+
+// isWordByte проверяет, может ли байт входить в ключевое слово или имя без кавычек.
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// Create сохраняет новую бронь. Занятый ID определяется через ON CONFLICT DO NOTHING,
+// чтобы не зависеть от текста ошибки конкретного драйвера.
+func (r *SQLRepository) Create(ctx context.Context, b Booking) error {
+	t := b.Ticket
+	res, err := r.db.ExecContext(ctx, `INSERT INTO bookings
+		(id, passenger, status, spaceline, speed, trip, days, price_minor, currency, departure, distance, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		b.ID, b.Passenger, string(b.Status), t.Spaceline, t.Speed, t.Trip.String(), t.Days,
+		t.Price.Amount(), t.Price.Currency().Code,
+		t.Departure.UTC().Format(timeLayout), t.Distance,
+		b.CreatedAt.UTC().Format(timeLayout), b.UpdatedAt.UTC().Format(timeLayout))
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrDuplicateID
+	}
+	return nil
+}
+
+// selectBooking - общая часть запросов чтения броней, порядок колонок соответствует scanBooking.
+const selectBooking = `SELECT id, passenger, status, spaceline, speed, trip, days, price_minor, currency,
+	departure, distance, created_at, updated_at FROM bookings`
+
+// Get возвращает бронь по ID.
+func (r *SQLRepository) Get(ctx context.Context, id string) (Booking, error) {
+	row := r.db.QueryRowContext(ctx, selectBooking+" WHERE id = ?", id)
+	b, err := scanBooking(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Booking{}, ErrNotFound
+	}
+	return b, err
+}
+
+// SetStatus меняет статус брони с from на to одним условным UPDATE,
+// поэтому параллельные подтверждение и отмена не перезапишут друг друга.
+func (r *SQLRepository) SetStatus(ctx context.Context, id string, from, to Status, at time.Time) error {
+	res, err := r.db.ExecContext(ctx,
+		"UPDATE bookings SET status = ?, updated_at = ? WHERE id = ? AND status = ?",
+		string(to), at.UTC().Format(timeLayout), id, string(from))
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+
+	// ни одна строка не обновилась: либо брони нет, либо статус уже другой
+	if _, err := r.Get(ctx, id); err != nil {
+		return err
+	}
+	return ErrStatusChanged
+}
+
+// ListByPassenger возвращает брони пассажира в порядке создания.
+func (r *SQLRepository) ListByPassenger(ctx context.Context, passenger string) ([]Booking, error) {
+	rows, err := r.db.QueryContext(ctx, selectBooking+" WHERE passenger = ? ORDER BY created_at, id", passenger)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []Booking
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, b)
+	}
+	return list, rows.Err()
+}
+
+// scanner - общий интерфейс *sql.Row и *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanBooking читает бронь из строки результата selectBooking.
+func scanBooking(s scanner) (Booking, error) {
+	var (
+		b                               Booking
+		status, trip, currency          string
+		priceMinor                      int64
+		departure, createdAt, updatedAt string
+	)
+	err := s.Scan(&b.ID, &b.Passenger, &status, &b.Ticket.Spaceline, &b.Ticket.Speed, &trip,
+		&b.Ticket.Days, &priceMinor, &currency, &departure, &b.Ticket.Distance, &createdAt, &updatedAt)
+	if err != nil {
+		return Booking{}, err
+	}
+
+	c, err := money.GetCurrency(currency)
+	if err != nil {
+		return Booking{}, err
+	}
+	b.Ticket.Price = money.New(priceMinor, c)
+
+	if b.Status, err = ParseStatus(status); err != nil {
+		return Booking{}, err
+	}
+	if b.Ticket.Trip, err = tickets.ParseTrip(trip); err != nil {
+		return Booking{}, err
+	}
+	if b.Ticket.Departure, err = time.Parse(timeLayout, departure); err != nil {
+		return Booking{}, err
+	}
+	if b.CreatedAt, err = time.Parse(timeLayout, createdAt); err != nil {
+		return Booking{}, err
+	}
+	if b.UpdatedAt, err = time.Parse(timeLayout, updatedAt); err != nil {
+		return Booking{}, err
+	}
+	return b, nil
+}
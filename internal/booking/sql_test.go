@@ -0,0 +1,108 @@
+package booking
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{"два выражения", "CREATE TABLE a (x);\n\nCREATE INDEX i ON a (x);\n", []string{"CREATE TABLE a (x)", "CREATE INDEX i ON a (x)"}},
+		{"без последней ;", "SELECT 1; SELECT 2", []string{"SELECT 1", "SELECT 2"}},
+		{"; в строке", "INSERT INTO a VALUES ('x;y', 'it''s; ok');", []string{"INSERT INTO a VALUES ('x;y', 'it''s; ok')"}},
+		{"; в имени", `CREATE TABLE "a;b" ([c;d] TEXT, ` + "`e;f`" + ` TEXT);`, []string{`CREATE TABLE "a;b" ([c;d] TEXT, ` + "`e;f`" + ` TEXT)`}},
+		{"; в комментариях", "-- первое; второе\nSELECT 1; /* ; */ SELECT 2;", []string{"-- первое; второе\nSELECT 1", "/* ; */ SELECT 2"}},
+		{"одни комментарии", "SELECT 1;\n-- конец;\n/* тоже */\n", []string{"SELECT 1"}},
+		{"пустые", " ; ;\n;", nil},
+		{"триггер", `CREATE TRIGGER t AFTER INSERT ON a BEGIN
+	UPDATE a SET x = CASE WHEN x > 0 THEN 1 ELSE 0 END;
+	DELETE FROM b;
+END;
+SELECT 1;`, []string{`CREATE TRIGGER t AFTER INSERT ON a BEGIN
+	UPDATE a SET x = CASE WHEN x > 0 THEN 1 ELSE 0 END;
+	DELETE FROM b;
+END`, "SELECT 1"}},
+		{"BEGIN вне триггера", "BEGIN; SELECT 1; END;", []string{"BEGIN", "SELECT 1", "END"}},
+	}
+	for _, tt := range tests {
+		if got := splitStatements(tt.script); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: splitStatements = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestMigrate проверяет, что миграции применяются один раз и повторный Migrate ничего не меняет.
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	repo := NewSQLRepository(db)
+	if err := repo.Create(ctx, testBooking("b1", "Гагарин", testNow)); err != nil {
+		t.Fatal(err)
+	}
+
+	// вторая миграция пересоздает таблицу: если бы она выполнилась снова, бронь бы пропала или запрос упал
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatalf("повторный Migrate: %v", err)
+	}
+	if _, err := repo.Get(ctx, "b1"); err != nil {
+		t.Errorf("бронь после повторного Migrate: %v", err)
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations ORDER BY version")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var versions []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"0001_create_bookings", "0002_price_in_minor_units"}; !slices.Equal(versions, want) {
+		t.Errorf("schema_migrations = %v, want %v", versions, want)
+	}
+}
+
+// TestMigratePrice проверяет перевод цены из миллионов долларов в REAL в центы второй миграцией.
+func TestMigratePrice(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	if _, err := db.ExecContext(ctx, `CREATE TABLE schema_migrations (version TEXT PRIMARY KEY, applied_at TEXT NOT NULL)`); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyMigration(ctx, db, "migrations/0001_create_bookings.sql"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := db.ExecContext(ctx, `INSERT INTO bookings
+		(id, passenger, status, spaceline, speed, trip, days, price, departure, distance, created_at, updated_at)
+		VALUES ('b1', 'Гагарин', 'reserved', 'SpaceX', 20, 'Round-trip', 35, 80.5, ?, 62100000, ?, ?)`,
+		testNow.Format(timeLayout), testNow.Format(timeLayout), testNow.Format(timeLayout))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewSQLRepository(db).Get(ctx, "b1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := b.Ticket.Price.String(); got != "$80500000.00" {
+		t.Errorf("цена после миграции %s, want $80500000.00", got)
+	}
+}
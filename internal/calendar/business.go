@@ -0,0 +1,367 @@
+package calendar
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNoWorkingDays возвращается для календаря, в котором все дни недели выходные.
+	ErrNoWorkingDays = errors.New("в календаре нет рабочих дней")
+	// ErrUncoveredYear возвращается для дня года, переносов которого в календаре нет.
+	ErrUncoveredYear = errors.New("календарь не охватывает год")
+)
+
+// BusinessCalendar - производственный календарь: выходные дни недели, праздники
+// и перенесенные рабочие дни. В f27 рабочий день определяется только днем недели.
+//
+// Праздник бывает ежегодным (1 января каждого года) или датой конкретного года,
+// например выходной, перенесенный на 29 апреля 2024 года. Рабочий день из списка переносов
+// (рабочая суббота) важнее выходного дня недели, а праздник важнее и того, и другого.
+//
+// Переносы выходных каждый год назначаются заново, поэтому календарь может ограничить
+// годы, для которых они известны: для других лет IsWorkingDay возвращает ErrUncoveredYear.
+type BusinessCalendar struct {
+	Name     string
+	weekends [7]bool
+	years    map[int]bool // годы с известными переносами, nil - любой год
+	yearly   map[monthDay]string
+	holidays map[Date]string
+	workdays map[Date]string
+}
+
+// monthDay - день ежегодного праздника.
+type monthDay struct {
+	month time.Month
+	day   int
+}
+
+// NewBusinessCalendar создает календарь с выходными днями недели weekends и без праздников.
+func NewBusinessCalendar(name string, weekends ...time.Weekday) (*BusinessCalendar, error) {
+	c := &BusinessCalendar{
+		Name:     name,
+		yearly:   map[monthDay]string{},
+		holidays: map[Date]string{},
+		workdays: map[Date]string{},
+	}
+	for _, w := range weekends {
+		if w < time.Sunday || w > time.Saturday {
+			return nil, fmt.Errorf("%w: день недели %d", ErrInvalidDate, w)
+		}
+		c.weekends[w] = true
+	}
+	if len(c.GetWeekends()) == len(c.weekends) {
+		return nil, ErrNoWorkingDays
+	}
+	return c, nil
+}
+
+// WithWeekends возвращает копию календаря с другими выходными днями недели и теми же праздниками.
+func (c *BusinessCalendar) WithWeekends(weekends ...time.Weekday) (*BusinessCalendar, error) {
+	copied, err := NewBusinessCalendar(c.Name, weekends...)
+	if err != nil {
+		return nil, err
+	}
+	copied.years = maps.Clone(c.years)
+	copied.yearly = maps.Clone(c.yearly)
+	copied.holidays = maps.Clone(c.holidays)
+	copied.workdays = maps.Clone(c.workdays)
+	return copied, nil
+}
+
+// GetWeekends возвращает выходные дни недели по порядку с воскресенья.
+func (c *BusinessCalendar) GetWeekends() []time.Weekday {
+	var days []time.Weekday
+	for w, isWeekend := range c.weekends {
+		if isWeekend {
+			days = append(days, time.Weekday(w))
+		}
+	}
+	return days
+}
+
+// SetYears ограничивает календарь годами years: праздники и переносы известны только для них.
+// Без годов, как и без вызова SetYears, календарь подходит для любого года.
+func (c *BusinessCalendar) SetYears(years ...int) error {
+	if len(years) == 0 {
+		c.years = nil
+		return nil
+	}
+	covered := map[int]bool{}
+	for _, year := range years {
+		if year < 1 {
+			return fmt.Errorf("%w: год %d", ErrInvalidDate, year)
+		}
+		covered[year] = true
+	}
+	c.years = covered
+	return nil
+}
+
+// GetYears возвращает по возрастанию годы, которые охватывает календарь, или nil для любого года.
+func (c *BusinessCalendar) GetYears() []int {
+	if c.years == nil {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(c.years))
+}
+
+// CheckYear возвращает ErrUncoveredYear, если переносы года даты d календарю неизвестны.
+func (c *BusinessCalendar) CheckYear(d Date) error {
+	if c.years != nil && !c.years[d.Year] {
+		return fmt.Errorf("%w: %d, известны %v", ErrUncoveredYear, d.Year, c.GetYears())
+	}
+	return nil
+}
+
+// AddYearlyHoliday добавляет праздник, который повторяется каждый год в день day месяца month.
+func (c *BusinessCalendar) AddYearlyHoliday(month time.Month, day int, name string) error {
+	// проверка по високосному году, чтобы 29 февраля тоже можно было задать
+	if err := (Date{Year: 2000, Month: month, Day: day}).Validate(); err != nil {
+		return err
+	}
+	c.yearly[monthDay{month, day}] = name
+	return nil
+}
+
+// AddHoliday добавляет нерабочий день d, например перенесенный выходной.
+func (c *BusinessCalendar) AddHoliday(d Date, name string) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	if err := c.CheckYear(d); err != nil {
+		return err
+	}
+	c.holidays[d] = name
+	return nil
+}
+
+// AddWorkday добавляет рабочий день d, который приходится на выходной день недели.
+func (c *BusinessCalendar) AddWorkday(d Date, name string) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	if err := c.CheckYear(d); err != nil {
+		return err
+	}
+	c.workdays[d] = name
+	return nil
+}
+
+// IsWeekend проверяет, что d приходится на выходной день недели.
+func (c *BusinessCalendar) IsWeekend(d Date) bool {
+	return c.weekends[d.Weekday()]
+}
+
+// GetHoliday возвращает название праздника в день d.
+func (c *BusinessCalendar) GetHoliday(d Date) (string, bool) {
+	if name, ok := c.holidays[d]; ok {
+		return name, true
+	}
+	name, ok := c.yearly[monthDay{d.Month, d.Day}]
+	return name, ok
+}
+
+// GetWorkday возвращает пояснение к рабочему дню d, перенесенному на выходной.
+func (c *BusinessCalendar) GetWorkday(d Date) (string, bool) {
+	name, ok := c.workdays[d]
+	return name, ok
+}
+
+// IsWorkingDay проверяет, рабочий ли день d. Для года, который календарь не охватывает,
+// возвращает ErrUncoveredYear: без переносов ответ был бы неверным.
+func (c *BusinessCalendar) IsWorkingDay(d Date) (bool, error) {
+	if err := c.CheckYear(d); err != nil {
+		return false, err
+	}
+	if _, ok := c.GetHoliday(d); ok {
+		return false, nil
+	}
+	if _, ok := c.workdays[d]; ok {
+		return true, nil
+	}
+	return !c.IsWeekend(d), nil
+}
+
+// AddWorkingDays возвращает n-й рабочий день после d (при n < 0 - до d). При n = 0 возвращает d.
+// Если праздники занимают все дни недели, кроме выходных, поиск не закончится,
+// поэтому он ограничен годом без единого рабочего дня.
+func (c *BusinessCalendar) AddWorkingDays(d Date, n int) (Date, error) {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	idle := 0 // нерабочих дней подряд
+	for n > 0 {
+		d = d.AddDays(step)
+		isWorking, err := c.IsWorkingDay(d)
+		if err != nil {
+			return Date{}, err
+		}
+		if !isWorking {
+			if idle++; idle > 366 {
+				return Date{}, fmt.Errorf("%w: больше года без рабочих дней после %v", ErrNoWorkingDays, d)
+			}
+			continue
+		}
+		idle = 0
+		n--
+	}
+	return d, nil
+}
+
+// WorkingDaysBetween возвращает число рабочих дней в промежутке [from, to):
+// from учитывается, а to - нет, поэтому дни соседних промежутков складываются.
+// Если to раньше from, результат отрицательный.
+func (c *BusinessCalendar) WorkingDaysBetween(from, to Date) (int, error) {
+	sign := 1
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+	count := 0
+	for d := from; d.Before(to); d = d.AddDays(1) {
+		isWorking, err := c.IsWorkingDay(d)
+		if err != nil {
+			return 0, err
+		}
+		if isWorking {
+			count++
+		}
+	}
+	return sign * count, nil
+}
+
+// Файл календаря - JSON:
+//
+//	{
+//	  "name": "Россия",
+//	  "years": [2024],
+//	  "weekends": ["saturday", "sunday"],
+//	  "holidays": [
+//	    {"date": "01-01", "name": "Новогодние каникулы"},
+//	    {"date": "2024-04-29", "name": "перенос с 27 апреля"}
+//	  ],
+//	  "workdays": [{"date": "2024-04-27", "name": "рабочая суббота"}]
+//	}
+//
+// Дата праздника ММ-ДД - ежегодный праздник, ГГГГ-ММ-ДД - день конкретного года.
+// years - годы, для которых в файле перечислены все переносы, без него календарь подходит
+// для любого года. Дни конкретных лет должны относиться к годам из years.
+// Дни недели записываются по-английски или по-русски, полностью или первыми тремя буквами.
+
+// calendarFile - файл календаря.
+type calendarFile struct {
+	Name     string      `json:"name"`
+	Years    []int       `json:"years"`
+	Weekends []string    `json:"weekends"`
+	Holidays []dayRecord `json:"holidays"`
+	Workdays []dayRecord `json:"workdays"`
+}
+
+// dayRecord - праздник или рабочий день в файле календаря.
+type dayRecord struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+// russianJSON - производственный календарь России.
+//
+//go:embed holidays/ru.json
+var russianJSON []byte
+
+// NewRussianCalendar возвращает производственный календарь России: праздники из статьи 112
+// Трудового кодекса и переносы выходных по постановлениям правительства на 2024-2026 годы.
+// Для других лет IsWorkingDay возвращает ErrUncoveredYear, их переносы можно описать
+// в своем файле по образцу holidays/ru.json.
+// Файл встроен в программу и проверен, поэтому ошибка разбора вызывает панику.
+func NewRussianCalendar() *BusinessCalendar {
+	c, err := LoadBusinessCalendar(bytes.NewReader(russianJSON))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// LoadBusinessCalendar читает календарь из r в формате JSON.
+func LoadBusinessCalendar(r io.Reader) (*BusinessCalendar, error) {
+	var file calendarFile
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("чтение календаря: %w", err)
+	}
+
+	weekends := make([]time.Weekday, 0, len(file.Weekends))
+	for _, name := range file.Weekends {
+		w, err := ParseWeekday(name)
+		if err != nil {
+			return nil, fmt.Errorf("weekends: %w", err)
+		}
+		weekends = append(weekends, w)
+	}
+	c, err := NewBusinessCalendar(file.Name, weekends...)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.SetYears(file.Years...); err != nil {
+		return nil, fmt.Errorf("years: %w", err)
+	}
+
+	for i, h := range file.Holidays {
+		if err := c.addRecord(h, true); err != nil {
+			return nil, fmt.Errorf("holidays[%d]: %w", i, err)
+		}
+	}
+	for i, w := range file.Workdays {
+		if err := c.addRecord(w, false); err != nil {
+			return nil, fmt.Errorf("workdays[%d]: %w", i, err)
+		}
+	}
+	return c, nil
+}
+
+// LoadBusinessCalendarFile читает календарь из файла path.
+func LoadBusinessCalendarFile(path string) (*BusinessCalendar, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadBusinessCalendar(file)
+}
+
+// addRecord добавляет праздник (isHoliday) или рабочий день из файла.
+// Ежегодными бывают только праздники.
+func (c *BusinessCalendar) addRecord(r dayRecord, isHoliday bool) error {
+	if strings.Count(r.Date, "-") == 1 {
+		if !isHoliday {
+			return fmt.Errorf("%w: %q, у рабочего дня должен быть год", ErrInvalidDate, r.Date)
+		}
+		month, day, _ := strings.Cut(r.Date, "-")
+		m, errMonth := strconv.Atoi(month)
+		d, errDay := strconv.Atoi(day)
+		if errMonth != nil || errDay != nil {
+			return fmt.Errorf("%w: %q, ожидалось ММ-ДД", ErrInvalidDate, r.Date)
+		}
+		return c.AddYearlyHoliday(time.Month(m), d, r.Name)
+	}
+
+	d, err := ParseDate(r.Date)
+	if err != nil {
+		return err
+	}
+	if isHoliday {
+		return c.AddHoliday(d, r.Name)
+	}
+	return c.AddWorkday(d, r.Name)
+}
@@ -0,0 +1,209 @@
+package calendar
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// day разбирает дату ГГГГ-ММ-ДД для таблиц тестов.
+func day(t *testing.T, s string) Date {
+	t.Helper()
+	d, err := ParseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestRussianIsWorkingDay(t *testing.T) {
+	c := NewRussianCalendar()
+	tests := []struct {
+		date      string
+		isWorking bool
+	}{
+		{"2024-01-08", false}, // ежегодные каникулы
+		{"2024-01-09", true},
+		{"2024-04-27", true},  // рабочая суббота
+		{"2024-04-29", false}, // перенос с субботы 27 апреля
+		{"2024-04-30", false},
+		{"2024-05-10", false},
+		{"2024-11-02", true},
+		{"2024-12-28", true},
+		{"2024-12-30", false},
+		{"2024-12-31", false},
+		{"2025-05-02", false}, // перенос с субботы 4 января
+		{"2025-05-08", false},
+		{"2025-06-13", false},
+		{"2025-11-01", true},
+		{"2025-11-03", false},
+		{"2025-12-31", false},
+		{"2026-01-09", false}, // перенос с субботы 3 января
+		{"2026-03-09", false}, // 8 марта - воскресенье
+		{"2026-05-11", false}, // 9 мая - суббота
+		{"2026-10-19", true},
+		{"2026-10-18", false}, // воскресенье
+	}
+	for _, tt := range tests {
+		got, err := c.IsWorkingDay(day(t, tt.date))
+		if err != nil || got != tt.isWorking {
+			t.Errorf("IsWorkingDay(%s) = %t, %v, want %t", tt.date, got, err, tt.isWorking)
+		}
+	}
+}
+
+// TestRussianYears сверяет число рабочих дней каждого года с производственным календарем
+// и проверяет, что за пределами известных лет календарь возвращает ошибку.
+func TestRussianYears(t *testing.T) {
+	c := NewRussianCalendar()
+	for _, tt := range []struct{ year, days int }{{2024, 248}, {2025, 247}, {2026, 247}} {
+		from := Date{Year: tt.year, Month: time.January, Day: 1}
+		to := Date{Year: tt.year, Month: time.December, Day: 31}
+		days, err := c.WorkingDaysBetween(from, to.AddDays(1))
+		if err != nil || days != tt.days {
+			t.Errorf("рабочих дней в %d году: %d, %v, want %d", tt.year, days, err, tt.days)
+		}
+	}
+	for _, date := range []string{"2023-12-29", "2027-01-11"} {
+		if _, err := c.IsWorkingDay(day(t, date)); !errors.Is(err, ErrUncoveredYear) {
+			t.Errorf("IsWorkingDay(%s): err = %v, want ErrUncoveredYear", date, err)
+		}
+	}
+	if _, err := c.AddWorkingDays(day(t, "2026-12-30"), 3); !errors.Is(err, ErrUncoveredYear) {
+		t.Errorf("AddWorkingDays через границу 2027 года: err = %v, want ErrUncoveredYear", err)
+	}
+	if _, err := c.WorkingDaysBetween(day(t, "2026-12-01"), day(t, "2027-01-02")); !errors.Is(err, ErrUncoveredYear) {
+		t.Errorf("WorkingDaysBetween до 2027 года: err = %v, want ErrUncoveredYear", err)
+	}
+}
+
+func TestAddWorkingDays(t *testing.T) {
+	c := NewRussianCalendar()
+	tests := []struct {
+		from string
+		n    int
+		want string
+	}{
+		{"2024-04-26", 1, "2024-04-27"}, // рабочая суббота
+		{"2024-04-26", 2, "2024-05-02"}, // через 29 апреля - 1 мая
+		{"2024-05-02", -1, "2024-04-27"},
+		{"2024-05-08", 1, "2024-05-13"},
+		{"2026-03-06", 1, "2026-03-10"},
+		{"2026-03-06", 0, "2026-03-06"},
+		{"2025-12-30", 1, "2026-01-12"},
+	}
+	for _, tt := range tests {
+		got, err := c.AddWorkingDays(day(t, tt.from), tt.n)
+		if err != nil || got != day(t, tt.want) {
+			t.Errorf("AddWorkingDays(%s, %d) = %v, %v, want %s", tt.from, tt.n, got, err, tt.want)
+		}
+	}
+
+	// праздник каждый будний день: поиск останавливается через год
+	busy, err := NewBusinessCalendar("занятой", time.Saturday, time.Sunday)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for d := (Date{Year: 2001, Month: time.January, Day: 1}); d.Year == 2001; d = d.AddDays(1) {
+		busy.AddYearlyHoliday(d.Month, d.Day, "праздник")
+	}
+	busy.AddYearlyHoliday(time.February, 29, "праздник")
+	if _, err := busy.AddWorkingDays(day(t, "2024-01-01"), 1); !errors.Is(err, ErrNoWorkingDays) {
+		t.Errorf("AddWorkingDays без рабочих дней: err = %v, want ErrNoWorkingDays", err)
+	}
+}
+
+func TestWorkingDaysBetween(t *testing.T) {
+	c := NewRussianCalendar()
+	tests := []struct {
+		from, to string
+		want     int
+	}{
+		{"2024-04-22", "2024-04-29", 6}, // пять будних дней и рабочая суббота
+		{"2024-04-29", "2024-04-22", -6},
+		{"2024-04-29", "2024-05-02", 0},
+		{"2024-04-29", "2024-04-29", 0},
+		{"2026-03-02", "2026-03-16", 9},
+	}
+	for _, tt := range tests {
+		got, err := c.WorkingDaysBetween(day(t, tt.from), day(t, tt.to))
+		if err != nil || got != tt.want {
+			t.Errorf("WorkingDaysBetween(%s, %s) = %d, %v, want %d", tt.from, tt.to, got, err, tt.want)
+		}
+	}
+}
+
+func TestWithWeekends(t *testing.T) {
+	c, err := NewRussianCalendar().WithWeekends(time.Friday, time.Saturday)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sunday, friday := day(t, "2024-10-20"), day(t, "2024-10-18")
+	if ok, err := c.IsWorkingDay(sunday); !ok || err != nil {
+		t.Errorf("воскресенье при выходных пт и сб: %t, %v, want рабочий", ok, err)
+	}
+	if ok, err := c.IsWorkingDay(friday); ok || err != nil {
+		t.Errorf("пятница при выходных пт и сб: %t, %v, want выходной", ok, err)
+	}
+	if ok, _ := c.IsWorkingDay(day(t, "2024-01-01")); ok {
+		t.Error("праздники не скопированы")
+	}
+	if _, err := c.IsWorkingDay(day(t, "2027-01-01")); !errors.Is(err, ErrUncoveredYear) {
+		t.Errorf("годы не скопированы: err = %v", err)
+	}
+	if _, err := c.WithWeekends(0, 1, 2, 3, 4, 5, 6); !errors.Is(err, ErrNoWorkingDays) {
+		t.Errorf("все дни выходные: err = %v, want ErrNoWorkingDays", err)
+	}
+}
+
+func TestLoadBusinessCalendar(t *testing.T) {
+	tests := []struct {
+		name, json string
+		wantErr    error // nil - любая ошибка разбора JSON
+		wantText   string
+	}{
+		{"неизвестное поле", `{"name": "x", "weekend": ["sun"]}`, nil, "weekend"},
+		{"не JSON", `{"name": `, nil, "чтение календаря"},
+		{"день недели", `{"weekends": ["субб"]}`, ErrInvalidDate, "weekends"},
+		{"сокращение из двух букв", `{"weekends": ["пт"]}`, ErrInvalidDate, "weekends"},
+		{"все выходные", `{"weekends": ["mon", "tue", "wed", "thu", "fri", "sat", "sun"]}`, ErrNoWorkingDays, ""},
+		{"ежегодный рабочий день", `{"workdays": [{"date": "04-27"}]}`, ErrInvalidDate, "workdays[0]"},
+		{"неверный праздник", `{"holidays": [{"date": "02-30"}]}`, ErrInvalidDate, "holidays[0]"},
+		{"не число", `{"holidays": [{"date": "01-xx"}]}`, ErrInvalidDate, "ММ-ДД"},
+		{"дата", `{"holidays": [{"date": "2023-02-29"}]}`, ErrInvalidDate, "holidays[0]"},
+		{"год вне years", `{"years": [2024], "holidays": [{"date": "2025-01-09"}]}`, ErrUncoveredYear, "holidays[0]"},
+		{"неверный год", `{"years": [0]}`, ErrInvalidDate, "years"},
+	}
+	for _, tt := range tests {
+		_, err := LoadBusinessCalendar(strings.NewReader(tt.json))
+		if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) || !strings.Contains(err.Error(), tt.wantText) {
+			t.Errorf("%s: err = %v, want %v с %q", tt.name, err, tt.wantErr, tt.wantText)
+		}
+	}
+
+	c, err := LoadBusinessCalendar(strings.NewReader(`{
+		"name": "Пример",
+		"weekends": ["пят", "sat"],
+		"holidays": [{"date": "02-29", "name": "високосный день"}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.GetWeekends(); len(got) != 2 || got[0] != time.Friday || got[1] != time.Saturday {
+		t.Errorf("GetWeekends = %v, want [Friday Saturday]", got)
+	}
+	if c.GetYears() != nil {
+		t.Errorf("GetYears = %v, want nil для любого года", c.GetYears())
+	}
+	if name, ok := c.GetHoliday(day(t, "2028-02-29")); !ok || name != "високосный день" {
+		t.Errorf("GetHoliday(2028-02-29) = %q, %t", name, ok)
+	}
+	if ok, err := c.IsWorkingDay(day(t, "1900-01-01")); !ok || err != nil {
+		t.Errorf("IsWorkingDay(1900-01-01) = %t, %v, want рабочий для календаря без years", ok, err)
+	}
+
+	if _, err := LoadBusinessCalendarFile("нет-такого-файла.json"); err == nil {
+		t.Error("LoadBusinessCalendarFile несуществующего файла без ошибки")
+	}
+}
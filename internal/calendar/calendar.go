@@ -0,0 +1,209 @@
+// Package calendar - правила григорианского календаря из f19, f41 и f42.
+//
+// В примерах правило високосного года и число дней в месяце повторяются в каждой функции.
+// Здесь они собраны в одном месте вместе с проверкой дат, номером дня в году
+// и случайной датой из промежутка. Календарь пролептический: правила 1582 года
+// применяются и к более ранним годам, а годы считаются астрономически (год 0 существует).
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidDate возвращается для несуществующей даты, например 29 февраля 2018 года.
+	ErrInvalidDate = errors.New("неверная дата")
+	// ErrInvalidRange возвращается, если начало промежутка позже конца.
+	ErrInvalidRange = errors.New("начало промежутка позже конца")
+)
+
+// IsLeap проверяет, високосный ли год: делится на 4, но не на 100, или делится на 400.
+func IsLeap(year int) bool {
+	return year%400 == 0 || (year%4 == 0 && year%100 != 0)
+}
+
+// DaysInMonth возвращает число дней в месяце month года year или 0 для несуществующего месяца.
+func DaysInMonth(year int, month time.Month) int {
+	switch month {
+	case time.February:
+		if IsLeap(year) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	case time.January, time.March, time.May, time.July, time.August, time.October, time.December:
+		return 31
+	}
+	return 0
+}
+
+// DaysInYear возвращает 366 для високосного года и 365 для остальных.
+func DaysInYear(year int) int {
+	if IsLeap(year) {
+		return 366
+	}
+	return 365
+}
+
+// Date - дата без времени и часового пояса.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate возвращает дату, проверенную Validate.
+func NewDate(year int, month time.Month, day int) (Date, error) {
+	d := Date{Year: year, Month: month, Day: day}
+	return d, d.Validate()
+}
+
+// FromTime возвращает дату t в ее часовом поясе.
+func FromTime(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// Validate проверяет, что месяц от 1 до 12, а день есть в этом месяце.
+func (d Date) Validate() error {
+	if d.Month < time.January || d.Month > time.December {
+		return fmt.Errorf("%w: месяц %d", ErrInvalidDate, d.Month)
+	}
+	if d.Day < 1 || d.Day > DaysInMonth(d.Year, d.Month) {
+		return fmt.Errorf("%w: %v, в месяце %d дней", ErrInvalidDate, d, DaysInMonth(d.Year, d.Month))
+	}
+	return nil
+}
+
+// IsValid проверяет дату без описания ошибки.
+func (d Date) IsValid() bool { return d.Validate() == nil }
+
+// String возвращает дату в виде 2018-02-28. Годы вне 0-9999 печатаются со знаком или длиннее.
+func (d Date) String() string {
+	if d.Year < 0 {
+		return fmt.Sprintf("-%04d-%02d-%02d", -d.Year, d.Month, d.Day)
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// ParseDate разбирает дату в виде String: 2018-02-28 или -0043-03-15.
+// Дата не проверяется, потому что правила зависят от календаря: вызывающий проверяет ее сам.
+func ParseDate(s string) (Date, error) {
+	text, sign := s, 1
+	if rest, ok := strings.CutPrefix(text, "-"); ok {
+		text, sign = rest, -1
+	}
+	parts := strings.Split(text, "-")
+	if len(parts) != 3 {
+		return Date{}, fmt.Errorf("%w: %q, ожидалось ГГГГ-ММ-ДД", ErrInvalidDate, s)
+	}
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Date{}, fmt.Errorf("%w: %q, ожидалось ГГГГ-ММ-ДД", ErrInvalidDate, s)
+		}
+		numbers[i] = n
+	}
+	return Date{Year: sign * numbers[0], Month: time.Month(numbers[1]), Day: numbers[2]}, nil
+}
+
+// Time возвращает начало дня d в часовом поясе loc.
+// time.Time тоже считает годы астрономически, поэтому даты до нашей эры переводятся без сдвига.
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// DayOfYear возвращает номер дня в году, с 1. Для неверной даты результат не определен.
+func (d Date) DayOfYear() int {
+	days := d.Day
+	for m := time.January; m < d.Month; m++ {
+		days += DaysInMonth(d.Year, m)
+	}
+	return days
+}
+
+// Weekday возвращает день недели, в том числе для лет вне диапазона time.Time.
+func (d Date) Weekday() time.Weekday {
+	return time.Weekday((d.CalcDays()%7 + 7 + int(time.Thursday)) % 7) // 1 января 1970 года - четверг
+}
+
+// Before проверяет, что d раньше other.
+func (d Date) Before(other Date) bool { return d.CalcDays() < other.CalcDays() }
+
+// After проверяет, что d позже other.
+func (d Date) After(other Date) bool { return d.CalcDays() > other.CalcDays() }
+
+// AddDays возвращает дату через n дней (n < 0 - назад).
+func (d Date) AddDays(n int) Date { return FromDays(d.CalcDays() + n) }
+
+// DaysBetween возвращает число дней от from до to; отрицательное, если to раньше from.
+func DaysBetween(from, to Date) int { return to.CalcDays() - from.CalcDays() }
+
+// CalcDays возвращает номер дня d, отсчитанный от 1 января 1970 года (день 0),
+// как у time.Unix, но без ограничений time.Time на диапазон лет.
+// Алгоритм days_from_civil Говарда Хиннанта: год считается с марта, чтобы 29 февраля было в конце.
+func (d Date) CalcDays() int {
+	y := d.Year
+	if d.Month <= time.February {
+		y--
+	}
+	era := floorDiv(y, 400)
+	yoe := y - era*400                     // год эры, 0-399
+	mp := (int(d.Month) + 9) % 12          // месяц с марта, 0-11
+	doy := (153*mp+2)/5 + d.Day - 1        // день года с 1 марта, 0-365
+	doe := yoe*365 + yoe/4 - yoe/100 + doy // день эры, 0-146096
+	return era*daysPer400Years + doe - daysTo1970FromEra
+}
+
+// FromDays возвращает дату по номеру дня от 1 января 1970 года; обратна CalcDays.
+func FromDays(days int) Date {
+	z := days + daysTo1970FromEra
+	era := floorDiv(z, daysPer400Years)
+	doe := z - era*daysPer400Years
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+	day := doy - (153*mp+2)/5 + 1
+	month := (mp+2)%12 + 1
+	year := yoe + era*400
+	if month <= 2 {
+		year++
+	}
+	return Date{Year: year, Month: time.Month(month), Day: day}
+}
+
+const (
+	daysPer400Years   = 146_097 // дней в 400 григорианских годах
+	daysTo1970FromEra = 719_468 // дней от 1 марта 0 года до 1 января 1970 года
+)
+
+// floorDiv делит с округлением вниз, чтобы годы до нашей эры попадали в предыдущую эру.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// RandomDate возвращает дату из промежутка [from, to], где каждый день равновероятен.
+// В f41 и f42 сначала выбирается месяц, а потом день, поэтому дни коротких месяцев выпадают чаще.
+func RandomDate(r *rand.Rand, from, to Date) (Date, error) {
+	for _, d := range []Date{from, to} {
+		if err := d.Validate(); err != nil {
+			return Date{}, err
+		}
+	}
+	span := DaysBetween(from, to)
+	if span < 0 {
+		return Date{}, fmt.Errorf("%w: %v > %v", ErrInvalidRange, from, to)
+	}
+	return from.AddDays(r.Intn(span + 1)), nil
+}
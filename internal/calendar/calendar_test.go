@@ -0,0 +1,84 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	firstYear = 1
+	lastYear  = 9999
+)
+
+// TestIsLeap сверяет правило високосного года с нормализацией time.Date:
+// 29 февраля невисокосного года переходит в 1 марта.
+func TestIsLeap(t *testing.T) {
+	for year := firstYear; year <= lastYear; year++ {
+		want := time.Date(year, time.February, 29, 0, 0, 0, 0, time.UTC).Month() == time.February
+		if got := IsLeap(year); got != want {
+			t.Errorf("IsLeap(%d) = %t, want %t", year, got, want)
+		}
+		if got, want := DaysInYear(year), time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay(); got != want {
+			t.Errorf("DaysInYear(%d) = %d, want %d", year, got, want)
+		}
+	}
+}
+
+// TestDaysInMonth сверяет число дней с нулевым днем следующего месяца в time.Date.
+func TestDaysInMonth(t *testing.T) {
+	for year := firstYear; year <= lastYear; year++ {
+		for month := time.January; month <= time.December; month++ {
+			want := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+			if got := DaysInMonth(year, month); got != want {
+				t.Errorf("DaysInMonth(%d, %v) = %d, want %d", year, month, got, want)
+			}
+		}
+	}
+	for _, month := range []time.Month{0, 13} {
+		if got := DaysInMonth(2020, month); got != 0 {
+			t.Errorf("DaysInMonth(2020, %d) = %d, want 0", month, got)
+		}
+	}
+}
+
+// TestDays проходит все дни с 1 января 1 года по 31 декабря 9999 года и сверяет
+// CalcDays, FromDays, DayOfYear и Weekday с time.Time.
+func TestDays(t *testing.T) {
+	start := time.Date(firstYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(lastYear, time.December, 31, 0, 0, 0, 0, time.UTC)
+	for tm := start; !tm.After(end); tm = tm.Add(24 * time.Hour) {
+		d := FromTime(tm)
+		if !d.IsValid() {
+			t.Fatalf("%v: Validate = %v", d, d.Validate())
+		}
+
+		days := d.CalcDays()
+		if want := int(tm.Unix() / (24 * 60 * 60)); days != want {
+			t.Fatalf("%v: CalcDays = %d, want %d", d, days, want)
+		}
+		if got := FromDays(days); got != d {
+			t.Fatalf("FromDays(%d) = %v, want %v", days, got, d)
+		}
+		if got, want := d.DayOfYear(), tm.YearDay(); got != want {
+			t.Fatalf("%v: DayOfYear = %d, want %d", d, got, want)
+		}
+		if got, want := d.Weekday(), tm.Weekday(); got != want {
+			t.Fatalf("%v: Weekday = %v, want %v", d, got, want)
+		}
+	}
+}
+
+// TestValidate проверяет, что Validate отвергает те же даты, которые time.Date переносит.
+func TestValidate(t *testing.T) {
+	for year := firstYear; year <= lastYear; year += 97 {
+		for month := time.January; month <= time.December; month++ {
+			for day := 0; day <= 32; day++ {
+				d := Date{Year: year, Month: month, Day: day}
+				want := FromTime(time.Date(year, month, day, 0, 0, 0, 0, time.UTC)) == d
+				if got := d.IsValid(); got != want {
+					t.Errorf("%v: IsValid = %t, want %t", d, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidEra возвращается для неизвестной эры или года эры меньше 1.
+var ErrInvalidEra = errors.New("неверная эра")
+
+// Era - эра летоисчисления. В f40-f42 эра - просто строка "AD", и дату до нашей эры не записать.
+type Era int
+
+const (
+	EraUnknown Era = iota // нулевое значение: эра не задана, FromEra ее не принимает
+	BC                    // до нашей эры: 1 BC, 2 BC, ...
+	AD                    // наша эра: AD 1, AD 2, ...
+)
+
+// String возвращает обозначение эры: "BC" или "AD".
+func (e Era) String() string {
+	switch e {
+	case BC:
+		return "BC"
+	case AD:
+		return "AD"
+	}
+	return fmt.Sprintf("Era(%d)", int(e))
+}
+
+// ParseEra разбирает обозначение эры без учета регистра: AD, CE, BC, BCE, н. э., до н. э.
+func ParseEra(s string) (Era, error) {
+	switch strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", "")) {
+	case "ad", "ce", "н.э.":
+		return AD, nil
+	case "bc", "bce", "дон.э.":
+		return BC, nil
+	}
+	return EraUnknown, fmt.Errorf("%w: %q", ErrInvalidEra, s)
+}
+
+// Даты хранятся в астрономической нумерации лет, как в time.Time: за 1 годом до нашей эры
+// идет год 0, а за ним год 1 нашей эры. Год 0 - это 1 BC, год -1 - 2 BC.
+// Поэтому високосные годы до нашей эры - 1 BC, 5 BC, 9 BC и так далее.
+
+// FromEra возвращает дату года year эры e, например FromEra(BC, 1, time.March, 1) - 1 марта 1 года до н. э.
+func FromEra(e Era, year int, month time.Month, day int) (Date, error) {
+	if year < 1 {
+		return Date{}, fmt.Errorf("%w: год эры %d, счет лет в эре начинается с 1", ErrInvalidEra, year)
+	}
+	switch e {
+	case AD:
+		return NewDate(year, month, day)
+	case BC:
+		return NewDate(1-year, month, day)
+	}
+	return Date{}, fmt.Errorf("%w: %v", ErrInvalidEra, e)
+}
+
+// GetEra возвращает эру даты и год в этой эре.
+func (d Date) GetEra() (Era, int) {
+	if d.Year < 1 {
+		return BC, 1 - d.Year
+	}
+	return AD, d.Year
+}
+
+// FormatEra возвращает дату с эрой в порядке f40-f42: "AD 2018 2 28" для нашей эры
+// и "1 BC 3 1" для дат до нее, где обозначение эры принято ставить после года.
+func (d Date) FormatEra() string {
+	era, year := d.GetEra()
+	if era == BC {
+		return fmt.Sprintf("%d %v %d %d", year, era, d.Month, d.Day)
+	}
+	return fmt.Sprintf("%v %d %d %d", era, year, d.Month, d.Day)
+}
+
+// ParseEraDate разбирает дату в формате FormatEra: "AD 2018 2 28" или "1 BC 3 1".
+// Эру можно поставить и до, и после года, в том числе по-русски: "44 до н. э. 3 15".
+func ParseEraDate(s string) (Date, error) {
+	var (
+		eraWords []string
+		numbers  []int
+	)
+	for _, field := range strings.Fields(s) {
+		if n, err := strconv.Atoi(field); err == nil {
+			numbers = append(numbers, n)
+		} else {
+			eraWords = append(eraWords, field)
+		}
+	}
+	if len(numbers) != 3 || len(eraWords) == 0 {
+		return Date{}, fmt.Errorf("%w: %q, ожидалось \"AD 2018 2 28\" или \"1 BC 3 1\"", ErrInvalidDate, s)
+	}
+
+	era, err := ParseEra(strings.Join(eraWords, " "))
+	if err != nil {
+		return Date{}, err
+	}
+	return FromEra(era, numbers[0], time.Month(numbers[1]), numbers[2])
+}
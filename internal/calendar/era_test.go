@@ -0,0 +1,192 @@
+package calendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseEra(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Era
+	}{
+		{"AD", AD},
+		{"ad", AD},
+		{" CE ", AD},
+		{"н. э.", AD},
+		{"Н.Э.", AD},
+		{"BC", BC},
+		{"bce", BC},
+		{"до н. э.", BC},
+		{"до н.э.", BC},
+	}
+	for _, tt := range tests {
+		if got, err := ParseEra(tt.s); err != nil || got != tt.want {
+			t.Errorf("ParseEra(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+	}
+	for _, s := range []string{"", "AC", "н.", "до", "BC AD"} {
+		if got, err := ParseEra(s); got != EraUnknown || !errors.Is(err, ErrInvalidEra) {
+			t.Errorf("ParseEra(%q) = %v, %v, want EraUnknown и ErrInvalidEra", s, got, err)
+		}
+	}
+}
+
+func TestEraString(t *testing.T) {
+	tests := []struct {
+		e    Era
+		want string
+	}{
+		{AD, "AD"},
+		{BC, "BC"},
+		{EraUnknown, "Era(0)"},
+		{Era(7), "Era(7)"},
+	}
+	for _, tt := range tests {
+		if got := tt.e.String(); got != tt.want {
+			t.Errorf("Era(%d).String() = %q, want %q", int(tt.e), got, tt.want)
+		}
+	}
+}
+
+func TestFromEra(t *testing.T) {
+	tests := []struct {
+		e     Era
+		year  int
+		month time.Month
+		day   int
+		want  string // String() даты
+	}{
+		{AD, 2018, time.February, 28, "2018-02-28"},
+		{AD, 1, time.January, 1, "0001-01-01"},
+		{BC, 1, time.December, 31, "0000-12-31"},
+		// 1 BC - астрономический год 0, он високосный
+		{BC, 1, time.February, 29, "0000-02-29"},
+		{BC, 5, time.February, 29, "-0004-02-29"},
+		{BC, 44, time.March, 15, "-0043-03-15"},
+	}
+	for _, tt := range tests {
+		got, err := FromEra(tt.e, tt.year, tt.month, tt.day)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("FromEra(%v, %d, %d, %d) = %v, %v, want %s", tt.e, tt.year, tt.month, tt.day, got, err, tt.want)
+		}
+	}
+
+	errs := []struct {
+		e     Era
+		year  int
+		month time.Month
+		day   int
+		want  error
+	}{
+		{AD, 0, time.January, 1, ErrInvalidEra},
+		{BC, 0, time.January, 1, ErrInvalidEra},
+		{BC, -1, time.January, 1, ErrInvalidEra},
+		{EraUnknown, 2018, time.January, 1, ErrInvalidEra},
+		{Era(7), 2018, time.January, 1, ErrInvalidEra},
+		{AD, 2019, time.February, 29, ErrInvalidDate},
+		// 2 BC - год -1, не високосный
+		{BC, 2, time.February, 29, ErrInvalidDate},
+		{BC, 1, 13, 1, ErrInvalidDate},
+	}
+	for _, tt := range errs {
+		if got, err := FromEra(tt.e, tt.year, tt.month, tt.day); !errors.Is(err, tt.want) {
+			t.Errorf("FromEra(%v, %d, %d, %d) = %v, %v, want %v", tt.e, tt.year, tt.month, tt.day, got, err, tt.want)
+		}
+	}
+}
+
+func TestGetEra(t *testing.T) {
+	tests := []struct {
+		year    int
+		era     Era
+		eraYear int
+		format  string
+	}{
+		{2018, AD, 2018, "AD 2018 3 1"},
+		{1, AD, 1, "AD 1 3 1"},
+		{0, BC, 1, "1 BC 3 1"},
+		{-1, BC, 2, "2 BC 3 1"},
+		{-43, BC, 44, "44 BC 3 1"},
+	}
+	for _, tt := range tests {
+		d := Date{Year: tt.year, Month: time.March, Day: 1}
+		if era, year := d.GetEra(); era != tt.era || year != tt.eraYear {
+			t.Errorf("%v.GetEra() = %v, %d, want %v, %d", d, era, year, tt.era, tt.eraYear)
+		}
+		if got := d.FormatEra(); got != tt.format {
+			t.Errorf("%v.FormatEra() = %q, want %q", d, got, tt.format)
+		}
+	}
+}
+
+func TestParseEraDate(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string // String() даты
+	}{
+		{"AD 2018 2 28", "2018-02-28"},
+		{"2018 AD 2 28", "2018-02-28"},
+		{"1 BC 3 1", "0000-03-01"},
+		{"BC 1 2 29", "0000-02-29"},
+		{"44 до н. э. 3 15", "-0043-03-15"},
+		{"  1   н. э.  1 1 ", "0001-01-01"},
+	}
+	for _, tt := range tests {
+		got, err := ParseEraDate(tt.s)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("ParseEraDate(%q) = %v, %v, want %s", tt.s, got, err, tt.want)
+		}
+	}
+
+	errs := []struct {
+		s    string
+		want error
+	}{
+		{"", ErrInvalidDate},
+		{"AD 2018 2", ErrInvalidDate},
+		{"2018 2 28", ErrInvalidDate},
+		{"AD 2018 2 28 1", ErrInvalidDate},
+		{"XX 2018 1 1", ErrInvalidEra},
+		{"AD 0 1 1", ErrInvalidEra},
+		{"2 BC 2 29", ErrInvalidDate},
+		{"1 BC 2 30", ErrInvalidDate},
+	}
+	for _, tt := range errs {
+		if got, err := ParseEraDate(tt.s); !errors.Is(err, tt.want) {
+			t.Errorf("ParseEraDate(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+	}
+}
+
+// TestEraRoundTrip проверяет, что дата переживает FormatEra и ParseEraDate,
+// а также Time и FromTime: time.Time тоже считает 1 BC годом 0.
+func TestEraRoundTrip(t *testing.T) {
+	for year := -500; year <= 2100; year++ {
+		for _, month := range []time.Month{time.January, time.February, time.December} {
+			d := Date{Year: year, Month: month, Day: DaysInMonth(year, month)}
+
+			era, eraYear := d.GetEra()
+			if got, err := FromEra(era, eraYear, d.Month, d.Day); err != nil || got != d {
+				t.Fatalf("FromEra(%v.GetEra()) = %v, %v", d, got, err)
+			}
+			if got, err := ParseEraDate(d.FormatEra()); err != nil || got != d {
+				t.Fatalf("ParseEraDate(%q) = %v, %v, want %v", d.FormatEra(), got, err, d)
+			}
+			tm := d.Time(time.UTC)
+			if got := FromTime(tm); got != d || tm.Year() != year {
+				t.Fatalf("FromTime(%v.Time()) = %v (%v), want %v", d, got, tm, d)
+			}
+		}
+	}
+
+	// 29 февраля 1 года до н. э. есть и в time.Time: день не переходит в март
+	leap, err := FromEra(BC, 1, time.February, 29)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm := leap.Time(time.UTC); tm.Month() != time.February || tm.Day() != 29 || tm.Year() != 0 {
+		t.Errorf("%v.Time() = %v", leap, tm)
+	}
+}
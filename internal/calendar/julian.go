@@ -0,0 +1,216 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+)
+
+// Юлианский календарь и переход на григорианский. В f19 проверяется только григорианское
+// правило, а по юлианскому календарю, который действовал в России до 1918 года,
+// високосный каждый четвертый год, в том числе 2100.
+//
+// Дни разных календарей сравниваются по номеру юлианского дня (JDN): непрерывному счету дней
+// от 1 января 4713 года до н. э. по юлианскому календарю. 1 января 2000 года по григорианскому
+// календарю - день 2451545.
+
+const (
+	unixEpochJDN      = 2_440_588 // JDN 1 января 1970 года по григорианскому календарю
+	julianEraJDN      = 1_721_118 // JDN 1 марта 0 года по юлианскому календарю
+	daysPer4JulianYrs = 1_461     // дней в 4 юлианских годах
+)
+
+// Calendar - календарь, по которому записана дата.
+type Calendar int
+
+const (
+	Gregorian Calendar = iota
+	Julian
+)
+
+// String возвращает название календаря.
+func (c Calendar) String() string {
+	switch c {
+	case Gregorian:
+		return "григорианский"
+	case Julian:
+		return "юлианский"
+	}
+	return fmt.Sprintf("Calendar(%d)", int(c))
+}
+
+// IsLeap проверяет, високосный ли год в календаре c.
+func (c Calendar) IsLeap(year int) bool {
+	if c == Julian {
+		return IsJulianLeap(year)
+	}
+	return IsLeap(year)
+}
+
+// DaysInMonth возвращает число дней в месяце month года year календаря c.
+func (c Calendar) DaysInMonth(year int, month time.Month) int {
+	if c == Julian && month == time.February && IsJulianLeap(year) {
+		return 29 // в остальных месяцах и в невисокосном феврале календари совпадают
+	}
+	return DaysInMonth(year, month)
+}
+
+// IsJulianLeap проверяет, високосный ли год по юлианскому календарю: каждый четвертый.
+func IsJulianLeap(year int) bool {
+	return year%4 == 0
+}
+
+// JulianDate - дата по юлианскому календарю, с астрономическим счетом лет, как у Date.
+type JulianDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// Validate проверяет дату по правилам юлианского календаря: 29 февраля 1900 года существует.
+func (d JulianDate) Validate() error {
+	if d.Month < time.January || d.Month > time.December {
+		return fmt.Errorf("%w: месяц %d", ErrInvalidDate, d.Month)
+	}
+	if n := Julian.DaysInMonth(d.Year, d.Month); d.Day < 1 || d.Day > n {
+		return fmt.Errorf("%w: %v по юлианскому календарю, в месяце %d дней", ErrInvalidDate, d, n)
+	}
+	return nil
+}
+
+// String возвращает дату в виде 1918-01-31, как Date.String.
+func (d JulianDate) String() string {
+	return Date(d).String()
+}
+
+// CalcJDN возвращает номер юлианского дня. Устроен как Date.CalcDays, но с 4-летним циклом вместо 400-летнего.
+func (d JulianDate) CalcJDN() int {
+	y := d.Year
+	if d.Month <= time.February {
+		y--
+	}
+	era := floorDiv(y, 4)
+	yoe := y - era*4
+	mp := (int(d.Month) + 9) % 12
+	doy := (153*mp+2)/5 + d.Day - 1
+	return era*daysPer4JulianYrs + yoe*365 + doy + julianEraJDN
+}
+
+// JulianFromJDN возвращает юлианскую дату по номеру юлианского дня.
+func JulianFromJDN(jdn int) JulianDate {
+	z := jdn - julianEraJDN
+	era := floorDiv(z, daysPer4JulianYrs)
+	doe := z - era*daysPer4JulianYrs
+	yoe := min(doe/365, 3) // 1460-й день цикла - 29 февраля, а не начало пятого года
+	doy := doe - 365*yoe
+	mp := (5*doy + 2) / 153
+	day := doy - (153*mp+2)/5 + 1
+	month := (mp+2)%12 + 1
+	year := yoe + era*4
+	if month <= 2 {
+		year++
+	}
+	return JulianDate{Year: year, Month: time.Month(month), Day: day}
+}
+
+// ToGregorian переводит юлианскую дату в григорианскую.
+func (d JulianDate) ToGregorian() Date {
+	return FromJDN(d.CalcJDN())
+}
+
+// CalcJDN возвращает номер юлианского дня григорианской даты.
+func (d Date) CalcJDN() int {
+	return d.CalcDays() + unixEpochJDN
+}
+
+// FromJDN возвращает григорианскую дату по номеру юлианского дня.
+func FromJDN(jdn int) Date {
+	return FromDays(jdn - unixEpochJDN)
+}
+
+// ToJulian переводит григорианскую дату в юлианскую: 14 февраля 1918 года - это 1 февраля по старому стилю.
+func (d Date) ToJulian() JulianDate {
+	return JulianFromJDN(d.CalcJDN())
+}
+
+// Reform - переход с юлианского календаря на григорианский: до FirstGregorian даты записываются
+// по юлианскому календарю, с него - по григорианскому. Дни между ними пропускаются.
+type Reform struct {
+	Name           string
+	FirstGregorian Date
+}
+
+var (
+	// Reform1582 - реформа папы Григория XIII: за 4 октября 1582 года последовало 15 октября.
+	Reform1582 = Reform{Name: "1582", FirstGregorian: Date{Year: 1582, Month: time.October, Day: 15}}
+	// Reform1918 - переход в Советской России: за 31 января 1918 года последовало 14 февраля.
+	Reform1918 = Reform{Name: "1918", FirstGregorian: Date{Year: 1918, Month: time.February, Day: 14}}
+)
+
+// GetReforms возвращает известные реформы.
+func GetReforms() []Reform {
+	return []Reform{Reform1582, Reform1918}
+}
+
+// GetCalendar возвращает календарь, действовавший в году year: юлианский до года реформы,
+// григорианский с него. В годы реформ 1582 и 1918 правила календарей для февраля совпадают.
+func (r Reform) GetCalendar(year int) Calendar {
+	if year < r.FirstGregorian.Year {
+		return Julian
+	}
+	return Gregorian
+}
+
+// IsLeap проверяет, был ли год високосным по календарю, действовавшему в нем.
+func (r Reform) IsLeap(year int) bool {
+	return r.GetCalendar(year).IsLeap(year)
+}
+
+// CivilDate - дата в календаре, который действовал в этот день, как ее записали бы современники.
+type CivilDate struct {
+	Date     Date // год, месяц и день; для Julian - по юлианскому календарю
+	Calendar Calendar
+}
+
+// String возвращает дату с пометкой календаря, для юлианского - "(ст. ст.)", старый стиль.
+func (d CivilDate) String() string {
+	if d.Calendar == Julian {
+		return d.Date.String() + " (ст. ст.)"
+	}
+	return d.Date.String()
+}
+
+// FromJDN возвращает дату дня jdn по календарю, действовавшему в этот день.
+func (r Reform) FromJDN(jdn int) CivilDate {
+	if jdn < r.FirstGregorian.CalcJDN() {
+		return CivilDate{Date: Date(JulianFromJDN(jdn)), Calendar: Julian}
+	}
+	return CivilDate{Date: FromJDN(jdn), Calendar: Gregorian}
+}
+
+// CalcJDN возвращает номер юлианского дня даты.
+func (d CivilDate) CalcJDN() int {
+	if d.Calendar == Julian {
+		return JulianDate(d.Date).CalcJDN()
+	}
+	return d.Date.CalcJDN()
+}
+
+// NewCivilDate возвращает дату d, записанную по календарю, который действовал в ней:
+// до FirstGregorian - по юлианскому. Дни, пропущенные при реформе
+// (например, 5-14 октября 1582 года), не существуют.
+func (r Reform) NewCivilDate(d Date) (CivilDate, error) {
+	if !d.Before(r.FirstGregorian) {
+		if err := d.Validate(); err != nil {
+			return CivilDate{}, err
+		}
+		return CivilDate{Date: d, Calendar: Gregorian}, nil
+	}
+	civil := CivilDate{Date: d, Calendar: Julian}
+	if err := JulianDate(d).Validate(); err != nil {
+		return CivilDate{}, err
+	}
+	if civil.CalcJDN() >= r.FirstGregorian.CalcJDN() {
+		return CivilDate{}, fmt.Errorf("%w: %v пропущено при переходе на григорианский календарь в %s году", ErrInvalidDate, d, r.Name)
+	}
+	return civil, nil
+}
@@ -0,0 +1,212 @@
+package calendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJDN(t *testing.T) {
+	tests := []struct {
+		name      string
+		julian    JulianDate
+		gregorian Date
+		jdn       int
+	}{
+		{"начало счета", JulianDate{-4712, time.January, 1}, Date{-4713, time.November, 24}, 0},
+		{"J2000", JulianDate{1999, time.December, 19}, Date{2000, time.January, 1}, 2_451_545},
+		{"Unix", JulianDate{1969, time.December, 19}, Date{1970, time.January, 1}, 2_440_588},
+		{"последний юлианский день 1582", JulianDate{1582, time.October, 4}, Date{1582, time.October, 14}, 2_299_160},
+		{"первый григорианский день 1582", JulianDate{1582, time.October, 5}, Date{1582, time.October, 15}, 2_299_161},
+		{"последний юлианский день 1918", JulianDate{1918, time.January, 31}, Date{1918, time.February, 13}, 2_421_638},
+		{"первый григорианский день 1918", JulianDate{1918, time.February, 1}, Date{1918, time.February, 14}, 2_421_639},
+		// разница календарей растет до 14 дней на юлианском 29 февраля 2100 года
+		{"29 февраля 2100", JulianDate{2100, time.February, 29}, Date{2100, time.March, 14}, 2_488_142},
+		{"1 год до н. э.", JulianDate{0, time.January, 1}, Date{-1, time.December, 30}, 1_721_058},
+	}
+	for _, tt := range tests {
+		if got := tt.julian.CalcJDN(); got != tt.jdn {
+			t.Errorf("%s: %v.CalcJDN() = %d, want %d", tt.name, tt.julian, got, tt.jdn)
+		}
+		if got := tt.gregorian.CalcJDN(); got != tt.jdn {
+			t.Errorf("%s: %v.CalcJDN() = %d, want %d", tt.name, tt.gregorian, got, tt.jdn)
+		}
+		if got := JulianFromJDN(tt.jdn); got != tt.julian {
+			t.Errorf("%s: JulianFromJDN(%d) = %v, want %v", tt.name, tt.jdn, got, tt.julian)
+		}
+		if got := FromJDN(tt.jdn); got != tt.gregorian {
+			t.Errorf("%s: FromJDN(%d) = %v, want %v", tt.name, tt.jdn, got, tt.gregorian)
+		}
+		if got := tt.julian.ToGregorian(); got != tt.gregorian {
+			t.Errorf("%s: %v.ToGregorian() = %v, want %v", tt.name, tt.julian, got, tt.gregorian)
+		}
+		if got := tt.gregorian.ToJulian(); got != tt.julian {
+			t.Errorf("%s: %v.ToJulian() = %v, want %v", tt.name, tt.gregorian, got, tt.julian)
+		}
+	}
+}
+
+// TestJulianFromJDN проверяет, что соседние номера дней дают соседние юлианские даты,
+// а перевод туда и обратно возвращает тот же номер.
+func TestJulianFromJDN(t *testing.T) {
+	prev := JulianFromJDN(-1)
+	for jdn := 0; jdn <= 2_600_000; jdn++ {
+		d := JulianFromJDN(jdn)
+		if err := d.Validate(); err != nil {
+			t.Fatalf("JulianFromJDN(%d) = %v: %v", jdn, d, err)
+		}
+		if got := d.CalcJDN(); got != jdn {
+			t.Fatalf("%v.CalcJDN() = %d, want %d", d, got, jdn)
+		}
+		isNext := d.Day == prev.Day+1 && d.Month == prev.Month && d.Year == prev.Year ||
+			d.Day == 1 && prev.Day == Julian.DaysInMonth(prev.Year, prev.Month) &&
+				(d.Month == prev.Month+1 && d.Year == prev.Year || d.Month == time.January && prev.Month == time.December && d.Year == prev.Year+1)
+		if !isNext {
+			t.Fatalf("JulianFromJDN(%d) = %v после %v", jdn, d, prev)
+		}
+		prev = d
+	}
+}
+
+func TestJulianLeap(t *testing.T) {
+	tests := []struct {
+		year               int
+		julian, gregorian  bool
+		reform1582, reform bool // по Reform1582 и Reform1918
+	}{
+		{1500, true, false, true, true},
+		{1600, true, true, true, true},
+		{1700, true, false, false, true},
+		{1900, true, false, false, true},
+		{1918, false, false, false, false},
+		{2000, true, true, true, true},
+		{2100, true, false, false, false},
+		{0, true, true, true, true},
+		{-1, false, false, false, false},
+		{-4, true, true, true, true},
+	}
+	for _, tt := range tests {
+		if got := Julian.IsLeap(tt.year); got != tt.julian || IsJulianLeap(tt.year) != tt.julian {
+			t.Errorf("Julian.IsLeap(%d) = %t, want %t", tt.year, got, tt.julian)
+		}
+		if got := Gregorian.IsLeap(tt.year); got != tt.gregorian {
+			t.Errorf("Gregorian.IsLeap(%d) = %t, want %t", tt.year, got, tt.gregorian)
+		}
+		if got := Reform1582.IsLeap(tt.year); got != tt.reform1582 {
+			t.Errorf("Reform1582.IsLeap(%d) = %t, want %t", tt.year, got, tt.reform1582)
+		}
+		if got := Reform1918.IsLeap(tt.year); got != tt.reform {
+			t.Errorf("Reform1918.IsLeap(%d) = %t, want %t", tt.year, got, tt.reform)
+		}
+	}
+	if got := Julian.DaysInMonth(1900, time.February); got != 29 {
+		t.Errorf("Julian.DaysInMonth(1900, February) = %d, want 29", got)
+	}
+	if got := Gregorian.DaysInMonth(1900, time.February); got != 28 {
+		t.Errorf("Gregorian.DaysInMonth(1900, February) = %d, want 28", got)
+	}
+	if got := Julian.DaysInMonth(1900, time.April); got != 30 {
+		t.Errorf("Julian.DaysInMonth(1900, April) = %d, want 30", got)
+	}
+	if Julian.String() != "юлианский" || Gregorian.String() != "григорианский" || Calendar(5).String() != "Calendar(5)" {
+		t.Errorf("String: %q, %q, %q", Julian, Gregorian, Calendar(5))
+	}
+}
+
+func TestJulianValidate(t *testing.T) {
+	valid := []JulianDate{{1900, time.February, 29}, {2100, time.February, 29}, {1918, time.January, 31}}
+	for _, d := range valid {
+		if err := d.Validate(); err != nil {
+			t.Errorf("%v.Validate() = %v", d, err)
+		}
+	}
+	invalid := []JulianDate{{1901, time.February, 29}, {1900, time.February, 30}, {1900, 13, 1}, {1900, time.April, 0}}
+	for _, d := range invalid {
+		if err := d.Validate(); !errors.Is(err, ErrInvalidDate) {
+			t.Errorf("%v.Validate() = %v, want ErrInvalidDate", d, err)
+		}
+	}
+}
+
+func TestReformFromJDN(t *testing.T) {
+	tests := []struct {
+		r    Reform
+		jdn  int
+		want string
+	}{
+		{Reform1582, 2_299_160, "1582-10-04 (ст. ст.)"},
+		{Reform1582, 2_299_161, "1582-10-15"},
+		{Reform1918, 2_299_161, "1582-10-05 (ст. ст.)"},
+		{Reform1918, 2_421_638, "1918-01-31 (ст. ст.)"},
+		{Reform1918, 2_421_639, "1918-02-14"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.FromJDN(tt.jdn); got.String() != tt.want {
+			t.Errorf("Reform%s.FromJDN(%d) = %v, want %s", tt.r.Name, tt.jdn, got, tt.want)
+		}
+	}
+
+	// вокруг реформы каждый день записывается одной датой, и пропусков в номерах дней нет
+	for _, r := range GetReforms() {
+		first := r.FirstGregorian.CalcJDN()
+		for jdn := first - 400; jdn < first+400; jdn++ {
+			civil := r.FromJDN(jdn)
+			if got := civil.CalcJDN(); got != jdn {
+				t.Fatalf("Reform%s: %v.CalcJDN() = %d, want %d", r.Name, civil, got, jdn)
+			}
+			if got, err := r.NewCivilDate(civil.Date); err != nil || got != civil {
+				t.Fatalf("Reform%s.NewCivilDate(%v) = %v, %v, want %v", r.Name, civil.Date, got, err, civil)
+			}
+		}
+	}
+}
+
+func TestNewCivilDate(t *testing.T) {
+	tests := []struct {
+		r    Reform
+		d    Date
+		want Calendar
+	}{
+		{Reform1582, Date{1582, time.October, 4}, Julian},
+		{Reform1582, Date{1582, time.October, 15}, Gregorian},
+		// 29 февраля 1500 года было по юлианскому календарю
+		{Reform1582, Date{1500, time.February, 29}, Julian},
+		{Reform1918, Date{1900, time.February, 29}, Julian},
+		{Reform1918, Date{1918, time.January, 31}, Julian},
+		{Reform1918, Date{1918, time.February, 14}, Gregorian},
+	}
+	for _, tt := range tests {
+		got, err := tt.r.NewCivilDate(tt.d)
+		if err != nil || got.Date != tt.d || got.Calendar != tt.want {
+			t.Errorf("Reform%s.NewCivilDate(%v) = %v, %v, want %v", tt.r.Name, tt.d, got, err, tt.want)
+		}
+	}
+
+	// пропущенные при реформе дни
+	skipped := map[Reform][2]Date{
+		Reform1582: {{1582, time.October, 5}, {1582, time.October, 14}},
+		Reform1918: {{1918, time.February, 1}, {1918, time.February, 13}},
+	}
+	for r, days := range skipped {
+		for d := days[0]; !d.After(days[1]); d = d.AddDays(1) {
+			if got, err := r.NewCivilDate(d); !errors.Is(err, ErrInvalidDate) {
+				t.Errorf("Reform%s.NewCivilDate(%v) = %v, %v, want ErrInvalidDate", r.Name, d, got, err)
+			}
+		}
+	}
+
+	invalid := []struct {
+		r Reform
+		d Date
+	}{
+		{Reform1582, Date{1700, time.February, 29}},
+		{Reform1582, Date{1582, time.February, 30}},
+		{Reform1918, Date{1918, time.February, 29}},
+		{Reform1918, Date{1800, 13, 1}},
+	}
+	for _, tt := range invalid {
+		if got, err := tt.r.NewCivilDate(tt.d); !errors.Is(err, ErrInvalidDate) {
+			t.Errorf("Reform%s.NewCivilDate(%v) = %v, %v, want ErrInvalidDate", tt.r.Name, tt.d, got, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"example/internal/datefmt"
+)
+
+// ErrUnknownLanguage возвращается для языка, названий которого нет в таблице.
+var ErrUnknownLanguage = errors.New("неизвестный язык")
+
+// Names - названия дней недели и месяцев одного языка в начальной форме,
+// как в f26: "понедельник", "январь". Названия берутся из таблиц языков internal/datefmt,
+// чтобы они не расходились с форматированием дат.
+type Names struct {
+	Language string
+	Weekdays [7]string // с воскресенья, как time.Weekday
+	Months   [12]string
+}
+
+// builtinNames - названия всех встроенных языков datefmt.
+var builtinNames = loadBuiltinNames()
+
+var (
+	// Russian - названия на русском.
+	Russian = mustGetNames("ru")
+	// English - названия на английском.
+	English = mustGetNames("en")
+)
+
+// loadBuiltinNames читает названия из встроенных таблиц языков datefmt.
+func loadBuiltinNames() []Names {
+	var list []Names
+	for _, lang := range datefmt.GetLocales() {
+		l, err := datefmt.GetLocale(lang)
+		if err != nil {
+			panic(err) // таблицы встроены в программу
+		}
+		n := Names{Language: l.Name}
+		months, _ := l.GetMonths("")     // именительный падеж есть в каждой проверенной таблице
+		weekdays, _ := l.GetWeekdays("") // как и ровно 12 месяцев и 7 дней недели
+		copy(n.Months[:], months)
+		copy(n.Weekdays[:], weekdays)
+		list = append(list, n)
+	}
+	return list
+}
+
+// mustGetNames возвращает названия встроенного языка lang.
+func mustGetNames(lang string) Names {
+	n, err := GetNames(lang)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// GetNames возвращает названия для языка lang из таблиц datefmt: "ru" или "en".
+func GetNames(lang string) (Names, error) {
+	var langs []string
+	for _, n := range builtinNames {
+		if strings.EqualFold(n.Language, lang) {
+			return n, nil
+		}
+		langs = append(langs, n.Language)
+	}
+	return Names{}, fmt.Errorf("%w: %q, есть %s", ErrUnknownLanguage, lang, strings.Join(langs, ", "))
+}
+
+// GetWeekday возвращает название дня недели.
+func (n Names) GetWeekday(w time.Weekday) string {
+	if w < time.Sunday || w > time.Saturday {
+		return w.String()
+	}
+	return n.Weekdays[w]
+}
+
+// GetMonth возвращает название месяца.
+func (n Names) GetMonth(m time.Month) string {
+	if m < time.January || m > time.December {
+		return m.String()
+	}
+	return n.Months[m-1]
+}
+
+// ParseWeekday разбирает день недели на любом встроенном языке без учета регистра:
+// полное название или первые три буквы ("sat", "суб").
+func ParseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, n := range builtinNames {
+		for w, name := range n.Weekdays {
+			name = strings.ToLower(name)
+			if s == name || (utf8.RuneCountInString(s) == 3 && strings.HasPrefix(name, s)) {
+				return time.Weekday(w), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: день недели %q", ErrInvalidDate, s)
+}
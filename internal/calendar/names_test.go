@@ -0,0 +1,71 @@
+package calendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetNames(t *testing.T) {
+	tests := []struct {
+		lang             string
+		monday, december string
+	}{
+		{"ru", "понедельник", "декабрь"},
+		{"RU", "понедельник", "декабрь"},
+		{"en", "Monday", "December"},
+	}
+	for _, tt := range tests {
+		n, err := GetNames(tt.lang)
+		if err != nil {
+			t.Errorf("GetNames(%q): %v", tt.lang, err)
+			continue
+		}
+		if got := n.GetWeekday(time.Monday); got != tt.monday {
+			t.Errorf("%s: GetWeekday(Monday) = %q, want %q", tt.lang, got, tt.monday)
+		}
+		if got := n.GetMonth(time.December); got != tt.december {
+			t.Errorf("%s: GetMonth(December) = %q, want %q", tt.lang, got, tt.december)
+		}
+	}
+	if _, err := GetNames("fr"); !errors.Is(err, ErrUnknownLanguage) {
+		t.Errorf("GetNames(fr): err = %v, want ErrUnknownLanguage", err)
+	}
+	if got := Russian.GetWeekday(time.Weekday(7)); got != time.Weekday(7).String() {
+		t.Errorf("GetWeekday(7) = %q", got)
+	}
+	if got := Russian.GetMonth(13); got != time.Month(13).String() {
+		t.Errorf("GetMonth(13) = %q", got)
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	tests := []struct {
+		s     string
+		want  time.Weekday
+		isErr bool
+	}{
+		{s: "saturday", want: time.Saturday},
+		{s: "Sat", want: time.Saturday},
+		{s: " sunday ", want: time.Sunday},
+		{s: "суббота", want: time.Saturday},
+		{s: "ВС", isErr: true}, // сокращение только из трех букв
+		{s: "вос", want: time.Sunday},
+		{s: "Пятница", want: time.Friday},
+		{s: "fri", want: time.Friday},
+		{s: "satur", isErr: true},
+		{s: "", isErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWeekday(tt.s)
+		if tt.isErr {
+			if !errors.Is(err, ErrInvalidDate) {
+				t.Errorf("ParseWeekday(%q) = %v, %v, want ErrInvalidDate", tt.s, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseWeekday(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+	}
+}
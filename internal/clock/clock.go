@@ -0,0 +1,112 @@
+// Package clock отделяет код от системных часов.
+// Примеры и пакеты, которым нужно текущее время или пауза, получают Clock снаружи:
+// в обычном запуске это Real, а в тестах и при воспроизведении - Fake.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock - источник текущего времени и пауз.
+type Clock interface {
+	// Now возвращает текущее время.
+	Now() time.Time
+	// Sleep приостанавливает выполнение на d.
+	Sleep(d time.Duration)
+	// NewTicker возвращает тикер, который срабатывает каждые d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker - источник периодических сигналов, как time.Ticker.
+type Ticker interface {
+	// C возвращает канал, в который приходит время каждого срабатывания.
+	C() <-chan time.Time
+	// Stop останавливает тикер. Канал C после этого не закрывается.
+	Stop()
+}
+
+// Real - системные часы: обертка над time.Now и time.Sleep.
+type Real struct{}
+
+// Now возвращает time.Now().
+func (Real) Now() time.Time { return time.Now() }
+
+// Sleep вызывает time.Sleep(d).
+func (Real) Sleep(d time.Duration) { time.Sleep(d) }
+
+// NewTicker возвращает обертку над time.NewTicker(d).
+func (Real) NewTicker(d time.Duration) Ticker { return realTicker{time.NewTicker(d)} }
+
+// realTicker - Ticker на основе time.Ticker.
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r realTicker) C() <-chan time.Time { return r.t.C }
+func (r realTicker) Stop()               { r.t.Stop() }
+
+// Fake - поддельные часы, время которых двигается только через Sleep, Advance и тикеры.
+// Sleep возвращается сразу, а тикер срабатывает, как только из него читают,
+// поэтому обратные отсчеты выполняются мгновенно.
+// Безопасен для использования из нескольких горутин.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake создает поддельные часы, показывающие время now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now возвращает текущее поддельное время.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Sleep не ждет, а сразу сдвигает поддельное время на d.
+func (f *Fake) Sleep(d time.Duration) {
+	f.Advance(d)
+}
+
+// Advance сдвигает поддельное время на d.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// NewTicker возвращает тикер, который не ждет: каждое срабатывание сдвигает поддельное время на d
+// и сразу отдается читающему. Пока из канала не читают, время уходит вперед не больше чем на d.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	t := &fakeTicker{c: make(chan time.Time), done: make(chan struct{})}
+	go func() {
+		for {
+			select {
+			case <-t.done:
+				return
+			default:
+			}
+			f.Advance(d)
+			select {
+			case t.c <- f.Now():
+			case <-t.done:
+				return
+			}
+		}
+	}()
+	return t
+}
+
+// fakeTicker - тикер поддельных часов.
+type fakeTicker struct {
+	c    chan time.Time
+	done chan struct{}
+	once sync.Once
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+func (t *fakeTicker) Stop()               { t.once.Do(func() { close(t.done) }) }
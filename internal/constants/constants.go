@@ -0,0 +1,57 @@
+// Package constants собирает именованные магические числа проекта,
+// чтобы по имени константы было понятно, откуда взялось значение.
+package constants
+
+// Время.
+const (
+	SecondsPerDay = 86_400 // секунд в сутках
+	HoursPerDay   = 24     // часов в сутках
+)
+
+// Полет на Марс из урока про покупку билетов (f43).
+const (
+	MarsDistance20201013 = 62_100_000 // км, расстояние от Земли до Марса 13 октября 2020 года
+	MinShipSpeed         = 16         // км/с, минимальная скорость корабля
+	MaxShipSpeed         = 30         // км/с, максимальная скорость корабля
+	BaseTicketPrice      = 20_000_000 // $, цена билета без надбавки за скорость
+	SpeedPremium         = 1_000_000  // $ за каждый км/с скорости
+	RoundTripMultiplier  = 2          // во сколько раз билет туда-обратно дороже билета в один конец
+)
+
+// Расстояние от Земли до Марса из примеров f8 и f12.
+const (
+	MinMarsDistance = 56_000_000  // км, наименьшее расстояние (великое противостояние)
+	MaxMarsDistance = 401_000_000 // км, наибольшее расстояние (соединение)
+)
+
+// Астрономия.
+const (
+	AstronomicalUnit     = 149_597_870.7 // км в астрономической единице
+	J2000JulianDay       = 2_451_545.0   // юлианский день эпохи J2000.0 (1 января 2000 года, 12:00 TT)
+	UnixEpochJulianDay   = 2_440_587.5   // юлианский день 1 января 1970 года, 00:00 UTC
+	DaysPerJulianCentury = 36_525.0      // суток в юлианском столетии
+)
+
+// Копилка из f54 и f69.
+const (
+	PiggyBankTarget  = 20     // основных единиц валюты, сколько нужно накопить
+	PiggyBankTrials  = 10_000 // сколько раз повторять опыт по умолчанию
+	HistogramBuckets = 10     // столбцов в гистограмме распределения
+	HistogramWidth   = 40     // символов в самом длинном столбце гистограммы
+)
+
+// Пещера из f15-f22.
+const (
+	DefaultPlayerAge = 41 // лет, возраст игрока из f16
+)
+
+// Календарь из f19.
+const (
+	LeapQuestionYear = 2100 // год из вопроса f19 "Он високосный?"
+)
+
+// Обратный отсчет из f23 и f25.
+const (
+	CountdownFrom        = 10  // с какого числа начинается отсчет
+	CountdownAbortChance = 100 // запуск отменяется с шансом 1 к 100 на каждом шаге
+)
@@ -0,0 +1,251 @@
+// Package countdown - обратный отсчет перед запуском из f23 и f25.
+//
+// В примерах отсчет - цикл с time.Sleep: его нельзя приостановить или отменить
+// из другой горутины, а шанс отмены 1 к 100 зашит в код. Countdown считает по тикеру
+// часов clock.Clock, слушает context.Context и команды Hold, Resume и Abort,
+// проверяет условия отмены Check перед каждым шагом и сообщает о ходе отсчета событиями Event.
+package countdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"example/internal/clock"
+)
+
+var (
+	// ErrAborted возвращается из Run, если запуск отменен: проверкой, командой Abort или контекстом.
+	ErrAborted = errors.New("запуск отменен")
+	// ErrContingency - причина отмены в RandomAbort, как в f25.
+	ErrContingency = errors.New("непредвиденные обстоятельства")
+	// ErrInvalidFrom возвращается для отсчета, который начинается не с положительного числа.
+	ErrInvalidFrom = errors.New("отсчет должен начинаться с положительного числа")
+	// ErrInvalidInterval возвращается для неположительного шага отсчета.
+	ErrInvalidInterval = errors.New("шаг отсчета должен быть положительным")
+	// ErrAlreadyStarted возвращается при повторном вызове Run.
+	ErrAlreadyStarted = errors.New("отсчет уже запущен")
+	// ErrNotRunning возвращается из Hold, Resume и Abort после завершения отсчета.
+	ErrNotRunning = errors.New("отсчет уже завершен")
+)
+
+// Kind - вид события отсчета.
+type Kind int
+
+const (
+	KindTick   Kind = iota // очередной шаг: T-10, T-9, ...
+	KindHold               // отсчет приостановлен
+	KindResume             // отсчет продолжен
+	KindAbort              // запуск отменен, последнее событие
+	KindLaunch             // T-0, последнее событие
+)
+
+// String возвращает название вида события.
+func (k Kind) String() string {
+	switch k {
+	case KindTick:
+		return "отсчет"
+	case KindHold:
+		return "задержка"
+	case KindResume:
+		return "продолжение"
+	case KindAbort:
+		return "отмена"
+	case KindLaunch:
+		return "запуск"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Event - событие отсчета.
+type Event struct {
+	Kind      Kind
+	Remaining int       // шагов до T-0
+	Time      time.Time // время события по часам отсчета
+	Reason    string    // причина задержки или отмены
+}
+
+// String описывает событие одной строкой: "T-7 задержка: ветер".
+func (e Event) String() string {
+	switch e.Kind {
+	case KindTick:
+		return fmt.Sprintf("T-%d", e.Remaining)
+	case KindLaunch:
+		return "Запуск!"
+	case KindResume:
+		return fmt.Sprintf("T-%d отсчет продолжен", e.Remaining)
+	}
+	if e.Reason == "" {
+		return fmt.Sprintf("T-%d %s", e.Remaining, e.Kind)
+	}
+	return fmt.Sprintf("T-%d %s: %s", e.Remaining, e.Kind, e.Reason)
+}
+
+// Check - проверка перед каждым шагом отсчета, remaining - шагов до T-0 на момент проверки.
+// Ошибка отменяет запуск, ее текст становится причиной отмены.
+type Check func(remaining int) error
+
+// RandomAbort возвращает проверку из f25: на каждом шаге запуск отменяется с шансом 1 к n.
+// Генератор r не потокобезопасен, поэтому не должен использоваться одновременно в другом месте.
+func RandomAbort(r *rand.Rand, n int) Check {
+	return func(int) error {
+		if r.Intn(n) == 0 {
+			return ErrContingency
+		}
+		return nil
+	}
+}
+
+// command - команда отсчету из другой горутины.
+type command struct {
+	kind   Kind // KindHold, KindResume или KindAbort
+	reason string
+}
+
+// Countdown - обратный отсчет. Run выполняет его один раз, а Hold, Resume и Abort
+// можно вызывать из других горутин, пока Run работает.
+type Countdown struct {
+	from     int
+	interval time.Duration
+	clock    clock.Clock
+	checks   []Check
+
+	events   chan Event
+	commands chan command
+	done     chan struct{} // закрывается, когда Run завершился
+	started  atomic.Bool
+}
+
+// New создает отсчет от from до нуля с шагом interval по часам c.
+// Проверки checks выполняются по порядку перед каждым шагом.
+func New(from int, interval time.Duration, c clock.Clock, checks ...Check) (*Countdown, error) {
+	if from <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidFrom, from)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInterval, interval)
+	}
+	return &Countdown{
+		from:     from,
+		interval: interval,
+		clock:    c,
+		checks:   checks,
+		events:   make(chan Event),
+		commands: make(chan command),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Events возвращает канал событий. Run закрывает его по завершении.
+// Канал не буферизован: пока событие не прочитано, отсчет стоит, поэтому читать его нужно до закрытия.
+// Если ctx, переданный в Run, отменен, непрочитанные промежуточные события отбрасываются,
+// но последнее событие, KindAbort или KindLaunch, доставляется всегда и перед самым закрытием канала.
+func (cd *Countdown) Events() <-chan Event { return cd.events }
+
+// Hold приостанавливает отсчет с причиной reason. Повторная задержка ничего не меняет.
+func (cd *Countdown) Hold(reason string) error {
+	return cd.send(command{kind: KindHold, reason: reason})
+}
+
+// Resume продолжает приостановленный отсчет с того же шага. Первый шаг после продолжения
+// наступает через полный interval.
+func (cd *Countdown) Resume() error {
+	return cd.send(command{kind: KindResume})
+}
+
+// Abort отменяет запуск с причиной reason.
+func (cd *Countdown) Abort(reason string) error {
+	return cd.send(command{kind: KindAbort, reason: reason})
+}
+
+// send передает команду Run. Если Run еще не запущен, ждет его запуска.
+func (cd *Countdown) send(c command) error {
+	select {
+	case cd.commands <- c:
+		return nil
+	case <-cd.done:
+		return ErrNotRunning
+	}
+}
+
+// Run ведет отсчет до запуска или отмены. Возвращает nil, если дошел до T-0,
+// и ошибку с ErrAborted при отмене. Если отмену вызвал ctx, ошибка оборачивает и context.Cause(ctx).
+func (cd *Countdown) Run(ctx context.Context) error {
+	if !cd.started.CompareAndSwap(false, true) {
+		return ErrAlreadyStarted
+	}
+	defer close(cd.events)
+	defer close(cd.done) // раньше events: после последнего события команды уже не принимаются
+
+	remaining := cd.from
+	start := cd.clock.Now() // до тикера: поддельный тикер сдвигает время, не дожидаясь чтения
+	ticker := cd.clock.NewTicker(cd.interval)
+	defer func() { ticker.Stop() }()
+	ticks := ticker.C()
+
+	cd.emit(ctx, KindTick, remaining, start, "")
+	for {
+		select {
+		case <-ctx.Done():
+			return cd.abort(remaining, context.Cause(ctx))
+
+		case c := <-cd.commands:
+			switch {
+			case c.kind == KindAbort:
+				return cd.abort(remaining, errors.New(c.reason))
+			case c.kind == KindHold && ticks != nil:
+				// на время задержки тикер останавливается, а nil-канал в select никогда не готов
+				ticker.Stop()
+				ticks = nil
+				cd.emit(ctx, KindHold, remaining, cd.clock.Now(), c.reason)
+			case c.kind == KindResume && ticks == nil:
+				ticker = cd.clock.NewTicker(cd.interval)
+				ticks = ticker.C()
+				cd.emit(ctx, KindResume, remaining, cd.clock.Now(), "")
+			}
+
+		case now := <-ticks:
+			for _, check := range cd.checks {
+				if err := check(remaining); err != nil {
+					return cd.abortAt(remaining, now, err)
+				}
+			}
+			remaining--
+			if remaining == 0 {
+				cd.emitLast(KindLaunch, remaining, now, "")
+				return nil
+			}
+			cd.emit(ctx, KindTick, remaining, now, "")
+		}
+	}
+}
+
+// abort сообщает об отмене на шаге remaining по причине cause и возвращает ошибку для Run.
+func (cd *Countdown) abort(remaining int, cause error) error {
+	return cd.abortAt(remaining, cd.clock.Now(), cause)
+}
+
+// abortAt - abort с временем события now, например временем срабатывания тикера.
+func (cd *Countdown) abortAt(remaining int, now time.Time, cause error) error {
+	cd.emitLast(KindAbort, remaining, now, cause.Error())
+	return fmt.Errorf("%w на T-%d: %w", ErrAborted, remaining, cause)
+}
+
+// emit отправляет промежуточное событие в канал Events. После отмены ctx событие, которое никто
+// не читает, отбрасывается: иначе Run ждал бы читателя, хотя отсчет уже пора завершать.
+func (cd *Countdown) emit(ctx context.Context, kind Kind, remaining int, now time.Time, reason string) {
+	select {
+	case cd.events <- Event{Kind: kind, Remaining: remaining, Time: now, Reason: reason}:
+	case <-ctx.Done():
+	}
+}
+
+// emitLast отправляет последнее событие, KindAbort или KindLaunch, без оглядки на ctx:
+// select с ctx.Done выбирал бы между отправкой и отменой случайно и терял бы событие об отмене.
+// Читатель Events обязан читать канал до закрытия, поэтому отправка завершится.
+func (cd *Countdown) emitLast(kind Kind, remaining int, now time.Time, reason string) {
+	cd.events <- Event{Kind: kind, Remaining: remaining, Time: now, Reason: reason}
+}
@@ -0,0 +1,268 @@
+package countdown
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"example/internal/clock"
+)
+
+var start = time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+// manualClock - часы, тикер которых срабатывает только по tick: так команды и шаги
+// отсчета приходят в Run в порядке, который задает тест.
+type manualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	ticker *manualTicker
+}
+
+type manualTicker struct {
+	c         chan time.Time
+	isStopped bool
+}
+
+func (t *manualTicker) C() <-chan time.Time { return t.c }
+func (t *manualTicker) Stop()               { t.isStopped = true }
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) Sleep(d time.Duration) {}
+
+func (c *manualClock) NewTicker(d time.Duration) clock.Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ticker = &manualTicker{c: make(chan time.Time)}
+	return c.ticker
+}
+
+// tick сдвигает время на секунду и ждет, пока Run примет срабатывание текущего тикера.
+func (c *manualClock) tick() {
+	c.mu.Lock()
+	c.now = c.now.Add(time.Second)
+	now, ticker := c.now, c.ticker
+	c.mu.Unlock()
+	ticker.c <- now
+}
+
+// startRun запускает Run в горутине и возвращает канал с его результатом.
+func startRun(ctx context.Context, cd *Countdown) <-chan error {
+	result := make(chan error, 1)
+	go func() { result <- cd.Run(ctx) }()
+	return result
+}
+
+// next читает следующее событие и сверяет его вид и шаг.
+func next(t *testing.T, cd *Countdown, kind Kind, remaining int) Event {
+	t.Helper()
+	e, ok := <-cd.Events()
+	if !ok {
+		t.Fatalf("канал событий закрыт, want %v T-%d", kind, remaining)
+	}
+	if e.Kind != kind || e.Remaining != remaining {
+		t.Fatalf("событие %v (%v), want %v T-%d", e, e.Kind, kind, remaining)
+	}
+	return e
+}
+
+// drain читает события до закрытия канала.
+func drain(cd *Countdown) []Event {
+	var events []Event
+	for e := range cd.Events() {
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestNew(t *testing.T) {
+	c := &manualClock{now: start}
+	if _, err := New(0, time.Second, c); !errors.Is(err, ErrInvalidFrom) {
+		t.Errorf("New(0): err = %v, want ErrInvalidFrom", err)
+	}
+	if _, err := New(3, 0, c); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("New(3, 0): err = %v, want ErrInvalidInterval", err)
+	}
+}
+
+func TestLaunch(t *testing.T) {
+	c := &manualClock{now: start}
+	cd, err := New(3, time.Second, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := startRun(context.Background(), cd)
+
+	if e := next(t, cd, KindTick, 3); !e.Time.Equal(start) {
+		t.Errorf("время первого события %v, want %v", e.Time, start)
+	}
+	go func() {
+		for range 3 {
+			c.tick()
+		}
+	}()
+	next(t, cd, KindTick, 2)
+	next(t, cd, KindTick, 1)
+	if e := next(t, cd, KindLaunch, 0); !e.Time.Equal(start.Add(3 * time.Second)) {
+		t.Errorf("время запуска %v, want %v", e.Time, start.Add(3*time.Second))
+	}
+	if rest := drain(cd); len(rest) != 0 {
+		t.Errorf("события после запуска: %v", rest)
+	}
+	if err := <-result; err != nil {
+		t.Errorf("Run = %v, want nil", err)
+	}
+	if err := cd.Run(context.Background()); !errors.Is(err, ErrAlreadyStarted) {
+		t.Errorf("повторный Run = %v, want ErrAlreadyStarted", err)
+	}
+	if err := cd.Hold("ветер"); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Hold после запуска = %v, want ErrNotRunning", err)
+	}
+}
+
+func TestHoldResume(t *testing.T) {
+	c := &manualClock{now: start}
+	cd, err := New(2, time.Second, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := startRun(context.Background(), cd)
+	next(t, cd, KindTick, 2)
+
+	// команды отправляются из другой горутины: Run ждет, пока событие прочитано
+	held := c.ticker
+	go func() {
+		cd.Hold("ветер")
+		cd.Hold("гроза") // повторная задержка ничего не меняет
+		cd.Resume()
+		cd.Resume() // отсчет не приостановлен
+	}()
+	if e := next(t, cd, KindHold, 2); e.Reason != "ветер" {
+		t.Errorf("причина задержки %q, want %q", e.Reason, "ветер")
+	}
+	if !held.isStopped {
+		t.Error("тикер не остановлен на время задержки")
+	}
+	next(t, cd, KindResume, 2)
+	go func() {
+		c.tick() // тикер, созданный при продолжении
+		c.tick()
+	}()
+	next(t, cd, KindTick, 1)
+	next(t, cd, KindLaunch, 0)
+	if rest := drain(cd); len(rest) != 0 {
+		t.Errorf("лишние события: %v", rest)
+	}
+	if err := <-result; err != nil {
+		t.Errorf("Run = %v, want nil", err)
+	}
+}
+
+func TestAbort(t *testing.T) {
+	c := &manualClock{now: start}
+	cd, err := New(5, time.Second, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := startRun(context.Background(), cd)
+	next(t, cd, KindTick, 5)
+	go c.tick()
+	next(t, cd, KindTick, 4)
+
+	go cd.Abort("утечка топлива")
+	if e := next(t, cd, KindAbort, 4); e.Reason != "утечка топлива" {
+		t.Errorf("причина отмены %q, want %q", e.Reason, "утечка топлива")
+	}
+	if rest := drain(cd); len(rest) != 0 {
+		t.Errorf("события после отмены: %v", rest)
+	}
+	if err := <-result; !errors.Is(err, ErrAborted) {
+		t.Errorf("Run = %v, want ErrAborted", err)
+	}
+	if err := cd.Resume(); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Resume после отмены = %v, want ErrNotRunning", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	errWind := errors.New("ветер")
+	var checked []int
+	c := clock.NewFake(start)
+	cd, err := New(5, time.Second, c,
+		func(remaining int) error {
+			checked = append(checked, remaining)
+			return nil
+		},
+		func(remaining int) error {
+			if remaining == 2 {
+				return errWind
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := startRun(context.Background(), cd)
+	events := drain(cd)
+	err = <-result
+	if !errors.Is(err, ErrAborted) || !errors.Is(err, errWind) {
+		t.Errorf("Run = %v, want ErrAborted и причину", err)
+	}
+	if want := []int{5, 4, 3, 2}; !slices.Equal(checked, want) {
+		t.Errorf("проверки на шагах %v, want %v", checked, want)
+	}
+	last := events[len(events)-1]
+	if last.Kind != KindAbort || last.Remaining != 2 || last.Reason != errWind.Error() {
+		t.Errorf("последнее событие %v, want отмена на T-2: ветер", last)
+	}
+	// время отмены - время срабатывания тикера, на котором провалилась проверка
+	if want := start.Add(4 * time.Second); !last.Time.Equal(want) {
+		t.Errorf("время отмены %v, want %v", last.Time, want)
+	}
+}
+
+func TestRandomAbort(t *testing.T) {
+	check := RandomAbort(rand.New(rand.NewSource(1)), 1)
+	for remaining := range 3 {
+		if err := check(remaining); !errors.Is(err, ErrContingency) {
+			t.Fatalf("RandomAbort с шансом 1 к 1 = %v, want ErrContingency", err)
+		}
+	}
+}
+
+// TestContextCancel проверяет, что после отмены ctx событие об отмене доставляется всегда
+// и остается последним, даже когда Run выбирает между отправкой и ctx.Done.
+func TestContextCancel(t *testing.T) {
+	for i := range 200 {
+		cd, err := New(1000, time.Second, clock.NewFake(start))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		result := startRun(ctx, cd)
+		var events []Event
+		for e := range cd.Events() {
+			events = append(events, e)
+			if len(events) == 3 {
+				cancel()
+			}
+		}
+		cancel()
+		err = <-result
+		if !errors.Is(err, ErrAborted) || !errors.Is(err, context.Canceled) {
+			t.Fatalf("запуск %d: Run = %v, want ErrAborted и context.Canceled", i, err)
+		}
+		if last := events[len(events)-1]; last.Kind != KindAbort {
+			t.Fatalf("запуск %d: последнее событие %v, want отмену", i, last)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+// Package datefmt - форматирование дат и времени на разных языках, обобщение f26 и f31.
+//
+// В f26 название дня недели выбирается оператором switch из семи веток, а в f31
+// половина суток определяется сравнением now.Hour() < 12. Здесь названия месяцев
+// и дней недели, падежи, части суток и шаблоны строк описаны таблицей языка
+// (файлом JSON), поэтому новый язык добавляется файлом, без изменения кода.
+//
+// Шаблон - текст с полями в фигурных скобках: "{day} {month:genitive} {year}".
+// Поля:
+//
+//	{year}                     год
+//	{month}, {month:падеж}     название месяца, по умолчанию в именительном падеже
+//	{mm}                       номер месяца, 01-12
+//	{day}, {dd}                день месяца: 1-31 и 01-31
+//	{weekday}, {weekday:падеж} название дня недели
+//	{hour}, {hour2}            час по 24-часовым часам: 0-23 и 00-23
+//	{hour12}                   час по 12-часовым часам, 1-12
+//	{minute}, {second}         минуты и секунды, 00-59
+//	{period}                   часть суток: AM/PM или "утра", "вечера"
+//
+// Падеж - любое имя из таблицы языка: для русского это genitive ("13 октября")
+// и on ("в понедельник", "во вторник").
+package datefmt
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnknownLocale возвращается для языка, таблицы которого нет.
+	ErrUnknownLocale = errors.New("неизвестный язык")
+	// ErrInvalidLocale возвращается для неполной или противоречивой таблицы языка.
+	ErrInvalidLocale = errors.New("неверная таблица языка")
+	// ErrInvalidPattern возвращается для шаблона с неизвестным полем или падежом.
+	ErrInvalidPattern = errors.New("неверный шаблон")
+)
+
+// HourClock - 12- или 24-часовые часы.
+type HourClock int
+
+const (
+	ClockDefault HourClock = 0  // часы, принятые в языке
+	Clock12      HourClock = 12 // 1:05 PM
+	Clock24      HourClock = 24 // 13:05
+)
+
+// Имена шаблонов, которые должны быть в таблице языка.
+const (
+	PatternDate      = "date"       // 13 октября 2026
+	PatternWeekday   = "weekday"    // понедельник
+	PatternOnWeekday = "on_weekday" // в понедельник
+	PatternTime12    = "time12"     // 1:05 дня
+	PatternTime24    = "time24"     // 13:05
+)
+
+// nominative - падеж названий по умолчанию; он должен быть в каждой таблице.
+const nominative = "nominative"
+
+// Locale - проверенная таблица языка. Создается GetLocale или LoadLocale.
+type Locale struct {
+	Name     string
+	plural   pluralRule
+	clock    HourClock
+	months   map[string][]string
+	weekdays map[string][]string
+	periods  []period
+	patterns map[string]string
+	relative relativeFile
+}
+
+// Файл языка - JSON, образцы лежат в locales/:
+//
+//	{
+//	  "name": "ru",
+//	  "plural": "slavic",
+//	  "clock": 24,
+//	  "months": {"nominative": [12 названий], "genitive": [12 названий]},
+//	  "weekdays": {"nominative": [7 названий с воскресенья], "on": [...]},
+//	  "periods": [{"from": 0, "name": "ночи"}, {"from": 4, "name": "утра"}, ...],
+//	  "patterns": {"date": "{day} {month:genitive} {year}", ...},
+//	  "relative": {
+//	    "now": "сейчас", "future": "через {n} {unit}", "past": "{n} {unit} назад",
+//	    "days": {"-1": "вчера", "1": "завтра"},
+//	    "units": {"day": ["день", "дня", "дней"], ...}
+//	  }
+//	}
+//
+// Часть суток начинается с часа from и длится до начала следующей.
+// Число форм единицы измерения задается правилом plural, см. GetPluralRules.
+
+// localeFile - файл языка.
+type localeFile struct {
+	Name     string              `json:"name"`
+	Plural   string              `json:"plural"`
+	Clock    HourClock           `json:"clock"`
+	Months   map[string][]string `json:"months"`
+	Weekdays map[string][]string `json:"weekdays"`
+	Periods  []period            `json:"periods"`
+	Patterns map[string]string   `json:"patterns"`
+	Relative relativeFile        `json:"relative"`
+}
+
+// period - часть суток, начиная с часа From.
+type period struct {
+	From int    `json:"from"`
+	Name string `json:"name"`
+}
+
+// locales - встроенные таблицы языков.
+//
+//go:embed locales/*.json
+var locales embed.FS
+
+// GetLocales возвращает имена встроенных языков по алфавиту.
+func GetLocales() []string {
+	entries, err := fs.ReadDir(locales, "locales")
+	if err != nil {
+		panic(err) // каталог встроен в программу
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), path.Ext(e.Name())))
+	}
+	return names
+}
+
+// GetLocale возвращает встроенную таблицу языка name: "ru" или "en".
+func GetLocale(name string) (*Locale, error) {
+	data, err := locales.ReadFile("locales/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q, есть %s", ErrUnknownLocale, name, strings.Join(GetLocales(), ", "))
+	}
+	return LoadLocale(bytes.NewReader(data))
+}
+
+// LoadLocale читает и проверяет таблицу языка из r.
+func LoadLocale(r io.Reader) (*Locale, error) {
+	var file localeFile
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("чтение таблицы языка: %w", err)
+	}
+	l, err := file.build()
+	if err != nil {
+		return nil, fmt.Errorf("язык %q: %w", file.Name, err)
+	}
+	return l, nil
+}
+
+// LoadLocaleFile читает таблицу языка из файла path.
+func LoadLocaleFile(path string) (*Locale, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadLocale(file)
+}
+
+// build проверяет файл языка и создает по нему Locale.
+// Все шаблоны проверяются сразу, поэтому форматирование проверенным языком не возвращает ошибок.
+func (f localeFile) build() (*Locale, error) {
+	if f.Name == "" {
+		return nil, fmt.Errorf("%w: нет имени", ErrInvalidLocale)
+	}
+	rule, ok := pluralRules[f.Plural]
+	if !ok {
+		return nil, fmt.Errorf("%w: правило множественного числа %q, есть %s",
+			ErrInvalidLocale, f.Plural, strings.Join(GetPluralRules(), ", "))
+	}
+	if f.Clock != Clock12 && f.Clock != Clock24 {
+		return nil, fmt.Errorf("%w: часы %d, ожидалось 12 или 24", ErrInvalidLocale, f.Clock)
+	}
+	if err := checkForms("months", f.Months, 12); err != nil {
+		return nil, err
+	}
+	if err := checkForms("weekdays", f.Weekdays, 7); err != nil {
+		return nil, err
+	}
+	if len(f.Periods) == 0 || f.Periods[0].From != 0 {
+		return nil, fmt.Errorf("%w: periods должны начинаться с часа 0", ErrInvalidLocale)
+	}
+	for i := 1; i < len(f.Periods); i++ {
+		if f.Periods[i].From <= f.Periods[i-1].From || f.Periods[i].From > 23 {
+			return nil, fmt.Errorf("%w: periods[%d]: час %d, часы должны возрастать до 23",
+				ErrInvalidLocale, i, f.Periods[i].From)
+		}
+	}
+
+	l := &Locale{
+		Name:     f.Name,
+		plural:   rule,
+		clock:    f.Clock,
+		months:   f.Months,
+		weekdays: f.Weekdays,
+		periods:  f.Periods,
+		patterns: f.Patterns,
+		relative: f.Relative,
+	}
+	for _, name := range []string{PatternDate, PatternWeekday, PatternOnWeekday, PatternTime12, PatternTime24} {
+		pattern, ok := f.Patterns[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: нет шаблона %q", ErrInvalidLocale, name)
+		}
+		if _, err := l.Format(time.Time{}, pattern); err != nil {
+			return nil, fmt.Errorf("шаблон %q: %w", name, err)
+		}
+	}
+	if err := f.Relative.validate(rule); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// checkForms проверяет, что у названий есть именительный падеж и в каждом падеже n форм.
+func checkForms(field string, forms map[string][]string, n int) error {
+	if _, ok := forms[nominative]; !ok {
+		return fmt.Errorf("%w: %s: нет падежа %s", ErrInvalidLocale, field, nominative)
+	}
+	for form, names := range forms {
+		if len(names) != n {
+			return fmt.Errorf("%w: %s.%s: названий %d, ожидалось %d", ErrInvalidLocale, field, form, len(names), n)
+		}
+	}
+	return nil
+}
+
+// GetCases возвращает падежи названий месяцев и дней недели, которые есть в таблице.
+func (l *Locale) GetCases() (months, weekdays []string) {
+	return slices.Sorted(maps.Keys(l.months)), slices.Sorted(maps.Keys(l.weekdays))
+}
+
+// GetMonths возвращает названия месяцев с января в падеже form; пустой form - именительный падеж.
+// Второе значение false, если такого падежа в таблице нет.
+func (l *Locale) GetMonths(form string) ([]string, bool) {
+	return getForms(l.months, form)
+}
+
+// GetWeekdays возвращает названия дней недели с воскресенья, как time.Weekday, в падеже form.
+func (l *Locale) GetWeekdays(form string) ([]string, bool) {
+	return getForms(l.weekdays, form)
+}
+
+// getForms возвращает копию названий в падеже form, чтобы вызывающий не изменил таблицу.
+func getForms(forms map[string][]string, form string) ([]string, bool) {
+	if form == "" {
+		form = nominative
+	}
+	names, ok := forms[form]
+	return slices.Clone(names), ok
+}
+
+// GetClock возвращает часы, принятые в языке.
+func (l *Locale) GetClock() HourClock { return l.clock }
+
+// GetPeriod возвращает часть суток часа hour (0-23), как AM/PM в f31.
+func (l *Locale) GetPeriod(hour int) string {
+	name := l.periods[0].Name
+	for _, p := range l.periods {
+		if hour >= p.From {
+			name = p.Name
+		}
+	}
+	return name
+}
+
+// FormatDate возвращает дату: "13 октября 2026" или "October 13, 2026".
+func (l *Locale) FormatDate(t time.Time) string { return l.apply(t, PatternDate) }
+
+// FormatWeekday возвращает день недели, как f26: "понедельник".
+func (l *Locale) FormatWeekday(t time.Time) string { return l.apply(t, PatternWeekday) }
+
+// FormatOnWeekday возвращает день недели как обстоятельство времени: "в понедельник", "on Monday".
+func (l *Locale) FormatOnWeekday(t time.Time) string { return l.apply(t, PatternOnWeekday) }
+
+// FormatTime возвращает время по часам c; ClockDefault - по часам, принятым в языке.
+func (l *Locale) FormatTime(t time.Time, c HourClock) string {
+	if c == ClockDefault {
+		c = l.clock
+	}
+	if c == Clock12 {
+		return l.apply(t, PatternTime12)
+	}
+	return l.apply(t, PatternTime24)
+}
+
+// apply форматирует t шаблоном name из таблицы. Шаблоны проверены в build, поэтому ошибки нет.
+func (l *Locale) apply(t time.Time, name string) string {
+	s, _ := l.Format(t, l.patterns[name])
+	return s
+}
+
+// Format форматирует t по шаблону pattern с полями из описания пакета.
+func (l *Locale) Format(t time.Time, pattern string) (string, error) {
+	var b strings.Builder
+	rest := pattern
+	for {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			b.WriteString(rest)
+			return b.String(), nil
+		}
+		length := strings.IndexByte(rest[open:], '}')
+		if length < 0 {
+			return "", fmt.Errorf("%w: %q, нет закрывающей скобки", ErrInvalidPattern, pattern)
+		}
+		value, err := l.formatField(t, rest[open+1:open+length])
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(rest[:open])
+		b.WriteString(value)
+		rest = rest[open+length+1:]
+	}
+}
+
+// formatField возвращает значение поля шаблона field, например "month:genitive".
+func (l *Locale) formatField(t time.Time, field string) (string, error) {
+	name, form, hasForm := strings.Cut(field, ":")
+	switch name {
+	case "month":
+		return pickForm(l.months, form, int(t.Month())-1, field)
+	case "weekday":
+		return pickForm(l.weekdays, form, int(t.Weekday()), field)
+	}
+	if hasForm {
+		return "", fmt.Errorf("%w: {%s}, падеж бывает только у month и weekday", ErrInvalidPattern, field)
+	}
+
+	switch name {
+	case "year":
+		return strconv.Itoa(t.Year()), nil
+	case "mm":
+		return fmt.Sprintf("%02d", t.Month()), nil
+	case "day":
+		return strconv.Itoa(t.Day()), nil
+	case "dd":
+		return fmt.Sprintf("%02d", t.Day()), nil
+	case "hour":
+		return strconv.Itoa(t.Hour()), nil
+	case "hour2":
+		return fmt.Sprintf("%02d", t.Hour()), nil
+	case "hour12":
+		return strconv.Itoa((t.Hour()+11)%12 + 1), nil
+	case "minute":
+		return fmt.Sprintf("%02d", t.Minute()), nil
+	case "second":
+		return fmt.Sprintf("%02d", t.Second()), nil
+	case "period":
+		return l.GetPeriod(t.Hour()), nil
+	}
+	return "", fmt.Errorf("%w: неизвестное поле {%s}", ErrInvalidPattern, field)
+}
+
+// pickForm возвращает i-е название в падеже form (по умолчанию - в именительном).
+func pickForm(forms map[string][]string, form string, i int, field string) (string, error) {
+	if form == "" {
+		form = nominative
+	}
+	names, ok := forms[form]
+	if !ok {
+		return "", fmt.Errorf("%w: {%s}, нет падежа %q", ErrInvalidPattern, field, form)
+	}
+	return names[i], nil
+}
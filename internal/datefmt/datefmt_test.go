@@ -0,0 +1,196 @@
+package datefmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mustGetLocale возвращает встроенную таблицу языка name.
+func mustGetLocale(t *testing.T, name string) *Locale {
+	t.Helper()
+	l, err := GetLocale(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestFormat(t *testing.T) {
+	ru, en := mustGetLocale(t, "ru"), mustGetLocale(t, "en")
+	tuesday := time.Date(2026, time.October, 13, 13, 5, 9, 0, time.UTC)
+	tests := []struct {
+		l       *Locale
+		pattern string
+		want    string
+	}{
+		{ru, "{day} {month:genitive} {year}", "13 октября 2026"},
+		{ru, "{month}", "октябрь"},
+		{ru, "{weekday:on}", "во вторник"},
+		{ru, "{weekday}", "вторник"},
+		{ru, "{dd}.{mm}.{year} {hour2}:{minute}:{second}", "13.10.2026 13:05:09"},
+		{en, "{weekday}, {month} {day}", "Tuesday, October 13"},
+		{en, "без полей", "без полей"},
+	}
+	for _, tt := range tests {
+		got, err := tt.l.Format(tuesday, tt.pattern)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: Format(%q) = %q, %v, want %q", tt.l.Name, tt.pattern, got, err, tt.want)
+		}
+	}
+
+	for _, pattern := range []string{"{month:dative}", "{year:genitive}", "{decade}", "{day"} {
+		if got, err := ru.Format(tuesday, pattern); !errors.Is(err, ErrInvalidPattern) {
+			t.Errorf("Format(%q) = %q, %v, want ErrInvalidPattern", pattern, got, err)
+		}
+	}
+}
+
+func TestFormatOnWeekday(t *testing.T) {
+	ru, en := mustGetLocale(t, "ru"), mustGetLocale(t, "en")
+	want := []string{"в воскресенье", "в понедельник", "во вторник", "в среду", "в четверг", "в пятницу", "в субботу"}
+	sunday := time.Date(2026, time.October, 11, 12, 0, 0, 0, time.UTC)
+	for i, w := range want {
+		d := sunday.AddDate(0, 0, i)
+		if got := ru.FormatOnWeekday(d); got != w {
+			t.Errorf("ru: FormatOnWeekday(%v) = %q, want %q", d.Weekday(), got, w)
+		}
+	}
+	if got := en.FormatOnWeekday(sunday.AddDate(0, 0, 2)); got != "on Tuesday" {
+		t.Errorf("en: FormatOnWeekday = %q, want %q", got, "on Tuesday")
+	}
+	if got := ru.FormatDate(time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)); got != "8 марта 2026" {
+		t.Errorf("ru: FormatDate = %q, want %q", got, "8 марта 2026")
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	ru, en := mustGetLocale(t, "ru"), mustGetLocale(t, "en")
+	tests := []struct {
+		l     *Locale
+		hour  int
+		clock HourClock
+		want  string
+	}{
+		{ru, 0, Clock12, "12:05 ночи"},
+		{ru, 3, Clock12, "3:05 ночи"},
+		{ru, 4, Clock12, "4:05 утра"},
+		{ru, 12, Clock12, "12:05 дня"},
+		{ru, 17, Clock12, "5:05 вечера"},
+		{ru, 23, Clock12, "11:05 вечера"},
+		{ru, 13, ClockDefault, "13:05"},
+		{ru, 0, Clock24, "0:05"},
+		{en, 0, ClockDefault, "12:05 AM"},
+		{en, 11, Clock12, "11:05 AM"},
+		{en, 12, Clock12, "12:05 PM"},
+		{en, 13, Clock12, "1:05 PM"},
+		{en, 9, Clock24, "09:05"},
+	}
+	for _, tt := range tests {
+		at := time.Date(2026, time.October, 13, tt.hour, 5, 0, 0, time.UTC)
+		if got := tt.l.FormatTime(at, tt.clock); got != tt.want {
+			t.Errorf("%s: FormatTime(%d:05, %d) = %q, want %q", tt.l.Name, tt.hour, tt.clock, got, tt.want)
+		}
+	}
+	if ru.GetClock() != Clock24 || en.GetClock() != Clock12 {
+		t.Errorf("GetClock: ru %d, en %d", ru.GetClock(), en.GetClock())
+	}
+}
+
+func TestGetLocale(t *testing.T) {
+	if _, err := GetLocale("fr"); !errors.Is(err, ErrUnknownLocale) {
+		t.Errorf("GetLocale(fr): err = %v, want ErrUnknownLocale", err)
+	}
+	ru := mustGetLocale(t, "ru")
+	months, ok := ru.GetMonths("genitive")
+	if !ok || months[0] != "января" {
+		t.Errorf("GetMonths(genitive) = %q, %t", months, ok)
+	}
+	months[0] = "изменено"
+	if again, _ := ru.GetMonths("genitive"); again[0] != "января" {
+		t.Error("GetMonths отдает таблицу языка, а не копию")
+	}
+	if _, ok := ru.GetWeekdays("dative"); ok {
+		t.Error("GetWeekdays(dative) нашел несуществующий падеж")
+	}
+	monthCases, weekdayCases := ru.GetCases()
+	if strings.Join(monthCases, ",") != "genitive,nominative" || strings.Join(weekdayCases, ",") != "nominative,on" {
+		t.Errorf("GetCases = %v, %v", monthCases, weekdayCases)
+	}
+}
+
+// TestLoadLocale портит по одному полю встроенной таблицы ru и проверяет ошибку.
+func TestLoadLocale(t *testing.T) {
+	data, err := locales.ReadFile("locales/ru.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		change  func(f map[string]any)
+		wantErr error // nil - ошибка разбора JSON
+	}{
+		{"нет имени", func(f map[string]any) { f["name"] = "" }, ErrInvalidLocale},
+		{"правило", func(f map[string]any) { f["plural"] = "arabic" }, ErrInvalidLocale},
+		{"часы", func(f map[string]any) { f["clock"] = 10 }, ErrInvalidLocale},
+		{"нет именительного падежа", func(f map[string]any) {
+			delete(f["months"].(map[string]any), "nominative")
+		}, ErrInvalidLocale},
+		{"11 месяцев", func(f map[string]any) {
+			months := f["months"].(map[string]any)
+			months["genitive"] = months["genitive"].([]any)[:11]
+		}, ErrInvalidLocale},
+		{"8 дней недели", func(f map[string]any) {
+			weekdays := f["weekdays"].(map[string]any)
+			weekdays["on"] = append(weekdays["on"].([]any), "в восьмой день")
+		}, ErrInvalidLocale},
+		{"части суток не с 0", func(f map[string]any) { f["periods"] = []any{map[string]any{"from": 1, "name": "x"}} }, ErrInvalidLocale},
+		{"части суток не по порядку", func(f map[string]any) {
+			f["periods"] = []any{map[string]any{"from": 0, "name": "x"}, map[string]any{"from": 12, "name": "y"}, map[string]any{"from": 6, "name": "z"}}
+		}, ErrInvalidLocale},
+		{"час 24", func(f map[string]any) {
+			f["periods"] = []any{map[string]any{"from": 0, "name": "x"}, map[string]any{"from": 24, "name": "y"}}
+		}, ErrInvalidLocale},
+		{"нет шаблона", func(f map[string]any) { delete(f["patterns"].(map[string]any), "time12") }, ErrInvalidLocale},
+		{"неизвестный падеж в шаблоне", func(f map[string]any) {
+			f["patterns"].(map[string]any)["date"] = "{day} {month:dative}"
+		}, ErrInvalidPattern},
+		{"нет now", func(f map[string]any) { f["relative"].(map[string]any)["now"] = "" }, ErrInvalidLocale},
+		{"future без {n}", func(f map[string]any) { f["relative"].(map[string]any)["future"] = "скоро" }, ErrInvalidLocale},
+		{"лишнее поле в past", func(f map[string]any) { f["relative"].(map[string]any)["past"] = "{n} {unit} {ago}" }, ErrInvalidPattern},
+		{"ключ дня не число", func(f map[string]any) {
+			f["relative"].(map[string]any)["days"] = map[string]any{"tomorrow": "завтра"}
+		}, ErrInvalidLocale},
+		{"нет единицы", func(f map[string]any) {
+			delete(f["relative"].(map[string]any)["units"].(map[string]any), "week")
+		}, ErrInvalidLocale},
+		{"две формы для slavic", func(f map[string]any) {
+			f["relative"].(map[string]any)["units"].(map[string]any)["day"] = []any{"день", "дней"}
+		}, ErrInvalidLocale},
+		{"неизвестная единица", func(f map[string]any) {
+			f["relative"].(map[string]any)["units"].(map[string]any)["century"] = []any{"век", "века", "веков"}
+		}, ErrInvalidLocale},
+		{"неизвестное поле", func(f map[string]any) { f["zone"] = "MSK" }, nil},
+	}
+	for _, tt := range tests {
+		var f map[string]any
+		if err := json.Unmarshal(data, &f); err != nil {
+			t.Fatal(err)
+		}
+		tt.change(f)
+		changed, err := json.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = LoadLocale(bytes.NewReader(changed))
+		if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+	if _, err := LoadLocale(bytes.NewReader(data)); err != nil {
+		t.Errorf("встроенная таблица ru: %v", err)
+	}
+}
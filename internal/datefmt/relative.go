@@ -0,0 +1,177 @@
+package datefmt
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Единицы относительного времени от меньшей к большей; в таблице языка должны быть все.
+var units = []string{"second", "minute", "hour", "day", "week", "month", "year"}
+
+// relativeFile - шаблоны относительного времени в файле языка.
+type relativeFile struct {
+	Now    string              `json:"now"`    // меньше секунды
+	Future string              `json:"future"` // через {n} {unit}
+	Past   string              `json:"past"`   // {n} {unit} назад
+	Days   map[string]string   `json:"days"`   // особые названия дней: "-1" - вчера, "1" - завтра
+	Units  map[string][]string `json:"units"`  // формы единиц для правила множественного числа
+}
+
+// validate проверяет шаблоны и что у каждой единицы столько форм, сколько требует правило rule.
+func (r relativeFile) validate(rule pluralRule) error {
+	if r.Now == "" {
+		return fmt.Errorf("%w: relative: нет now", ErrInvalidLocale)
+	}
+	for name, pattern := range map[string]string{"future": r.Future, "past": r.Past} {
+		if !strings.Contains(pattern, "{n}") {
+			return fmt.Errorf("%w: relative.%s: %q без {n}", ErrInvalidLocale, name, pattern)
+		}
+		if rest := relativeFields("", "").Replace(pattern); strings.ContainsAny(rest, "{}") {
+			return fmt.Errorf("%w: relative.%s: %q, допустимы только {n} и {unit}", ErrInvalidPattern, name, pattern)
+		}
+	}
+	for key := range r.Days {
+		if _, err := strconv.Atoi(key); err != nil {
+			return fmt.Errorf("%w: relative.days: ключ %q должен быть числом дней", ErrInvalidLocale, key)
+		}
+	}
+	for _, unit := range units {
+		forms, ok := r.Units[unit]
+		if !ok {
+			return fmt.Errorf("%w: relative.units: нет единицы %q", ErrInvalidLocale, unit)
+		}
+		if len(forms) != rule.forms {
+			return fmt.Errorf("%w: relative.units.%s: форм %d, правилу %s нужно %d",
+				ErrInvalidLocale, unit, len(forms), rule.name, rule.forms)
+		}
+	}
+	for unit := range r.Units {
+		if !slices.Contains(units, unit) {
+			return fmt.Errorf("%w: relative.units: неизвестная единица %q", ErrInvalidLocale, unit)
+		}
+	}
+	return nil
+}
+
+// relativeFields заменяет поля шаблона относительного времени.
+func relativeFields(n, unit string) *strings.Replacer {
+	return strings.NewReplacer("{n}", n, "{unit}", unit)
+}
+
+// FormatRelative описывает t относительно now: "через 3 дня", "2 hours ago", "завтра".
+// Число единиц округляется вниз: через 13 дней - "через 1 неделю". Месяц считается
+// равным 30 дням, а год - 365 дням. Дни, начиная с суток, считаются по календарю
+// в часовом поясе now: от 23:00 до 01:00 послезавтра всего 26 часов, но это "послезавтра".
+func (l *Locale) FormatRelative(t, now time.Time) string {
+	d := t.Sub(now)
+	pattern := l.relative.Future
+	if d < 0 {
+		d, pattern = -d, l.relative.Past
+	}
+
+	const day = 24 * time.Hour
+	var unit string
+	var n int
+	switch {
+	case d < time.Second:
+		return l.relative.Now
+	case d < time.Minute:
+		unit, n = "second", int(d/time.Second)
+	case d < time.Hour:
+		unit, n = "minute", int(d/time.Minute)
+	case d < day || calendarDays(now, t) == 0: // сутки перехода на зимнее время длятся 25 часов
+		unit, n = "hour", int(d/time.Hour)
+	case d < 7*day:
+		unit, n = "day", calendarDays(now, t)
+	case d < 30*day:
+		unit, n = "week", int(d/(7*day))
+	case d < 365*day:
+		unit, n = "month", int(d/(30*day))
+	default:
+		unit, n = "year", int(d/(365*day))
+	}
+
+	if unit == "day" {
+		signed := n
+		if t.Before(now) {
+			signed = -n
+		}
+		if name, ok := l.relative.Days[strconv.Itoa(signed)]; ok {
+			return name
+		}
+	}
+	return relativeFields(strconv.Itoa(n), l.Plural(n, l.relative.Units[unit])).Replace(pattern)
+}
+
+// calendarDays возвращает число смен даты между now и t в часовом поясе now, без знака.
+func calendarDays(now, t time.Time) int {
+	date := func(t time.Time) time.Time {
+		y, m, d := t.In(now.Location()).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	days := int(date(t).Sub(date(now)) / (24 * time.Hour))
+	if days < 0 {
+		return -days
+	}
+	return days
+}
+
+// Plural выбирает из forms форму слова для числа n по правилу языка:
+// для русского forms - ["день", "дня", "дней"], для английского - ["day", "days"].
+func (l *Locale) Plural(n int, forms []string) string {
+	if n < 0 {
+		n = -n
+	}
+	i := l.plural.choose(n)
+	if i >= len(forms) {
+		i = len(forms) - 1
+	}
+	return forms[i]
+}
+
+// pluralRule - правило выбора формы слова по числу.
+type pluralRule struct {
+	name   string
+	forms  int             // сколько форм у слова
+	choose func(n int) int // номер формы для n >= 0
+}
+
+// pluralRules - известные правила. Правило - единственная часть языка, описанная кодом:
+// языков много, а правил мало, и новый язык обычно пользуется одним из них.
+var pluralRules = map[string]pluralRule{
+	// один день, два дня, пять дней, двадцать один день
+	"slavic": {name: "slavic", forms: 3, choose: func(n int) int {
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return 0
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return 1
+		}
+		return 2
+	}},
+	// one day, two days, zero days
+	"english": {name: "english", forms: 2, choose: func(n int) int {
+		if n == 1 {
+			return 0
+		}
+		return 1
+	}},
+	// zero jour, un jour, deux jours
+	"french": {name: "french", forms: 2, choose: func(n int) int {
+		if n <= 1 {
+			return 0
+		}
+		return 1
+	}},
+	// слово не меняется: китайский, японский, турецкий после числительных
+	"none": {name: "none", forms: 1, choose: func(int) int { return 0 }},
+}
+
+// GetPluralRules возвращает имена известных правил множественного числа по алфавиту.
+func GetPluralRules() []string {
+	return slices.Sorted(maps.Keys(pluralRules))
+}
@@ -0,0 +1,109 @@
+package datefmt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlural(t *testing.T) {
+	ru, en := mustGetLocale(t, "ru"), mustGetLocale(t, "en")
+	days := []string{"день", "дня", "дней"}
+	tests := []struct {
+		n      int
+		ru, en string
+	}{
+		{0, "дней", "days"},
+		{1, "день", "day"},
+		{2, "дня", "days"},
+		{4, "дня", "days"},
+		{5, "дней", "days"},
+		{11, "дней", "days"},
+		{12, "дней", "days"},
+		{14, "дней", "days"},
+		{21, "день", "days"},
+		{22, "дня", "days"},
+		{111, "дней", "days"},
+		{101, "день", "days"},
+		{-1, "день", "day"},
+	}
+	for _, tt := range tests {
+		if got := ru.Plural(tt.n, days); got != tt.ru {
+			t.Errorf("ru: Plural(%d) = %q, want %q", tt.n, got, tt.ru)
+		}
+		if got := en.Plural(tt.n, []string{"day", "days"}); got != tt.en {
+			t.Errorf("en: Plural(%d) = %q, want %q", tt.n, got, tt.en)
+		}
+	}
+}
+
+func TestFormatRelative(t *testing.T) {
+	ru, en := mustGetLocale(t, "ru"), mustGetLocale(t, "en")
+	now := time.Date(2026, time.October, 10, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		l    *Locale
+		t    time.Time
+		want string
+	}{
+		{ru, now, "сейчас"},
+		{ru, now.Add(time.Second), "через 1 секунду"},
+		{ru, now.Add(2 * time.Minute), "через 2 минуты"},
+		{ru, now.Add(-5 * time.Hour), "5 часов назад"},
+		{ru, now.Add(11 * time.Hour), "через 11 часов"},
+		{ru, now.Add(21 * time.Hour), "через 21 час"},
+		{ru, now.Add(24 * time.Hour), "завтра"},
+		{ru, now.Add(-24 * time.Hour), "вчера"},
+		{ru, now.Add(48 * time.Hour), "послезавтра"},
+		{ru, now.Add(-48 * time.Hour), "позавчера"},
+		{ru, now.Add(3 * 24 * time.Hour), "через 3 дня"},
+		{ru, now.Add(-5 * 24 * time.Hour), "5 дней назад"},
+		{ru, now.AddDate(0, 0, 14), "через 2 недели"},
+		{ru, now.AddDate(0, 0, 13), "через 1 неделю"},
+		{ru, now.AddDate(0, 0, -21), "3 недели назад"},
+		{ru, now.AddDate(0, 0, 150), "через 5 месяцев"},
+		{ru, now.AddDate(-11, 0, 0), "11 лет назад"},
+		{ru, now.AddDate(21, 0, 0), "через 21 год"},
+		{en, now.Add(24 * time.Hour), "tomorrow"},
+		{en, now.Add(48 * time.Hour), "in 2 days"},
+		{en, now.Add(-time.Hour), "1 hour ago"},
+	}
+	for _, tt := range tests {
+		if got := tt.l.FormatRelative(tt.t, now); got != tt.want {
+			t.Errorf("%s: FormatRelative(%v) = %q, want %q", tt.l.Name, tt.t.Sub(now), got, tt.want)
+		}
+	}
+}
+
+// TestFormatRelativeCalendarDays проверяет, что дни считаются по календарю в часовом поясе now,
+// а не делением прошедшего времени на 24 часа.
+func TestFormatRelativeCalendarDays(t *testing.T) {
+	ru := mustGetLocale(t, "ru")
+	moscow := time.FixedZone("MSK", 3*60*60)
+	tests := []struct {
+		now, t time.Time
+		want   string
+	}{
+		// 26 часов, но через две смены даты
+		{time.Date(2026, 10, 10, 23, 0, 0, 0, moscow), time.Date(2026, 10, 12, 1, 0, 0, 0, moscow), "послезавтра"},
+		// 47 часов, но это завтра
+		{time.Date(2026, 10, 10, 0, 30, 0, 0, moscow), time.Date(2026, 10, 11, 23, 30, 0, 0, moscow), "завтра"},
+		{time.Date(2026, 10, 12, 1, 0, 0, 0, moscow), time.Date(2026, 10, 10, 23, 0, 0, 0, moscow), "позавчера"},
+		{time.Date(2026, 10, 10, 0, 30, 0, 0, moscow), time.Date(2026, 10, 14, 23, 0, 0, 0, moscow), "через 4 дня"},
+		// t в другом поясе: в Москве это уже 11 октября, хотя в UTC еще 10-е
+		{time.Date(2026, 10, 9, 12, 0, 0, 0, moscow), time.Date(2026, 10, 10, 22, 0, 0, 0, time.UTC), "послезавтра"},
+	}
+	for _, tt := range tests {
+		if got := ru.FormatRelative(tt.t, tt.now); got != tt.want {
+			t.Errorf("FormatRelative(%v, now %v) = %q, want %q", tt.t, tt.now, got, tt.want)
+		}
+	}
+
+	// в сутках перехода на зимнее время 25 часов: через 24 часа дата та же, и это не "завтра"
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	now := time.Date(2026, 10, 25, 0, 0, 0, 0, berlin)
+	if got := ru.FormatRelative(now.Add(24*time.Hour), now); got != "через 24 часа" {
+		t.Errorf("сутки с переводом часов: %q, want %q", got, "через 24 часа")
+	}
+}
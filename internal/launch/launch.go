@@ -0,0 +1,286 @@
+// Package launch - предстартовая подготовка поверх отсчета из f25.
+//
+// В f25 запуск отменяет один бросок кубика. Здесь готовность проверяют несколько подсистем:
+// каждая работает в своей горутине со своим генератором случайных чисел и на каждом шаге
+// отсчета сообщает GO или NO-GO в общий канал. Руководитель запуска рассылает запросы
+// всем подсистемам сразу и собирает ответы (fan-out/fan-in), а запуск состоится,
+// только если на T-0 все подсистемы готовы.
+package launch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"example/internal/clock"
+	"example/internal/countdown"
+)
+
+var (
+	// ErrNoGo - причина отмены, если на T-0 не все подсистемы готовы.
+	ErrNoGo = errors.New("нет готовности")
+	// ErrNoSubsystems возвращается для подготовки без подсистем.
+	ErrNoSubsystems = errors.New("нет подсистем")
+	// ErrInvalidChance возвращается для вероятности вне отрезка [0, 1].
+	ErrInvalidChance = errors.New("вероятность должна быть от 0 до 1")
+	// ErrDuplicateSubsystem возвращается, если две подсистемы называются одинаково:
+	// ответы подсистем сопоставляются по имени.
+	ErrDuplicateSubsystem = errors.New("повторяется имя подсистемы")
+)
+
+// Subsystem - подсистема, которая перед запуском сообщает о готовности.
+// Готовая подсистема на каждой проверке становится неготовой с вероятностью FailChance,
+// а неготовая восстанавливается с вероятностью RecoverChance.
+type Subsystem struct {
+	Name          string
+	FailChance    float64
+	RecoverChance float64
+	Reason        string // что случилось, когда подсистема не готова
+}
+
+// DefaultSubsystems возвращает подсистемы по умолчанию: топливо, погода, наведение и безопасность полигона.
+func DefaultSubsystems() []Subsystem {
+	return []Subsystem{
+		{Name: "топливо", FailChance: 0.02, RecoverChance: 0.5, Reason: "давление в баке ниже нормы"},
+		{Name: "погода", FailChance: 0.05, RecoverChance: 0.3, Reason: "сильный боковой ветер"},
+		{Name: "наведение", FailChance: 0.01, RecoverChance: 0.5, Reason: "сбой инерциальной системы"},
+		{Name: "полигон", FailChance: 0.01, RecoverChance: 0.2, Reason: "судно в запретной зоне"},
+	}
+}
+
+// Validate проверяет, что вероятности подсистемы лежат в [0, 1].
+func (s Subsystem) Validate() error {
+	for _, p := range []float64{s.FailChance, s.RecoverChance} {
+		if p < 0 || p > 1 {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidChance, s.Name, p)
+		}
+	}
+	return nil
+}
+
+// monitor - горутина подсистемы: на каждый запрос с шагом отсчета отвечает отчетом в reports.
+// Завершается, когда канал requests закрыт.
+func (s Subsystem) monitor(r *rand.Rand, requests <-chan int, reports chan<- Report) {
+	isGo := true
+	for remaining := range requests {
+		if isGo {
+			isGo = r.Float64() >= s.FailChance
+		} else {
+			isGo = r.Float64() < s.RecoverChance
+		}
+		report := Report{Subsystem: s.Name, Remaining: remaining, IsGo: isGo}
+		if !isGo {
+			report.Reason = s.Reason
+		}
+		reports <- report
+	}
+}
+
+// Report - ответ подсистемы на одной проверке.
+type Report struct {
+	Subsystem string
+	Remaining int // шаг отсчета, к которому относится проверка: 0 - решение о запуске
+	IsGo      bool
+	Reason    string
+}
+
+// Poll - одна проверка всех подсистем.
+type Poll struct {
+	Remaining int
+	Time      time.Time
+	Reports   []Report // в порядке подсистем
+}
+
+// IsGo проверяет, что все подсистемы готовы.
+func (p Poll) IsGo() bool {
+	for _, r := range p.Reports {
+		if !r.IsGo {
+			return false
+		}
+	}
+	return true
+}
+
+// Timeline - ход подготовки: события отсчета и проверки подсистем.
+type Timeline struct {
+	Subsystems []string
+	Events     []countdown.Event
+	Polls      []Poll
+}
+
+// IsLaunched проверяет, что отсчет дошел до запуска.
+func (t *Timeline) IsLaunched() bool {
+	return len(t.Events) > 0 && t.Events[len(t.Events)-1].Kind == countdown.KindLaunch
+}
+
+// Config - параметры подготовки.
+type Config struct {
+	From       int           // с какого числа начинать отсчет
+	Interval   time.Duration // шаг отсчета
+	Subsystems []Subsystem
+	Seed       int64 // зерно генераторов; подсистема i получает Seed+i
+}
+
+// Validate проверяет, что подсистемы есть, их вероятности допустимы, а имена не повторяются.
+func (cfg Config) Validate() error {
+	if len(cfg.Subsystems) == 0 {
+		return ErrNoSubsystems
+	}
+	names := map[string]bool{}
+	for _, s := range cfg.Subsystems {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+		if names[s.Name] {
+			return fmt.Errorf("%w: %q", ErrDuplicateSubsystem, s.Name)
+		}
+		names[s.Name] = true
+	}
+	return nil
+}
+
+// director - руководитель запуска: опрашивает подсистемы на каждом шаге отсчета.
+// Проверки выполняются в горутине отсчета, поэтому результаты уходят в канал polls,
+// а Timeline заполняет только горутина Simulate.
+type director struct {
+	requests []chan int  // запросы каждой подсистеме
+	reports  chan Report // общий канал ответов
+	polls    chan Poll   // результаты проверок для Simulate
+	index    map[string]int
+}
+
+// Simulate проводит подготовку к запуску по часам c и возвращает ее ход.
+// Ошибка с countdown.ErrAborted означает, что запуск отменен: на T-0 не все подсистемы готовы
+// (ошибка оборачивает ErrNoGo) или отменен ctx. Ход подготовки возвращается и в этом случае.
+func Simulate(ctx context.Context, c clock.Clock, cfg Config) (*Timeline, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	d := &director{
+		reports: make(chan Report, len(cfg.Subsystems)),
+		polls:   make(chan Poll),
+		index:   map[string]int{},
+	}
+	timeline := &Timeline{}
+	for i, s := range cfg.Subsystems {
+		d.index[s.Name] = i
+		timeline.Subsystems = append(timeline.Subsystems, s.Name)
+	}
+
+	cd, err := countdown.New(cfg.From, cfg.Interval, c, d.poll)
+	if err != nil {
+		return nil, err
+	}
+
+	// у каждой подсистемы свой генератор, поэтому ответы не зависят от порядка выполнения горутин
+	var wg sync.WaitGroup
+	for i, s := range cfg.Subsystems {
+		requests := make(chan int)
+		d.requests = append(d.requests, requests)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.monitor(rand.New(rand.NewSource(cfg.Seed+int64(i))), requests, d.reports)
+		}()
+	}
+	defer func() {
+		for _, requests := range d.requests {
+			close(requests)
+		}
+		wg.Wait()
+	}()
+
+	result := make(chan error, 1)
+	go func() { result <- cd.Run(ctx) }()
+	for events := cd.Events(); events != nil; {
+		select {
+		case p := <-d.polls:
+			timeline.Polls = append(timeline.Polls, p)
+		case e, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			// проверка выполняется перед событием шага, поэтому время проверки - время события
+			if n := len(timeline.Polls); n > 0 && timeline.Polls[n-1].Time.IsZero() {
+				timeline.Polls[n-1].Time = e.Time
+			}
+			timeline.Events = append(timeline.Events, e)
+		}
+	}
+	return timeline, <-result
+}
+
+// poll - проверка отсчета: опрашивает все подсистемы перед шагом remaining-1.
+// До T-0 неготовая подсистема еще может восстановиться, на T-0 готовы должны быть все.
+func (d *director) poll(remaining int) error {
+	step := remaining - 1
+	for _, requests := range d.requests {
+		requests <- step
+	}
+	reports := make([]Report, len(d.requests))
+	for range d.requests {
+		r := <-d.reports
+		reports[d.index[r.Subsystem]] = r
+	}
+	d.polls <- Poll{Remaining: step, Reports: reports}
+
+	if step > 0 {
+		return nil
+	}
+	var noGo []string
+	for _, r := range reports {
+		if !r.IsGo {
+			noGo = append(noGo, r.Subsystem+" ("+r.Reason+")")
+		}
+	}
+	if len(noGo) > 0 {
+		return fmt.Errorf("%w: %s", ErrNoGo, strings.Join(noGo, ", "))
+	}
+	return nil
+}
+
+// WriteTimeline печатает ход подготовки таблицей: время от начала, шаг и ответы подсистем,
+// а в конце - итог отсчета.
+func WriteTimeline(w io.Writer, t *Timeline) error {
+	if len(t.Events) == 0 {
+		return nil
+	}
+	start := t.Events[0].Time
+
+	header := fmt.Sprintf("%-8s %-5s", "Время", "Шаг")
+	for _, name := range t.Subsystems {
+		header += fmt.Sprintf(" %-*s", max(len([]rune(name)), len("NO-GO")), name)
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return err
+	}
+
+	for _, p := range t.Polls {
+		line := fmt.Sprintf("%-8v T-%-3d", p.Time.Sub(start).Round(time.Millisecond), p.Remaining)
+		var reasons []string
+		for i, r := range p.Reports {
+			status := "GO"
+			if !r.IsGo {
+				status = "NO-GO"
+				reasons = append(reasons, r.Subsystem+": "+r.Reason)
+			}
+			line += fmt.Sprintf(" %-*s", max(len([]rune(t.Subsystems[i])), len("NO-GO")), status)
+		}
+		if len(reasons) > 0 {
+			line += " " + strings.Join(reasons, "; ")
+		}
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+
+	last := t.Events[len(t.Events)-1]
+	_, err := fmt.Fprintf(w, "%-8v %v\n", last.Time.Sub(start).Round(time.Millisecond), last)
+	return err
+}
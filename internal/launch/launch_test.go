@@ -0,0 +1,245 @@
+package launch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"example/internal/clock"
+	"example/internal/countdown"
+)
+
+var start = time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+// always - подсистема, которая всегда готова.
+func always(name string) Subsystem {
+	return Subsystem{Name: name, FailChance: 0, RecoverChance: 1}
+}
+
+// never - подсистема, которая с первой проверки не готова и не восстанавливается.
+func never(name, reason string) Subsystem {
+	return Subsystem{Name: name, FailChance: 1, RecoverChance: 0, Reason: reason}
+}
+
+// flapping - подсистема, которая на нечетных проверках не готова, а на четных восстанавливается.
+func flapping(name string) Subsystem {
+	return Subsystem{Name: name, FailChance: 1, RecoverChance: 1, Reason: "мигает"}
+}
+
+func simulate(t *testing.T, ctx context.Context, cfg Config) (*Timeline, error) {
+	t.Helper()
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Second
+	}
+	return Simulate(ctx, clock.NewFake(start), cfg)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want error
+	}{
+		{"нет подсистем", Config{From: 3}, ErrNoSubsystems},
+		{"отрицательная вероятность", Config{From: 3, Subsystems: []Subsystem{{Name: "a", FailChance: -0.1}}}, ErrInvalidChance},
+		{"вероятность больше 1", Config{From: 3, Subsystems: []Subsystem{{Name: "a", RecoverChance: 1.5}}}, ErrInvalidChance},
+		{"повтор имени", Config{From: 3, Subsystems: []Subsystem{always("a"), always("b"), always("a")}}, ErrDuplicateSubsystem},
+	}
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Validate = %v, want %v", tt.name, err, tt.want)
+		}
+		if timeline, err := simulate(t, context.Background(), tt.cfg); timeline != nil || !errors.Is(err, tt.want) {
+			t.Errorf("%s: Simulate = %v, %v, want %v", tt.name, timeline, err, tt.want)
+		}
+	}
+	if err := (Config{Subsystems: DefaultSubsystems()}).Validate(); err != nil {
+		t.Errorf("DefaultSubsystems: %v", err)
+	}
+	if _, err := simulate(t, context.Background(), Config{From: 0, Subsystems: []Subsystem{always("a")}}); !errors.Is(err, countdown.ErrInvalidFrom) {
+		t.Errorf("From 0: err = %v, want ErrInvalidFrom", err)
+	}
+}
+
+func TestPollIsGo(t *testing.T) {
+	tests := []struct {
+		reports []bool
+		want    bool
+	}{
+		{nil, true},
+		{[]bool{true, true}, true},
+		{[]bool{true, false, true}, false},
+		{[]bool{false}, false},
+	}
+	for _, tt := range tests {
+		var p Poll
+		for _, isGo := range tt.reports {
+			p.Reports = append(p.Reports, Report{IsGo: isGo})
+		}
+		if got := p.IsGo(); got != tt.want {
+			t.Errorf("Poll%v.IsGo() = %t, want %t", tt.reports, got, tt.want)
+		}
+	}
+}
+
+func TestSimulateGo(t *testing.T) {
+	cfg := Config{From: 5, Subsystems: []Subsystem{always("топливо"), always("погода"), always("полигон")}}
+	timeline, err := simulate(t, context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !timeline.IsLaunched() {
+		t.Fatalf("не запущено: %v", timeline.Events)
+	}
+	if !reflect.DeepEqual(timeline.Subsystems, []string{"топливо", "погода", "полигон"}) {
+		t.Errorf("Subsystems = %v", timeline.Subsystems)
+	}
+	// события T-5 ... T-1 и запуск, проверка перед каждым шагом: T-4 ... T-0
+	if len(timeline.Events) != 6 || len(timeline.Polls) != 5 {
+		t.Fatalf("%d событий и %d проверок, want 6 и 5", len(timeline.Events), len(timeline.Polls))
+	}
+	for i, p := range timeline.Polls {
+		if p.Remaining != 4-i || !p.IsGo() || len(p.Reports) != 3 {
+			t.Errorf("проверка %d: %+v", i, p)
+		}
+		// ответы в порядке подсистем, а не в порядке прихода из общего канала
+		for j, r := range p.Reports {
+			if r.Subsystem != timeline.Subsystems[j] || r.Remaining != p.Remaining {
+				t.Errorf("проверка T-%d, ответ %d: %+v", p.Remaining, j, r)
+			}
+		}
+		if e := timeline.Events[i+1]; !p.Time.Equal(e.Time) {
+			t.Errorf("проверка T-%d в %v, событие %v в %v", p.Remaining, p.Time, e, e.Time)
+		}
+	}
+}
+
+// TestSimulateNoGo проверяет, что решение принимается по ответам на T-0:
+// неготовность раньше не отменяет запуск, если подсистема успела восстановиться.
+func TestSimulateNoGo(t *testing.T) {
+	tests := []struct {
+		name       string
+		from       int
+		subsystems []Subsystem
+		wantNoGo   []string // неготовые на T-0; пусто - запуск
+	}{
+		{"одна не готова", 3, []Subsystem{always("топливо"), never("погода", "ветер")}, []string{"погода (ветер)"}},
+		{"две не готовы", 2, []Subsystem{never("топливо", "утечка"), always("наведение"), never("погода", "ветер")}, []string{"топливо (утечка)", "погода (ветер)"}},
+		{"восстановилась к T-0", 4, []Subsystem{always("топливо"), flapping("погода")}, nil},
+		{"не готова на T-0", 3, []Subsystem{always("топливо"), flapping("погода")}, []string{"погода (мигает)"}},
+	}
+	for _, tt := range tests {
+		timeline, err := simulate(t, context.Background(), Config{From: tt.from, Subsystems: tt.subsystems})
+		if timeline == nil {
+			t.Fatalf("%s: нет хода подготовки, err = %v", tt.name, err)
+		}
+		if len(tt.wantNoGo) == 0 {
+			if err != nil || !timeline.IsLaunched() {
+				t.Errorf("%s: err = %v, запущено %t, want запуск", tt.name, err, timeline.IsLaunched())
+			}
+			continue
+		}
+		if !errors.Is(err, countdown.ErrAborted) || !errors.Is(err, ErrNoGo) {
+			t.Errorf("%s: err = %v, want ErrAborted и ErrNoGo", tt.name, err)
+			continue
+		}
+		if want := strings.Join(tt.wantNoGo, ", "); !strings.HasSuffix(err.Error(), want) {
+			t.Errorf("%s: err = %q, want суффикс %q", tt.name, err, want)
+		}
+		last := timeline.Events[len(timeline.Events)-1]
+		if timeline.IsLaunched() || last.Kind != countdown.KindAbort || last.Remaining != 1 {
+			t.Errorf("%s: последнее событие %v, want отмена на T-1", tt.name, last)
+		}
+		if n := len(timeline.Polls); n != tt.from || timeline.Polls[n-1].IsGo() {
+			t.Errorf("%s: %d проверок, want %d, последняя NO-GO", tt.name, n, tt.from)
+		}
+	}
+}
+
+// TestSimulateSeed проверяет, что при одном зерне ход подготовки одинаков,
+// хотя подсистемы отвечают из разных горутин.
+func TestSimulateSeed(t *testing.T) {
+	cfg := Config{From: 30, Subsystems: DefaultSubsystems(), Seed: 7}
+	first, firstErr := simulate(t, context.Background(), cfg)
+	for range 5 {
+		again, err := simulate(t, context.Background(), cfg)
+		if !reflect.DeepEqual(first.Polls, again.Polls) || !reflect.DeepEqual(first.Events, again.Events) || !sameError(firstErr, err) {
+			t.Fatalf("ход подготовки с зерном %d изменился: %v, %v", cfg.Seed, firstErr, err)
+		}
+	}
+}
+
+func sameError(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Error() == b.Error()
+}
+
+func TestSimulateContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	timeline, err := simulate(t, ctx, Config{From: 5, Subsystems: []Subsystem{always("a"), always("b")}})
+	if !errors.Is(err, countdown.ErrAborted) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want ErrAborted и context.Canceled", err)
+	}
+	if timeline == nil || timeline.IsLaunched() || len(timeline.Events) == 0 {
+		t.Fatalf("timeline = %+v", timeline)
+	}
+	if last := timeline.Events[len(timeline.Events)-1]; last.Kind != countdown.KindAbort {
+		t.Errorf("последнее событие %v, want отмена", last)
+	}
+}
+
+// TestSimulateTimeout прерывает подготовку по таймауту посреди отсчета на настоящих часах.
+func TestSimulateTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	cfg := Config{From: 1_000_000, Interval: time.Millisecond, Subsystems: []Subsystem{always("a"), always("b"), always("c")}}
+	timeline, err := Simulate(ctx, clock.Real{}, cfg)
+	if !errors.Is(err, countdown.ErrAborted) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want ErrAborted и context.DeadlineExceeded", err)
+	}
+	if len(timeline.Polls) == 0 {
+		t.Error("до таймаута не было ни одной проверки")
+	}
+	last := timeline.Events[len(timeline.Events)-1]
+	if last.Kind != countdown.KindAbort || last.Remaining <= 0 {
+		t.Errorf("последнее событие %v, want отмена посреди отсчета", last)
+	}
+	for _, p := range timeline.Polls {
+		if len(p.Reports) != 3 {
+			t.Fatalf("проверка T-%d: %d ответов, want 3", p.Remaining, len(p.Reports))
+		}
+	}
+}
+
+func TestWriteTimeline(t *testing.T) {
+	timeline, err := simulate(t, context.Background(), Config{From: 2, Subsystems: []Subsystem{always("топливо"), never("погода", "ветер")}})
+	if !errors.Is(err, ErrNoGo) {
+		t.Fatalf("err = %v, want ErrNoGo", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteTimeline(&buf, timeline); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"Время    Шаг   топливо погода",
+		"1s       T-1   GO      NO-GO  погода: ветер",
+		"2s       T-0   GO      NO-GO  погода: ветер",
+		"2s       T-1 отмена: нет готовности: погода (ветер)",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("WriteTimeline:\n%s\nwant:\n%s", got, want)
+	}
+
+	buf.Reset()
+	if err := WriteTimeline(&buf, &Timeline{}); err != nil || buf.Len() != 0 {
+		t.Errorf("пустой ход подготовки: %q, %v", buf.String(), err)
+	}
+}
@@ -0,0 +1,134 @@
+// Package launchwindow ищет окна запуска к Марсу: даты отправления,
+// при которых полет длится меньше всего. Это обратный вопрос к f13:
+// не "как быстро лететь", а "когда вылетать и сколько лететь".
+package launchwindow
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"example/internal/orbit"
+	"example/internal/tickets"
+)
+
+var (
+	// ErrInvalidRange возвращается, если конец диапазона дат раньше начала.
+	ErrInvalidRange = errors.New("конец диапазона раньше начала")
+	// ErrInvalidStep возвращается для неположительного шага поиска.
+	ErrInvalidStep = errors.New("шаг поиска должен быть положительным")
+)
+
+// Search - параметры поиска окон запуска.
+type Search struct {
+	From time.Time     // начало диапазона дат отправления
+	To   time.Time     // конец диапазона дат отправления, включительно
+	Step time.Duration // шаг перебора дат, обычно сутки
+	Top  int           // сколько лучших окон вернуть для каждой компании
+}
+
+// Window - окно запуска для одной компании.
+type Window struct {
+	Spaceline   string    `json:"spaceline"`
+	Departure   time.Time `json:"departure"`
+	Distance    int       `json:"distance"`     // км от Земли до Марса в день отправления
+	FastestDays int       `json:"fastest_days"` // длительность полета на максимальной скорости компании
+	SlowestDays int       `json:"slowest_days"` // длительность полета на минимальной скорости компании
+	Arrival     time.Time `json:"arrival"`      // прибытие при полете на максимальной скорости
+}
+
+// sample - расстояние до Марса на одну дату.
+type sample struct {
+	at       time.Time
+	distance float64
+}
+
+// Find перебирает даты от s.From до s.To с шагом s.Step и для каждой компании флота
+// возвращает до s.Top окон - локальных минимумов расстояния, от лучшего к худшему.
+// Если в диапазоне нет ни одного минимума внутри, окном считается его ближайший к Марсу край.
+func Find(fleet []tickets.Spaceline, s Search) ([]Window, error) {
+	if err := tickets.ValidateFleet(fleet); err != nil {
+		return nil, err
+	}
+	if s.To.Before(s.From) {
+		return nil, fmt.Errorf("%w: %s < %s", ErrInvalidRange, s.To.Format(time.DateOnly), s.From.Format(time.DateOnly))
+	}
+	if s.Step <= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStep, s.Step)
+	}
+
+	best := findMinima(calcSamples(s.From, s.To, s.Step))
+	if s.Top > 0 && len(best) > s.Top {
+		best = best[:s.Top]
+	}
+
+	var windows []Window
+	for _, line := range fleet {
+		for _, m := range best {
+			distance := int(math.Round(m.distance))
+			fastest, err := tickets.CalcDays(distance, line.MaxSpeed)
+			if err != nil {
+				return nil, err
+			}
+			slowest, err := tickets.CalcDays(distance, line.MinSpeed)
+			if err != nil {
+				return nil, err
+			}
+			windows = append(windows, Window{
+				Spaceline:   line.Name,
+				Departure:   m.at,
+				Distance:    distance,
+				FastestDays: fastest,
+				SlowestDays: slowest,
+				Arrival:     m.at.AddDate(0, 0, fastest),
+			})
+		}
+	}
+	return windows, nil
+}
+
+// calcSamples считает расстояние до Марса на каждую дату диапазона.
+func calcSamples(from, to time.Time, step time.Duration) []sample {
+	var samples []sample
+	for t := from; !t.After(to); t = t.Add(step) {
+		samples = append(samples, sample{at: t, distance: orbit.Distance(t)})
+	}
+	return samples
+}
+
+// findMinima возвращает локальные минимумы расстояния, отсортированные по возрастанию.
+// Края диапазона считаются минимумами, если соседняя дата дальше.
+func findMinima(samples []sample) []sample {
+	var minima []sample
+	for i, s := range samples {
+		isLeftHigher := i == 0 || samples[i-1].distance > s.distance
+		isRightHigher := i == len(samples)-1 || samples[i+1].distance >= s.distance
+		if isLeftHigher && isRightHigher {
+			minima = append(minima, s)
+		}
+	}
+	sort.SliceStable(minima, func(i, j int) bool { return minima[i].distance < minima[j].distance })
+	return minima
+}
+
+// WriteTable печатает окна таблицей в стиле f43.
+func WriteTable(w io.Writer, windows []Window) error {
+	if _, err := fmt.Fprintln(w, "Spaceline        Departure  Distance, km  Days    Arrival"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "=========================================================="); err != nil {
+		return err
+	}
+	for _, win := range windows {
+		_, err := fmt.Fprintf(w, "%-16v %v %13v %3v-%-3v %v\n",
+			win.Spaceline, win.Departure.Format(time.DateOnly), win.Distance,
+			win.FastestDays, win.SlowestDays, win.Arrival.Format(time.DateOnly))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package launchwindow
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"example/internal/tickets"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+var fleet = []tickets.Spaceline{
+	{Name: "SpaceX", MinSpeed: 16, MaxSpeed: 30},
+	{Name: "Virgin Galactic", MinSpeed: 20, MaxSpeed: 25},
+}
+
+// TestFind проверяет окна на 2020-2022 годы: противостояние октября 2020 года
+// и край диапазона в декабре 2022-го, когда Марс еще приближается.
+func TestFind(t *testing.T) {
+	windows, err := Find(fleet, Search{From: date("2020-01-01"), To: date("2022-12-31"), Step: 24 * time.Hour, Top: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Window{
+		{Spaceline: "SpaceX", Departure: date("2020-10-07"), Distance: 62_064_163, FastestDays: 23, SlowestDays: 44, Arrival: date("2020-10-30")},
+		{Spaceline: "SpaceX", Departure: date("2022-12-01"), Distance: 81_463_652, FastestDays: 31, SlowestDays: 58, Arrival: date("2023-01-01")},
+		{Spaceline: "Virgin Galactic", Departure: date("2020-10-07"), Distance: 62_064_163, FastestDays: 28, SlowestDays: 35, Arrival: date("2020-11-04")},
+		{Spaceline: "Virgin Galactic", Departure: date("2022-12-01"), Distance: 81_463_652, FastestDays: 37, SlowestDays: 47, Arrival: date("2023-01-07")},
+	}
+	if !reflect.DeepEqual(windows, want) {
+		t.Errorf("Find =\n%+v\nwant\n%+v", windows, want)
+	}
+
+	again, err := Find(fleet, Search{From: date("2020-01-01"), To: date("2022-12-31"), Step: 24 * time.Hour, Top: 2})
+	if err != nil || !reflect.DeepEqual(again, windows) {
+		t.Errorf("повторный Find = %+v, %v", again, err)
+	}
+}
+
+func TestFindJSON(t *testing.T) {
+	windows, err := Find(fleet[:1], Search{From: date("2003-08-20"), To: date("2003-09-03"), Step: 24 * time.Hour, Top: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(windows); err != nil {
+		t.Fatal(err)
+	}
+	want := `[
+  {
+    "spaceline": "SpaceX",
+    "departure": "2003-08-27T00:00:00Z",
+    "distance": 55802193,
+    "fastest_days": 21,
+    "slowest_days": 40,
+    "arrival": "2003-09-17T00:00:00Z"
+  }
+]
+`
+	if got := buf.String(); got != want {
+		t.Errorf("JSON:\n%s\nwant:\n%s", got, want)
+	}
+
+	var back []Window
+	if err := json.Unmarshal(buf.Bytes(), &back); err != nil || !reflect.DeepEqual(back, windows) {
+		t.Errorf("JSON туда и обратно = %+v, %v, want %+v", back, err, windows)
+	}
+}
+
+func TestFindEdges(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        Search
+		wantDays []string // даты отправления окон первой компании
+	}{
+		// после противостояния Марс только удаляется: окно - начало диапазона
+		{"удаление", Search{From: date("2021-01-01"), To: date("2021-03-01"), Step: 24 * time.Hour}, []string{"2021-01-01"}},
+		// перед противостоянием Марс только приближается: окно - конец диапазона
+		{"приближение", Search{From: date("2020-06-01"), To: date("2020-07-01"), Step: 24 * time.Hour}, []string{"2020-07-01"}},
+		{"одна дата", Search{From: date("2020-06-01"), To: date("2020-06-01"), Step: 24 * time.Hour}, []string{"2020-06-01"}},
+		// без ограничения Top возвращаются все минимумы, от ближайшего к дальнему
+		{"все минимумы", Search{From: date("2018-01-01"), To: date("2021-01-01"), Step: 24 * time.Hour}, []string{"2018-07-31", "2020-10-07"}},
+		{"Top 1", Search{From: date("2018-01-01"), To: date("2021-01-01"), Step: 24 * time.Hour, Top: 1}, []string{"2018-07-31"}},
+	}
+	for _, tt := range tests {
+		windows, err := Find(fleet[:1], tt.s)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var got []string
+		for _, w := range windows {
+			got = append(got, w.Departure.Format(time.DateOnly))
+		}
+		if !reflect.DeepEqual(got, tt.wantDays) {
+			t.Errorf("%s: окна %v, want %v", tt.name, got, tt.wantDays)
+		}
+	}
+}
+
+func TestFindErrors(t *testing.T) {
+	valid := Search{From: date("2020-01-01"), To: date("2020-12-31"), Step: 24 * time.Hour}
+	tests := []struct {
+		name  string
+		fleet []tickets.Spaceline
+		s     Search
+		want  error
+	}{
+		{"конец раньше начала", fleet, Search{From: valid.To, To: valid.From, Step: valid.Step}, ErrInvalidRange},
+		{"нулевой шаг", fleet, Search{From: valid.From, To: valid.To}, ErrInvalidStep},
+		{"отрицательный шаг", fleet, Search{From: valid.From, To: valid.To, Step: -time.Hour}, ErrInvalidStep},
+		{"пустой флот", nil, valid, tickets.ErrEmptyFleet},
+		{"повтор компании", []tickets.Spaceline{fleet[0], fleet[0]}, valid, tickets.ErrDuplicateSpaceline},
+	}
+	for _, tt := range tests {
+		if windows, err := Find(tt.fleet, tt.s); windows != nil || !errors.Is(err, tt.want) {
+			t.Errorf("%s: Find = %v, %v, want %v", tt.name, windows, err, tt.want)
+		}
+	}
+}
+
+func TestFindMinima(t *testing.T) {
+	at := func(i int) time.Time { return date("2020-01-01").AddDate(0, 0, i) }
+	samplesOf := func(distances ...float64) []sample {
+		var samples []sample
+		for i, d := range distances {
+			samples = append(samples, sample{at: at(i), distance: d})
+		}
+		return samples
+	}
+	tests := []struct {
+		name      string
+		distances []float64
+		want      []int // номера минимумов по возрастанию расстояния
+	}{
+		{"один минимум внутри", []float64{5, 3, 4}, []int{1}},
+		{"края", []float64{2, 3, 1}, []int{2, 0}},
+		{"два минимума", []float64{9, 4, 6, 2, 8}, []int{3, 1}},
+		// на плато минимумом считается его первая дата
+		{"плато", []float64{5, 3, 3, 3, 6}, []int{1}},
+		{"одна точка", []float64{7}, []int{0}},
+		{"пусто", nil, nil},
+	}
+	for _, tt := range tests {
+		var got []int
+		for _, m := range findMinima(samplesOf(tt.distances...)) {
+			got = append(got, int(m.at.Sub(at(0))/(24*time.Hour)))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: findMinima(%v) = %v, want %v", tt.name, tt.distances, got, tt.want)
+		}
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteTable(&buf, []Window{
+		{Spaceline: "SpaceX", Departure: date("2020-10-07"), Distance: 62_064_163, FastestDays: 23, SlowestDays: 44, Arrival: date("2020-10-30")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"Spaceline        Departure  Distance, km  Days    Arrival",
+		"==========================================================",
+		"SpaceX           2020-10-07      62064163  23-44  2020-10-30",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("WriteTable:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,110 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+var (
+	// ErrUnknownRate возвращается, если в таблице нет курса нужной валюты.
+	ErrUnknownRate = errors.New("нет курса валюты")
+	// ErrInvalidRate возвращается для курса, который не является положительным числом.
+	ErrInvalidRate = errors.New("курс должен быть положительным числом")
+)
+
+// Rates - таблица курсов относительно базовой валюты: сколько единиц валюты стоит одна единица Base.
+// Курсы хранятся точными дробями, поэтому "92.5" не превращается в 92.499999...
+type Rates struct {
+	Base  Currency
+	rates map[string]*big.Rat // код валюты -> курс
+}
+
+// ratesFile - формат JSON-файла курсов:
+//
+//	{"base": "USD", "rates": {"EUR": 0.92, "RUB": 92.5}}
+type ratesFile struct {
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+// LoadRates читает таблицу курсов из JSON, см. ratesFile.
+// Курс базовой валюты к себе равен 1 и может не указываться.
+func LoadRates(r io.Reader) (*Rates, error) {
+	var file ratesFile
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("чтение курсов: %w", err)
+	}
+
+	base, err := GetCurrency(file.Base)
+	if err != nil {
+		return nil, fmt.Errorf("базовая валюта: %w", err)
+	}
+	rates := &Rates{Base: base, rates: map[string]*big.Rat{base.Code: big.NewRat(1, 1)}}
+	for code, number := range file.Rates {
+		c, err := GetCurrency(code)
+		if err != nil {
+			return nil, fmt.Errorf("курс %s: %w", code, err)
+		}
+		rate, ok := new(big.Rat).SetString(number.String())
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: %s: %s", ErrInvalidRate, code, number)
+		}
+		rates.rates[c.Code] = rate
+	}
+	return rates, nil
+}
+
+// GetRate возвращает курс валюты c относительно базовой.
+func (r *Rates) GetRate(c Currency) (*big.Rat, error) {
+	rate, ok := r.rates[c.Code]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRate, c.Code)
+	}
+	return rate, nil
+}
+
+// Convert переводит сумму m в валюту to через базовую валюту
+// с банковским округлением до минимальной единицы to.
+func (r *Rates) Convert(m Money, to Currency) (Money, error) {
+	if m.currency == to {
+		return m, nil
+	}
+	fromRate, err := r.GetRate(m.currency)
+	if err != nil {
+		return Money{}, err
+	}
+	toRate, err := r.GetRate(to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	// amount_to = amount_from / fromRate * toRate с поправкой на разное число знаков после запятой
+	factor := new(big.Rat).Quo(toRate, fromRate)
+	scale := new(big.Rat).SetFrac(big.NewInt(to.calcScale()), big.NewInt(m.currency.calcScale()))
+	factor.Mul(factor, scale)
+
+	converted, err := Money{amount: m.amount, currency: to}.mulRat(factor)
+	if err != nil {
+		return Money{}, fmt.Errorf("перевод %v в %s: %w", m, to.Code, err)
+	}
+	return converted, nil
+}
+
+// String выводит курсы в виде "1 USD = 0.92 EUR, 92.5 RUB".
+func (r *Rates) String() string {
+	var parts []string
+	for _, c := range currencies {
+		if rate, ok := r.rates[c.Code]; ok && c != r.Base {
+			text := strings.TrimRight(strings.TrimRight(rate.FloatString(6), "0"), ".")
+			parts = append(parts, text+" "+c.Code)
+		}
+	}
+	return "1 " + r.Base.Code + " = " + strings.Join(parts, ", ")
+}
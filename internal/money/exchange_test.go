@@ -0,0 +1,113 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// mustLoadRates разбирает таблицу курсов из строки JSON.
+func mustLoadRates(t *testing.T, text string) *Rates {
+	t.Helper()
+	rates, err := LoadRates(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rates
+}
+
+func TestLoadRates(t *testing.T) {
+	rates := mustLoadRates(t, `{"base": "usd", "rates": {"EUR": 0.92, "rub": 92.5}}`)
+	if rates.Base != USD {
+		t.Errorf("Base = %v, want USD", rates.Base)
+	}
+	tests := []struct {
+		c    Currency
+		want *big.Rat
+	}{
+		{USD, big.NewRat(1, 1)},
+		{EUR, big.NewRat(92, 100)},
+		// курс хранится точной дробью, а не ближайшим float64
+		{RUB, big.NewRat(185, 2)},
+	}
+	for _, tt := range tests {
+		if got, err := rates.GetRate(tt.c); err != nil || got.Cmp(tt.want) != 0 {
+			t.Errorf("GetRate(%s) = %v, %v, want %v", tt.c.Code, got, err, tt.want)
+		}
+	}
+	if got, want := rates.String(), "1 USD = 0.92 EUR, 92.5 RUB"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	errs := []struct {
+		name string
+		text string
+		want error // nil - ошибка разбора JSON
+	}{
+		{"не JSON", `base: USD`, nil},
+		{"неизвестное поле", `{"base": "USD", "rates": {}, "date": "2024-05-01"}`, nil},
+		{"курс строкой", `{"base": "USD", "rates": {"EUR": "много"}}`, nil},
+		{"нет базовой валюты", `{"rates": {"EUR": 0.92}}`, ErrUnknownCurrency},
+		{"неизвестная базовая валюта", `{"base": "JPY", "rates": {}}`, ErrUnknownCurrency},
+		{"неизвестная валюта курса", `{"base": "USD", "rates": {"JPY": 150}}`, ErrUnknownCurrency},
+		{"нулевой курс", `{"base": "USD", "rates": {"EUR": 0}}`, ErrInvalidRate},
+		{"отрицательный курс", `{"base": "USD", "rates": {"RUB": -92.5}}`, ErrInvalidRate},
+	}
+	for _, tt := range errs {
+		_, err := LoadRates(strings.NewReader(tt.text))
+		if err == nil || (tt.want != nil && !errors.Is(err, tt.want)) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	rates := mustLoadRates(t, `{"base": "USD", "rates": {"EUR": 0.92, "RUB": 92.5}}`)
+	halves := mustLoadRates(t, `{"base": "USD", "rates": {"EUR": 0.5}}`)
+	tests := []struct {
+		rates *Rates
+		m     string
+		to    Currency
+		want  string
+	}{
+		{rates, "$10.00", EUR, "€9.20"},
+		{rates, "$1.00", RUB, "₽92.50"},
+		{rates, "€9.20", USD, "$10.00"},
+		{rates, "-$2.00", EUR, "-€1.84"},
+		// через базовую валюту: 100 / 92.5 * 0.92 = 0.99459...
+		{rates, "₽100.00", EUR, "€0.99"},
+		{rates, "₽3.00", RUB, "₽3.00"},
+		// половина цента округляется к четному
+		{halves, "$0.01", EUR, "€0.00"},
+		{halves, "$0.03", EUR, "€0.02"},
+		{halves, "$0.05", EUR, "€0.02"},
+	}
+	for _, tt := range tests {
+		got, err := tt.rates.Convert(MustParse(tt.m), tt.to)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("Convert(%s, %s) = %v, %v, want %s", tt.m, tt.to.Code, got, err, tt.want)
+		}
+	}
+
+	errs := []struct {
+		name string
+		m    Money
+		to   Currency
+		want error
+	}{
+		{"нет курса цели", MustParse("$1.00"), RUB, ErrUnknownRate},
+		{"нет курса суммы", MustParse("₽1.00"), USD, ErrUnknownRate},
+		{"переполнение", New(math.MaxInt64, EUR), USD, ErrOverflow},
+	}
+	for _, tt := range errs {
+		if got, err := halves.Convert(tt.m, tt.to); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Convert(%v, %s) = %v, %v, want %v", tt.name, tt.m, tt.to.Code, got, err, tt.want)
+		}
+	}
+	// сумма в той же валюте возвращается как есть, даже если курса нет
+	if got, err := halves.Convert(MustParse("₽5.00"), RUB); err != nil || got.String() != "₽5.00" {
+		t.Errorf("Convert(₽5.00, RUB) = %v, %v", got, err)
+	}
+}
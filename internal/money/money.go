@@ -0,0 +1,329 @@
+// Package money - денежные суммы с фиксированной точкой.
+//
+// Примеры f50, f52 и f53 показывают, что 0.1 + 0.2 != 0.3 во float64,
+// а f69 - что деньги надежнее считать в целых центах. Money хранит сумму
+// в минимальных единицах валюты (центах, копейках) в int64, проверяет
+// переполнение и округляет дробные результаты по-банковски.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"example/internal/safeint"
+)
+
+var (
+	// ErrOverflow возвращается, если результат не помещается в int64.
+	ErrOverflow = errors.New("переполнение денежной суммы")
+	// ErrCurrencyMismatch возвращается при операции над суммами в разных валютах.
+	ErrCurrencyMismatch = errors.New("суммы в разных валютах")
+	// ErrUnknownCurrency возвращается для валюты, которой нет в таблице.
+	ErrUnknownCurrency = errors.New("неизвестная валюта")
+	// ErrInvalidFormat возвращается, если строку не удалось разобрать как сумму.
+	ErrInvalidFormat = errors.New("неверный формат суммы")
+	// ErrDivisionByZero возвращается при делении на ноль в MulRatio.
+	ErrDivisionByZero = errors.New("деление на ноль")
+)
+
+// Currency - валюта: код ISO 4217, символ и число знаков после запятой.
+type Currency struct {
+	Code     string // например "USD"
+	Symbol   string // например "$"
+	Exponent int    // знаков после запятой: 2 для центов и копеек
+}
+
+var (
+	USD = Currency{Code: "USD", Symbol: "$", Exponent: 2}
+	EUR = Currency{Code: "EUR", Symbol: "€", Exponent: 2}
+	RUB = Currency{Code: "RUB", Symbol: "₽", Exponent: 2}
+)
+
+// currencies - поддерживаемые валюты.
+var currencies = []Currency{USD, EUR, RUB}
+
+// GetCurrency возвращает валюту по коду ISO 4217 без учета регистра.
+func GetCurrency(code string) (Currency, error) {
+	for _, c := range currencies {
+		if strings.EqualFold(c.Code, code) {
+			return c, nil
+		}
+	}
+	return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+}
+
+// calcScale возвращает число минимальных единиц в одной основной: 100 для центов.
+func (c Currency) calcScale() int64 {
+	scale := int64(1)
+	for i := 0; i < c.Exponent; i++ {
+		scale *= 10
+	}
+	return scale
+}
+
+// Money - сумма в минимальных единицах валюты.
+// Нулевое значение Money{} - ноль без валюты: его можно сложить с суммой в любой валюте.
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// New создает сумму amount в минимальных единицах валюты c: New(2005, USD) - это $20.05.
+func New(amount int64, c Currency) Money {
+	return Money{amount: amount, currency: c}
+}
+
+// FromMajor создает сумму из целого числа основных единиц: FromMajor(20, USD) - это $20.00.
+func FromMajor(units int64, c Currency) (Money, error) {
+	amount, err := mulInt64(units, c.calcScale())
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: amount, currency: c}, nil
+}
+
+// Amount возвращает сумму в минимальных единицах валюты.
+func (m Money) Amount() int64 { return m.amount }
+
+// Currency возвращает валюту суммы.
+func (m Money) Currency() Currency { return m.currency }
+
+// IsZero проверяет, что сумма равна нулю.
+func (m Money) IsZero() bool { return m.amount == 0 }
+
+// IsNegative проверяет, что сумма меньше нуля.
+func (m Money) IsNegative() bool { return m.amount < 0 }
+
+// Cmp сравнивает суммы: -1, если m < o, 0, если равны, и 1, если m > o.
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := m.calcCommonCurrency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < o.amount:
+		return -1, nil
+	case m.amount > o.amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Add возвращает m + o.
+func (m Money) Add(o Money) (Money, error) {
+	c, err := m.calcCommonCurrency(o)
+	if err != nil {
+		return Money{}, err
+	}
+	sum, err := safeint.Add(m.amount, o.amount)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %v + %v", ErrOverflow, m, o)
+	}
+	return Money{amount: sum, currency: c}, nil
+}
+
+// Sub возвращает m - o.
+func (m Money) Sub(o Money) (Money, error) {
+	neg, err := o.Neg()
+	if err != nil {
+		return Money{}, err
+	}
+	return m.Add(neg)
+}
+
+// Neg возвращает -m. Наименьшее значение int64 отрицать нельзя: вернется ErrOverflow.
+func (m Money) Neg() (Money, error) {
+	if m.amount == math.MinInt64 {
+		return Money{}, fmt.Errorf("%w: -(%v)", ErrOverflow, m)
+	}
+	return Money{amount: -m.amount, currency: m.currency}, nil
+}
+
+// Mul возвращает m * k.
+func (m Money) Mul(k int64) (Money, error) {
+	amount, err := mulInt64(m.amount, k)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %v * %d", err, m, k)
+	}
+	return Money{amount: amount, currency: m.currency}, nil
+}
+
+// MulRatio возвращает m * num / den с банковским округлением до минимальной единицы.
+func (m Money) MulRatio(num, den int64) (Money, error) {
+	if den == 0 {
+		return Money{}, ErrDivisionByZero
+	}
+	r := new(big.Rat).SetFrac(big.NewInt(num), big.NewInt(den))
+	return m.mulRat(r)
+}
+
+// MulFloat возвращает m * f с банковским округлением до минимальной единицы.
+// f переводится в дробь точно, поэтому MulFloat(0.5) делит ровно пополам.
+func (m Money) MulFloat(f float64) (Money, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Money{}, fmt.Errorf("%w: множитель %v", ErrOverflow, f)
+	}
+	r := new(big.Rat).SetFloat64(f)
+	return m.mulRat(r)
+}
+
+// mulRat умножает сумму на точную дробь r и округляет результат по-банковски.
+func (m Money) mulRat(r *big.Rat) (Money, error) {
+	product := new(big.Rat).Mul(new(big.Rat).SetInt64(m.amount), r)
+	rounded := RoundHalfEven(product)
+	if !rounded.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %v * %v", ErrOverflow, m, r.RatString())
+	}
+	return Money{amount: rounded.Int64(), currency: m.currency}, nil
+}
+
+// RoundHalfEven округляет дробь до целого по-банковски:
+// половина округляется к ближайшему четному, поэтому 2.5 -> 2, а 3.5 -> 4.
+func RoundHalfEven(r *big.Rat) *big.Int {
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return q
+	}
+
+	// сравниваем удвоенный остаток со знаменателем: больше - округляем от нуля
+	twice := new(big.Int).Abs(rem)
+	twice.Lsh(twice, 1)
+	cmp := twice.Cmp(r.Denom())
+	if cmp > 0 || (cmp == 0 && q.Bit(0) == 1) {
+		if r.Sign() < 0 {
+			return q.Sub(q, big.NewInt(1))
+		}
+		return q.Add(q, big.NewInt(1))
+	}
+	return q
+}
+
+// String форматирует сумму с символом валюты: "$20.05", "-$1.10", "₽100.00".
+func (m Money) String() string {
+	sign := ""
+	// uint64 нужен, чтобы взять модуль math.MinInt64 без переполнения
+	abs := uint64(m.amount)
+	if m.amount < 0 {
+		sign = "-"
+		abs = uint64(-(m.amount + 1)) + 1
+	}
+
+	scale := uint64(m.currency.calcScale())
+	if m.currency.Exponent == 0 {
+		return fmt.Sprintf("%s%s%d", sign, m.currency.Symbol, abs)
+	}
+	return fmt.Sprintf("%s%s%d.%0*d", sign, m.currency.Symbol, abs/scale, m.currency.Exponent, abs%scale)
+}
+
+// Parse разбирает сумму из строки с символом валюты впереди или кодом в конце:
+// "$20.05", "-$1.10", "20.05 USD", "€3". Дробная часть не может быть длиннее,
+// чем позволяет валюта: "$0.001" - ошибка, а не округление.
+func Parse(s string) (Money, error) {
+	text := strings.TrimSpace(s)
+	isNegative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	c, number, ok := cutCurrency(text)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q: не указана валюта", ErrInvalidFormat, s)
+	}
+
+	whole, frac, hasPoint := strings.Cut(number, ".")
+	if whole == "" || !isDigits(whole) || !isDigits(frac) || (hasPoint && frac == "") {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidFormat, s)
+	}
+	if len(frac) > c.Exponent {
+		return Money{}, fmt.Errorf("%w: %q: у %s не больше %d знаков после точки", ErrInvalidFormat, s, c.Code, c.Exponent)
+	}
+	frac += strings.Repeat("0", c.Exponent-len(frac))
+
+	// собираем число в отрицательном виде, чтобы поместился и math.MinInt64
+	var amount int64
+	for _, r := range whole + frac {
+		next, err := mulInt64(amount, 10)
+		if err == nil && next >= math.MinInt64+int64(r-'0') {
+			amount = next - int64(r-'0')
+			continue
+		}
+		return Money{}, fmt.Errorf("%w: %q", ErrOverflow, s)
+	}
+	if !isNegative {
+		if amount == math.MinInt64 {
+			return Money{}, fmt.Errorf("%w: %q", ErrOverflow, s)
+		}
+		amount = -amount
+	}
+	return Money{amount: amount, currency: c}, nil
+}
+
+// MustParse работает как Parse, но паникует при ошибке. Для констант в коде.
+func MustParse(s string) Money {
+	m, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// MarshalText позволяет хранить сумму в JSON строкой "$20.05".
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText разбирает сумму из строки, см. Parse.
+func (m *Money) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// calcCommonCurrency возвращает общую валюту двух сумм.
+// Ноль без валюты (Money{}) совместим с любой валютой.
+func (m Money) calcCommonCurrency(o Money) (Currency, error) {
+	switch {
+	case m.currency == o.currency:
+		return m.currency, nil
+	case m.currency.Code == "" && m.amount == 0:
+		return o.currency, nil
+	case o.currency.Code == "" && o.amount == 0:
+		return m.currency, nil
+	}
+	return Currency{}, fmt.Errorf("%w: %s и %s", ErrCurrencyMismatch, m.currency.Code, o.currency.Code)
+}
+
+// cutCurrency отделяет валюту от числа: символ в начале или код в конце строки.
+func cutCurrency(text string) (Currency, string, bool) {
+	for _, c := range currencies {
+		if number, ok := strings.CutPrefix(text, c.Symbol); ok {
+			return c, strings.TrimSpace(number), true
+		}
+		if number, ok := strings.CutSuffix(text, c.Code); ok {
+			return c, strings.TrimSpace(number), true
+		}
+	}
+	return Currency{}, "", false
+}
+
+// isDigits проверяет, что строка состоит только из цифр ASCII.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// mulInt64 умножает a на b с проверкой переполнения.
+func mulInt64(a, b int64) (int64, error) {
+	product, err := safeint.Mul(a, b)
+	if err != nil {
+		return 0, ErrOverflow
+	}
+	return product, nil
+}
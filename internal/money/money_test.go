@@ -0,0 +1,200 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s      string
+		amount int64
+		c      Currency
+		want   string // String() после разбора
+	}{
+		{"$20.05", 2005, USD, "$20.05"},
+		{"-$1.10", -110, USD, "-$1.10"},
+		{"20.05 USD", 2005, USD, "$20.05"},
+		{"€3", 300, EUR, "€3.00"},
+		{"  ₽100.5 ", 10050, RUB, "₽100.50"},
+		{"0 RUB", 0, RUB, "₽0.00"},
+		{"$92233720368547758.07", math.MaxInt64, USD, "$92233720368547758.07"},
+		{"-$92233720368547758.08", math.MinInt64, USD, "-$92233720368547758.08"},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.s)
+		if err != nil || got.Amount() != tt.amount || got.Currency() != tt.c {
+			t.Errorf("Parse(%q) = %d %s, %v, want %d %s", tt.s, got.Amount(), got.Currency().Code, err, tt.amount, tt.c.Code)
+			continue
+		}
+		if s := got.String(); s != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.s, s, tt.want)
+		}
+	}
+
+	errs := []struct {
+		s    string
+		want error
+	}{
+		{"20.05", ErrInvalidFormat},
+		{"$", ErrInvalidFormat},
+		{"$.05", ErrInvalidFormat},
+		{"$20.", ErrInvalidFormat},
+		{"$2a", ErrInvalidFormat},
+		{"$0.001", ErrInvalidFormat},
+		{"$+1", ErrInvalidFormat},
+		{"$92233720368547758.08", ErrOverflow},
+		{"-$92233720368547758.09", ErrOverflow},
+		{"$1000000000000000000000", ErrOverflow},
+	}
+	for _, tt := range errs {
+		if got, err := Parse(tt.s); !errors.Is(err, tt.want) {
+			t.Errorf("Parse(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+	}
+}
+
+func TestRoundHalfEven(t *testing.T) {
+	tests := []struct {
+		num, den int64
+		want     int64
+	}{
+		{5, 2, 2},
+		{7, 2, 4},
+		{-5, 2, -2},
+		{-7, 2, -4},
+		{1, 3, 0},
+		{2, 3, 1},
+		{-2, 3, -1},
+		{251, 100, 3},
+		{249, 100, 2},
+		{6, 3, 2},
+	}
+	for _, tt := range tests {
+		got := RoundHalfEven(big.NewRat(tt.num, tt.den))
+		if got.Int64() != tt.want {
+			t.Errorf("RoundHalfEven(%d/%d) = %v, want %d", tt.num, tt.den, got, tt.want)
+		}
+	}
+}
+
+// TestMulRounding проверяет банковское округление до цента: половина цента уходит к четному.
+func TestMulRounding(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func() (Money, error)
+		want string
+	}{
+		{"0.125 -> 0.12", func() (Money, error) { return MustParse("$0.05").MulRatio(5, 2) }, "$0.12"},
+		{"0.135 -> 0.14", func() (Money, error) { return MustParse("$0.27").MulRatio(1, 2) }, "$0.14"},
+		{"-0.125 -> -0.12", func() (Money, error) { return MustParse("-$0.25").MulRatio(1, 2) }, "-$0.12"},
+		{"0.175 -> 0.18", func() (Money, error) { return MustParse("$0.07").MulFloat(2.5) }, "$0.18"},
+		{"0.125 через MulFloat", func() (Money, error) { return MustParse("$0.25").MulFloat(0.5) }, "$0.12"},
+		{"треть", func() (Money, error) { return MustParse("$1.00").MulRatio(1, 3) }, "$0.33"},
+	}
+	for _, tt := range tests {
+		got, err := tt.got()
+		if err != nil || got.String() != tt.want {
+			t.Errorf("%s: %v, %v, want %s", tt.name, got, err, tt.want)
+		}
+	}
+
+	if _, err := MustParse("$1.00").MulRatio(1, 0); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("MulRatio(1, 0): err = %v, want ErrDivisionByZero", err)
+	}
+	for _, f := range []float64{math.NaN(), math.Inf(1), 1e300} {
+		if got, err := MustParse("$1.00").MulFloat(f); !errors.Is(err, ErrOverflow) {
+			t.Errorf("MulFloat(%v) = %v, %v, want ErrOverflow", f, got, err)
+		}
+	}
+}
+
+func TestCurrencyMismatch(t *testing.T) {
+	usd, eur := MustParse("$1.00"), MustParse("€1.00")
+	if _, err := usd.Add(eur); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add: err = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := usd.Sub(eur); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Sub: err = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := usd.Cmp(eur); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Cmp: err = %v, want ErrCurrencyMismatch", err)
+	}
+	// даже нулевые суммы в разных валютах несовместимы
+	if _, err := New(0, USD).Add(New(0, EUR)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("$0 + €0: err = %v, want ErrCurrencyMismatch", err)
+	}
+
+	// ноль без валюты складывается с любой суммой
+	var zero Money
+	if got, err := zero.Add(eur); err != nil || got != eur {
+		t.Errorf("Money{} + €1.00 = %v, %v", got, err)
+	}
+	if got, err := usd.Sub(zero); err != nil || got != usd {
+		t.Errorf("$1.00 - Money{} = %v, %v", got, err)
+	}
+	if cmp, err := zero.Cmp(usd); err != nil || cmp != -1 {
+		t.Errorf("Money{}.Cmp($1.00) = %d, %v, want -1", cmp, err)
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	maxUSD, minUSD := New(math.MaxInt64, USD), New(math.MinInt64, USD)
+	cent := New(1, USD)
+	tests := []struct {
+		name string
+		got  func() (Money, error)
+	}{
+		{"max + 1", func() (Money, error) { return maxUSD.Add(cent) }},
+		{"min - 1", func() (Money, error) { return minUSD.Sub(cent) }},
+		{"0 - min", func() (Money, error) { return New(0, USD).Sub(minUSD) }},
+		{"-min", func() (Money, error) { return minUSD.Neg() }},
+		{"max * 2", func() (Money, error) { return maxUSD.Mul(2) }},
+		{"min * -1", func() (Money, error) { return minUSD.Mul(-1) }},
+		{"half * 3", func() (Money, error) { return New(math.MaxInt64/2, USD).Mul(3) }},
+		{"MulRatio", func() (Money, error) { return maxUSD.MulRatio(3, 2) }},
+		{"FromMajor", func() (Money, error) { return FromMajor(math.MaxInt64/10, USD) }},
+	}
+	for _, tt := range tests {
+		if got, err := tt.got(); !errors.Is(err, ErrOverflow) {
+			t.Errorf("%s = %v, %v, want ErrOverflow", tt.name, got, err)
+		}
+	}
+
+	// у самой границы переполнения еще нет
+	if got, err := maxUSD.Sub(cent); err != nil || got.Amount() != math.MaxInt64-1 {
+		t.Errorf("max - 1 = %v, %v", got, err)
+	}
+	if got, err := minUSD.Add(maxUSD); err != nil || got.Amount() != -1 {
+		t.Errorf("min + max = %v, %v", got, err)
+	}
+	if got, err := New(-math.MaxInt64, USD).Mul(1); err != nil || got.Amount() != -math.MaxInt64 {
+		t.Errorf("-max * 1 = %v, %v", got, err)
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	m := MustParse("-€12.34")
+	text, err := m.MarshalText()
+	if err != nil || string(text) != "-€12.34" {
+		t.Fatalf("MarshalText = %q, %v", text, err)
+	}
+	var back Money
+	if err := back.UnmarshalText(text); err != nil || back != m {
+		t.Errorf("UnmarshalText(%q) = %v, %v, want %v", text, back, err, m)
+	}
+	if err := back.UnmarshalText([]byte("12.34")); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("UnmarshalText без валюты: err = %v, want ErrInvalidFormat", err)
+	}
+}
+
+func TestGetCurrency(t *testing.T) {
+	if c, err := GetCurrency("usd"); err != nil || c != USD {
+		t.Errorf("GetCurrency(usd) = %v, %v", c, err)
+	}
+	if _, err := GetCurrency("XYZ"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("GetCurrency(XYZ): err = %v, want ErrUnknownCurrency", err)
+	}
+}
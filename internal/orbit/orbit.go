@@ -0,0 +1,157 @@
+// Package orbit считает положения Земли и Марса на орбитах вокруг Солнца
+// и расстояние между ними на заданную дату.
+//
+// Вместо случайного расстояния из f12 и постоянных 62 100 000 км из f43
+// используются кеплеровы элементы орбит (приближение Standish, JPL),
+// точные до долей процента для дат 1800-2050 годов.
+package orbit
+
+import (
+	"math"
+	"time"
+
+	co "example/internal/constants"
+)
+
+// Elements - кеплеровы элементы орбиты в плоскости эклиптики J2000.
+type Elements struct {
+	A        float64 // большая полуось, а.е.
+	E        float64 // эксцентриситет
+	I        float64 // наклонение, градусы
+	L        float64 // средняя долгота, градусы
+	LongPeri float64 // долгота перигелия, градусы
+	LongNode float64 // долгота восходящего узла, градусы
+}
+
+// Body - планета: элементы орбиты на эпоху J2000 и их изменение за юлианское столетие.
+type Body struct {
+	Name  string
+	Epoch Elements // элементы на J2000.0
+	Rate  Elements // изменение элементов за столетие
+}
+
+// Earth - барицентр системы Земля-Луна.
+var Earth = Body{
+	Name:  "Земля",
+	Epoch: Elements{A: 1.00000261, E: 0.01671123, I: -0.00001531, L: 100.46457166, LongPeri: 102.93768193, LongNode: 0},
+	Rate:  Elements{A: 0.00000562, E: -0.00004392, I: -0.01294668, L: 35999.37244981, LongPeri: 0.32327364, LongNode: 0},
+}
+
+// Mars - Марс.
+var Mars = Body{
+	Name:  "Марс",
+	Epoch: Elements{A: 1.52371034, E: 0.09339410, I: 1.84969142, L: -4.55343205, LongPeri: -23.94362959, LongNode: 49.55953891},
+	Rate:  Elements{A: 0.00001847, E: 0.00007882, I: -0.00813131, L: 19140.30268499, LongPeri: 0.44441088, LongNode: -0.29257343},
+}
+
+// Vector - точка в гелиоцентрической эклиптической системе координат, км.
+type Vector struct {
+	X, Y, Z float64
+}
+
+// Sub возвращает v - u.
+func (v Vector) Sub(u Vector) Vector {
+	return Vector{v.X - u.X, v.Y - u.Y, v.Z - u.Z}
+}
+
+// Len возвращает длину вектора.
+func (v Vector) Len() float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+}
+
+// CalcJulianCenturies возвращает число юлианских столетий от эпохи J2000.0 до t.
+func CalcJulianCenturies(t time.Time) float64 {
+	// t.UnixNano переполняется за пределами 1678-2262 годов, поэтому секунды и наносекунды считаются отдельно
+	julianDay := (float64(t.Unix())+float64(t.Nanosecond())/1e9)/co.SecondsPerDay + co.UnixEpochJulianDay
+	return (julianDay - co.J2000JulianDay) / co.DaysPerJulianCentury
+}
+
+// CalcElements возвращает элементы орбиты тела b на момент t.
+func (b Body) CalcElements(t time.Time) Elements {
+	T := CalcJulianCenturies(t)
+	return Elements{
+		A:        b.Epoch.A + b.Rate.A*T,
+		E:        b.Epoch.E + b.Rate.E*T,
+		I:        b.Epoch.I + b.Rate.I*T,
+		L:        b.Epoch.L + b.Rate.L*T,
+		LongPeri: b.Epoch.LongPeri + b.Rate.LongPeri*T,
+		LongNode: b.Epoch.LongNode + b.Rate.LongNode*T,
+	}
+}
+
+// CalcPosition возвращает положение тела b относительно Солнца на момент t, км.
+func (b Body) CalcPosition(t time.Time) Vector {
+	el := b.CalcElements(t)
+
+	meanAnomaly := normalizeAngle(deg2rad(el.L - el.LongPeri))
+	argPeri := deg2rad(el.LongPeri - el.LongNode)
+	node := deg2rad(el.LongNode)
+	incl := deg2rad(el.I)
+
+	// положение в плоскости орбиты, ось x направлена в перигелий
+	E := solveKepler(meanAnomaly, el.E)
+	xOrb := el.A * (math.Cos(E) - el.E)
+	yOrb := el.A * math.Sqrt(1-el.E*el.E) * math.Sin(E)
+
+	// поворот плоскости орбиты в плоскость эклиптики
+	cosW, sinW := math.Cos(argPeri), math.Sin(argPeri)
+	cosN, sinN := math.Cos(node), math.Sin(node)
+	cosI, sinI := math.Cos(incl), math.Sin(incl)
+
+	x := (cosW*cosN-sinW*sinN*cosI)*xOrb + (-sinW*cosN-cosW*sinN*cosI)*yOrb
+	y := (cosW*sinN+sinW*cosN*cosI)*xOrb + (-sinW*sinN+cosW*cosN*cosI)*yOrb
+	z := sinW*sinI*xOrb + cosW*sinI*yOrb
+
+	return Vector{x * co.AstronomicalUnit, y * co.AstronomicalUnit, z * co.AstronomicalUnit}
+}
+
+// Distance возвращает расстояние от Земли до Марса на момент t, км.
+func Distance(t time.Time) float64 {
+	return Mars.CalcPosition(t).Sub(Earth.CalcPosition(t)).Len()
+}
+
+// CircularDistance - упрощенная модель: круговые орбиты в одной плоскости.
+// Удобна для объяснения, но ошибается на десятки миллионов километров
+// из-за вытянутой орбиты Марса.
+func CircularDistance(t time.Time) float64 {
+	T := CalcJulianCenturies(t)
+	earthAngle := deg2rad(Earth.Epoch.L + Earth.Rate.L*T)
+	marsAngle := deg2rad(Mars.Epoch.L + Mars.Rate.L*T)
+	earth := Vector{Earth.Epoch.A * math.Cos(earthAngle), Earth.Epoch.A * math.Sin(earthAngle), 0}
+	mars := Vector{Mars.Epoch.A * math.Cos(marsAngle), Mars.Epoch.A * math.Sin(marsAngle), 0}
+	return mars.Sub(earth).Len() * co.AstronomicalUnit
+}
+
+// CalcRequiredSpeed возвращает скорость (км/с), с которой нужно лететь по прямой,
+// чтобы за travel добраться до Марса, если вылететь в момент t. Это вопрос из f13,
+// но с расстоянием на конкретную дату.
+func CalcRequiredSpeed(t time.Time, travel time.Duration) float64 {
+	return Distance(t) / travel.Seconds()
+}
+
+// solveKepler решает уравнение Кеплера E - e*sin(E) = M методом Ньютона.
+func solveKepler(meanAnomaly, e float64) float64 {
+	E := meanAnomaly + e*math.Sin(meanAnomaly)
+	for range 50 {
+		delta := (E - e*math.Sin(E) - meanAnomaly) / (1 - e*math.Cos(E))
+		E -= delta
+		if math.Abs(delta) < 1e-12 {
+			break
+		}
+	}
+	return E
+}
+
+// deg2rad переводит градусы в радианы.
+func deg2rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// normalizeAngle приводит угол к диапазону [-π, π).
+func normalizeAngle(rad float64) float64 {
+	rad = math.Mod(rad+math.Pi, 2*math.Pi)
+	if rad < 0 {
+		rad += 2 * math.Pi
+	}
+	return rad - math.Pi
+}
@@ -0,0 +1,129 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// closeTo проверяет, что got отличается от want не больше чем на долю tolerance.
+func closeTo(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= want*tolerance
+}
+
+// TestDistance сверяет модель с опубликованными расстояниями (JPL Horizons)
+// в противостояниях и соединениях Марса.
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want float64 // км
+	}{
+		{"великое противостояние 2003", time.Date(2003, time.August, 27, 9, 51, 0, 0, time.UTC), 55_758_006},
+		{"противостояние 2018", time.Date(2018, time.July, 31, 7, 50, 0, 0, time.UTC), 57_590_630},
+		{"противостояние 2020", time.Date(2020, time.October, 6, 14, 18, 0, 0, time.UTC), 62_069_570},
+		{"соединение 2019", time.Date(2019, time.September, 2, 10, 42, 0, 0, time.UTC), 400_250_000},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.t); !closeTo(got, tt.want, 0.005) {
+			t.Errorf("%s: Distance = %.0f км, want %.0f км ± 0.5%%", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestClosestApproach ищет по часам ближайшее сближение в окрестности противостояния:
+// модель должна попасть в тот же день.
+func TestClosestApproach(t *testing.T) {
+	tests := []struct {
+		from, to time.Time
+		want     time.Time // день наибольшего сближения
+	}{
+		{time.Date(2003, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2003, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2003, 8, 27, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 10, 6, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		closest, minDistance := tt.from, Distance(tt.from)
+		for at := tt.from; at.Before(tt.to); at = at.Add(time.Hour) {
+			if d := Distance(at); d < minDistance {
+				closest, minDistance = at, d
+			}
+		}
+		if got := closest.Truncate(24 * time.Hour); !got.Equal(tt.want) {
+			t.Errorf("сближение между %s и %s: %v (%.0f км), want %s",
+				tt.from.Format(time.DateOnly), tt.to.Format(time.DateOnly), closest, minDistance, tt.want.Format(time.DateOnly))
+		}
+	}
+
+	// великие противостояния выходят за нижнюю границу f12 в 56 000 000 км
+	if d := Distance(time.Date(2003, 8, 27, 10, 0, 0, 0, time.UTC)); d >= 56_000_000 {
+		t.Errorf("Distance(2003-08-27) = %.0f км, want < 56 000 000", d)
+	}
+}
+
+// TestCircularDistance проверяет, что упрощенная модель ошибается в противостоянии 2003 года
+// на десятки миллионов километров, как и сказано в ее описании.
+func TestCircularDistance(t *testing.T) {
+	at := time.Date(2003, time.August, 27, 9, 51, 0, 0, time.UTC)
+	if diff := CircularDistance(at) - Distance(at); diff < 10_000_000 {
+		t.Errorf("CircularDistance - Distance = %.0f км, want > 10 000 000", diff)
+	}
+}
+
+func TestCalcJulianCenturies(t *testing.T) {
+	j2000 := time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t    time.Time
+		want float64
+	}{
+		{j2000, 0},
+		{j2000.Add(36525 * 24 * time.Hour), 1},
+		{j2000.Add(-36525 * 12 * time.Hour), -0.5},
+		// 1900 год не високосный: до него 36524 суток, а не столетие
+		{time.Date(1900, time.January, 1, 12, 0, 0, 0, time.UTC), -36524.0 / 36525},
+		// за пределами диапазона UnixNano
+		{time.Date(2500, time.January, 1, 12, 0, 0, 0, time.UTC), 182622.0 / 36525},
+	}
+	for _, tt := range tests {
+		if got := CalcJulianCenturies(tt.t); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("CalcJulianCenturies(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestCalcRequiredSpeed(t *testing.T) {
+	at := time.Date(2020, time.October, 6, 14, 18, 0, 0, time.UTC)
+	travel := 28 * 24 * time.Hour
+	if got, want := CalcRequiredSpeed(at, travel), Distance(at)/travel.Seconds(); got != want {
+		t.Errorf("CalcRequiredSpeed = %v, want %v", got, want)
+	}
+}
+
+func TestSolveKepler(t *testing.T) {
+	for _, e := range []float64{0, 0.0167, 0.0934, 0.5, 0.9} {
+		for _, m := range []float64{-3, -1, 0, 0.5, 2, math.Pi - 0.01} {
+			E := solveKepler(m, e)
+			if residual := E - e*math.Sin(E) - m; math.Abs(residual) > 1e-10 {
+				t.Errorf("solveKepler(%v, %v) = %v: невязка %v", m, e, E, residual)
+			}
+		}
+	}
+}
+
+func TestNormalizeAngle(t *testing.T) {
+	tests := []struct {
+		rad, want float64
+	}{
+		{0, 0},
+		{math.Pi, -math.Pi},
+		{-math.Pi, -math.Pi},
+		{3 * math.Pi / 2, -math.Pi / 2},
+		{-3 * math.Pi / 2, math.Pi / 2},
+		{7 * math.Pi, -math.Pi},
+		{1, 1},
+	}
+	for _, tt := range tests {
+		if got := normalizeAngle(tt.rad); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("normalizeAngle(%v) = %v, want %v", tt.rad, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Package piggybank - копилка из f54 и f69, повторенная много раз.
+//
+// Один прогон f54 показывает только одну случайную историю пополнений.
+// Simulate повторяет опыт тысячи раз для любого набора монет и валюты
+// и возвращает распределение числа монет, которое нужно, чтобы накопить цель.
+package piggybank
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	co "example/internal/constants"
+	"example/internal/money"
+)
+
+var (
+	// ErrEmptyCoins возвращается для набора без монет.
+	ErrEmptyCoins = errors.New("в наборе нет монет")
+	// ErrInvalidCoin возвращается для монеты с неположительным номиналом или в чужой валюте.
+	ErrInvalidCoin = errors.New("неверная монета")
+	// ErrInvalidTarget возвращается для неположительной цели.
+	ErrInvalidTarget = errors.New("цель должна быть положительной")
+	// ErrInvalidTrials возвращается для неположительного числа опытов.
+	ErrInvalidTrials = errors.New("число опытов должно быть положительным")
+)
+
+// CoinSet - монеты одной валюты, которые с равной вероятностью падают в копилку.
+type CoinSet struct {
+	Currency money.Currency
+	Coins    []money.Money
+}
+
+// DefaultCoinSets возвращает наборы монет по умолчанию:
+// никели, даймы и квотеры из f54 для USD, монеты евро от 5 центов и рубли.
+func DefaultCoinSets() []CoinSet {
+	return []CoinSet{
+		{Currency: money.USD, Coins: parseCoins("$0.05", "$0.10", "$0.25")},
+		{Currency: money.EUR, Coins: parseCoins("€0.05", "€0.10", "€0.20", "€0.50", "€1", "€2")},
+		{Currency: money.RUB, Coins: parseCoins("₽1", "₽2", "₽5", "₽10")},
+	}
+}
+
+// GetCoinSet возвращает набор монет по умолчанию для валюты c.
+func GetCoinSet(c money.Currency) (CoinSet, error) {
+	for _, set := range DefaultCoinSets() {
+		if set.Currency == c {
+			return set, nil
+		}
+	}
+	return CoinSet{}, fmt.Errorf("%w: нет монет для %s", money.ErrUnknownCurrency, c.Code)
+}
+
+// Validate проверяет, что в наборе есть монеты и все они положительные и в валюте набора.
+func (s CoinSet) Validate() error {
+	if len(s.Coins) == 0 {
+		return fmt.Errorf("%w: %s", ErrEmptyCoins, s.Currency.Code)
+	}
+	for _, coin := range s.Coins {
+		if coin.Currency() != s.Currency || coin.IsNegative() || coin.IsZero() {
+			return fmt.Errorf("%w: %v в наборе %s", ErrInvalidCoin, coin, s.Currency.Code)
+		}
+	}
+	return nil
+}
+
+// Trial - итог одного опыта: сколько монет бросили и сколько накопили.
+type Trial struct {
+	Deposits int
+	Balance  money.Money
+}
+
+// Fill бросает в пустую копилку случайные монеты из s, пока баланс меньше target, как в f54.
+func Fill(r *rand.Rand, s CoinSet, target money.Money) (Trial, error) {
+	if err := s.Validate(); err != nil {
+		return Trial{}, err
+	}
+	if err := validateTarget(s, target); err != nil {
+		return Trial{}, err
+	}
+	return fill(r, s, target)
+}
+
+// fill - Fill без проверки аргументов, для повторных опытов в Simulate.
+func fill(r *rand.Rand, s CoinSet, target money.Money) (Trial, error) {
+	trial := Trial{Balance: money.New(0, s.Currency)}
+	for {
+		cmp, err := trial.Balance.Cmp(target)
+		if err != nil {
+			return Trial{}, err
+		}
+		if cmp >= 0 {
+			return trial, nil
+		}
+		if trial.Balance, err = trial.Balance.Add(s.Coins[r.Intn(len(s.Coins))]); err != nil {
+			return Trial{}, err
+		}
+		trial.Deposits++
+	}
+}
+
+// validateTarget проверяет, что цель положительна и в валюте набора монет.
+func validateTarget(s CoinSet, target money.Money) error {
+	if target.Currency() != s.Currency {
+		return fmt.Errorf("%w: цель %v, монеты %s", money.ErrCurrencyMismatch, target, s.Currency.Code)
+	}
+	if target.IsNegative() || target.IsZero() {
+		return fmt.Errorf("%w: %v", ErrInvalidTarget, target)
+	}
+	return nil
+}
+
+// Bucket - столбец гистограммы: сколько опытов закончились за From..To монет включительно.
+type Bucket struct {
+	From  int
+	To    int
+	Count int
+}
+
+// Stats - распределение числа монет, нужных для цели, по всем опытам.
+type Stats struct {
+	Target      money.Money
+	Trials      int
+	Min         int
+	Max         int
+	Median      int
+	P90         int // 90% опытов закончились не больше чем за P90 монет
+	Mean        float64
+	StdDev      float64
+	MeanBalance money.Money // средний итоговый баланс: последняя монета обычно перебрасывает цель
+	Histogram   []Bucket
+}
+
+// Simulate проводит trials опытов Fill и собирает статистику.
+// Для одного и того же зерна r результат всегда одинаковый.
+func Simulate(r *rand.Rand, s CoinSet, target money.Money, trials int) (Stats, error) {
+	if trials <= 0 {
+		return Stats{}, fmt.Errorf("%w: %d", ErrInvalidTrials, trials)
+	}
+	if err := s.Validate(); err != nil {
+		return Stats{}, err
+	}
+	if err := validateTarget(s, target); err != nil {
+		return Stats{}, err
+	}
+
+	deposits := make([]int, 0, trials)
+	total := money.New(0, s.Currency)
+	for i := 0; i < trials; i++ {
+		trial, err := fill(r, s, target)
+		if err != nil {
+			return Stats{}, err
+		}
+		deposits = append(deposits, trial.Deposits)
+		if total, err = total.Add(trial.Balance); err != nil {
+			return Stats{}, err
+		}
+	}
+
+	meanBalance, err := total.MulRatio(1, int64(trials))
+	if err != nil {
+		return Stats{}, err
+	}
+	sort.Ints(deposits)
+	mean, stdDev := calcMeanStdDev(deposits)
+	return Stats{
+		Target:      target,
+		Trials:      trials,
+		Min:         deposits[0],
+		Max:         deposits[len(deposits)-1],
+		Median:      calcPercentile(deposits, 50),
+		P90:         calcPercentile(deposits, 90),
+		Mean:        mean,
+		StdDev:      stdDev,
+		MeanBalance: meanBalance,
+		Histogram:   calcHistogram(deposits, co.HistogramBuckets),
+	}, nil
+}
+
+// calcMeanStdDev возвращает среднее и стандартное отклонение выборки.
+func calcMeanStdDev(values []int) (float64, float64) {
+	sum := 0.0
+	for _, v := range values {
+		sum += float64(v)
+	}
+	mean := sum / float64(len(values))
+
+	squares := 0.0
+	for _, v := range values {
+		squares += (float64(v) - mean) * (float64(v) - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
+
+// calcPercentile возвращает p-й процентиль отсортированной выборки методом ближайшего ранга.
+func calcPercentile(sorted []int, p int) int {
+	rank := (p*len(sorted) + 99) / 100 // округление вверх
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// calcHistogram делит диапазон отсортированной выборки на buckets столбцов одинаковой ширины.
+func calcHistogram(sorted []int, buckets int) []Bucket {
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	width := (hi - lo + buckets) / buckets // округление вверх, ширина не меньше 1
+
+	var histogram []Bucket
+	for from := lo; from <= hi; from += width {
+		histogram = append(histogram, Bucket{From: from, To: from + width - 1})
+	}
+	for _, v := range sorted {
+		histogram[(v-lo)/width].Count++
+	}
+	return histogram
+}
+
+// WriteReport печатает статистику и гистограмму из символов #.
+func WriteReport(w io.Writer, s Stats) error {
+	_, err := fmt.Fprintf(w, "Цель:           %v\nОпытов:         %d\n", s.Target, s.Trials)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Монет до цели:  мин %d, медиана %d, 90%% %d, макс %d\n", s.Min, s.Median, s.P90, s.Max)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Среднее:        %.2f ± %.2f\nСредний итог:   %v\n", s.Mean, s.StdDev, s.MeanBalance)
+	if err != nil {
+		return err
+	}
+
+	peak := 0
+	for _, b := range s.Histogram {
+		peak = max(peak, b.Count)
+	}
+	for _, b := range s.Histogram {
+		bar := strings.Repeat("#", b.Count*co.HistogramWidth/peak)
+		if _, err := fmt.Fprintf(w, "%5d-%-5d %-*s %d\n", b.From, b.To, co.HistogramWidth, bar, b.Count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseCoins разбирает номиналы монет, заданные в коде.
+func parseCoins(values ...string) []money.Money {
+	coins := make([]money.Money, 0, len(values))
+	for _, v := range values {
+		coins = append(coins, money.MustParse(v))
+	}
+	return coins
+}
@@ -0,0 +1,231 @@
+package piggybank
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	co "example/internal/constants"
+	"example/internal/money"
+)
+
+func newRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+func TestDefaultCoinSets(t *testing.T) {
+	for _, set := range DefaultCoinSets() {
+		if err := set.Validate(); err != nil {
+			t.Errorf("%s: %v", set.Currency.Code, err)
+		}
+		if got, err := GetCoinSet(set.Currency); err != nil || !reflect.DeepEqual(got, set) {
+			t.Errorf("GetCoinSet(%s) = %v, %v", set.Currency.Code, got, err)
+		}
+	}
+	if _, err := GetCoinSet(money.Currency{Code: "JPY", Symbol: "¥"}); !errors.Is(err, money.ErrUnknownCurrency) {
+		t.Errorf("GetCoinSet(JPY): err = %v, want ErrUnknownCurrency", err)
+	}
+}
+
+func TestFill(t *testing.T) {
+	usd, _ := GetCoinSet(money.USD)
+	target := money.MustParse("$20.00")
+	for seed := int64(1); seed <= 20; seed++ {
+		trial, err := Fill(newRand(seed), usd, target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// цель достигнута, и без последней монеты ее еще не было: квотер - самая крупная монета
+		overshoot, err := trial.Balance.Sub(target)
+		if err != nil || overshoot.IsNegative() || overshoot.Amount() >= 25 {
+			t.Errorf("зерно %d: баланс %v при цели %v", seed, trial.Balance, target)
+		}
+		// не меньше 80 квотеров и не больше 400 никелей
+		if trial.Deposits < 80 || trial.Deposits > 400 {
+			t.Errorf("зерно %d: %d монет", seed, trial.Deposits)
+		}
+		again, _ := Fill(newRand(seed), usd, target)
+		if again != trial {
+			t.Errorf("зерно %d: повтор дал %+v, want %+v", seed, again, trial)
+		}
+	}
+
+	// с одной монетой случайности нет
+	nickels := CoinSet{Currency: money.USD, Coins: parseCoins("$0.05")}
+	if trial, err := Fill(newRand(1), nickels, money.MustParse("$1.02")); err != nil || trial.Deposits != 21 || trial.Balance.String() != "$1.05" {
+		t.Errorf("никели до $1.02: %+v, %v, want 21 монета и $1.05", trial, err)
+	}
+}
+
+func TestFillErrors(t *testing.T) {
+	usd, _ := GetCoinSet(money.USD)
+	tests := []struct {
+		name   string
+		set    CoinSet
+		target money.Money
+		want   error
+	}{
+		{"нет монет", CoinSet{Currency: money.USD}, money.MustParse("$1.00"), ErrEmptyCoins},
+		{"монета в евро", CoinSet{Currency: money.USD, Coins: parseCoins("$0.05", "€0.10")}, money.MustParse("$1.00"), ErrInvalidCoin},
+		{"нулевая монета", CoinSet{Currency: money.USD, Coins: parseCoins("$0.05", "$0")}, money.MustParse("$1.00"), ErrInvalidCoin},
+		{"отрицательная монета", CoinSet{Currency: money.USD, Coins: parseCoins("-$0.05")}, money.MustParse("$1.00"), ErrInvalidCoin},
+		{"цель в рублях", usd, money.MustParse("₽100"), money.ErrCurrencyMismatch},
+		{"нулевая цель", usd, money.MustParse("$0"), ErrInvalidTarget},
+		{"отрицательная цель", usd, money.MustParse("-$1"), ErrInvalidTarget},
+	}
+	for _, tt := range tests {
+		if _, err := Fill(newRand(1), tt.set, tt.target); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Fill: err = %v, want %v", tt.name, err, tt.want)
+		}
+		if _, err := Simulate(newRand(1), tt.set, tt.target, 10); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Simulate: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	for _, trials := range []int{0, -1} {
+		if _, err := Simulate(newRand(1), usd, money.MustParse("$1.00"), trials); !errors.Is(err, ErrInvalidTrials) {
+			t.Errorf("Simulate(%d опытов): err = %v, want ErrInvalidTrials", trials, err)
+		}
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	const trials = 2000
+	target := money.MustParse("$20.00")
+	usd, _ := GetCoinSet(money.USD)
+	stats, err := Simulate(newRand(42), usd, target, trials)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := Simulate(newRand(42), usd, target, trials)
+	if err != nil || !reflect.DeepEqual(again, stats) {
+		t.Fatalf("Simulate с тем же зерном = %+v, %v, want %+v", again, err, stats)
+	}
+
+	if stats.Target != target || stats.Trials != trials {
+		t.Errorf("Target, Trials = %v, %d", stats.Target, stats.Trials)
+	}
+	if !(stats.Min <= stats.Median && stats.Median <= stats.P90 && stats.P90 <= stats.Max) {
+		t.Errorf("мин %d, медиана %d, 90%% %d, макс %d не по порядку", stats.Min, stats.Median, stats.P90, stats.Max)
+	}
+	// средний номинал (5 + 10 + 25) / 3 = 13⅓ цента, до $20.00 нужно около 150 монет
+	if math.Abs(stats.Mean-150) > 5 || stats.StdDev <= 0 || stats.StdDev > 20 {
+		t.Errorf("Mean = %.2f ± %.2f, want около 150", stats.Mean, stats.StdDev)
+	}
+	if cmp, _ := stats.MeanBalance.Cmp(target); cmp < 0 || stats.MeanBalance.Amount() >= target.Amount()+25 {
+		t.Errorf("MeanBalance = %v", stats.MeanBalance)
+	}
+
+	total := 0
+	for i, b := range stats.Histogram {
+		total += b.Count
+		if i > 0 && b.From != stats.Histogram[i-1].To+1 {
+			t.Errorf("столбец %d начинается с %d после %d", i, b.From, stats.Histogram[i-1].To)
+		}
+	}
+	first, last := stats.Histogram[0], stats.Histogram[len(stats.Histogram)-1]
+	if total != trials || len(stats.Histogram) > co.HistogramBuckets || first.From != stats.Min || last.To < stats.Max {
+		t.Errorf("гистограмма %+v: %d опытов, want %d от %d до %d", stats.Histogram, total, trials, stats.Min, stats.Max)
+	}
+
+	// с одной монетой все опыты одинаковы
+	nickels := CoinSet{Currency: money.USD, Coins: parseCoins("$0.05")}
+	stats, err = Simulate(newRand(1), nickels, money.MustParse("$1.00"), 50)
+	want := Stats{
+		Target: money.MustParse("$1.00"), Trials: 50,
+		Min: 20, Max: 20, Median: 20, P90: 20, Mean: 20, StdDev: 0,
+		MeanBalance: money.MustParse("$1.00"),
+		Histogram:   []Bucket{{From: 20, To: 20, Count: 50}},
+	}
+	if err != nil || !reflect.DeepEqual(stats, want) {
+		t.Errorf("никели: %+v, %v, want %+v", stats, err, want)
+	}
+}
+
+func TestCalcMeanStdDev(t *testing.T) {
+	tests := []struct {
+		values       []int
+		mean, stdDev float64
+	}{
+		{[]int{7}, 7, 0},
+		{[]int{1, 3}, 2, 1},
+		{[]int{2, 4, 4, 4, 5, 5, 7, 9}, 5, 2},
+	}
+	for _, tt := range tests {
+		mean, stdDev := calcMeanStdDev(tt.values)
+		if mean != tt.mean || math.Abs(stdDev-tt.stdDev) > 1e-12 {
+			t.Errorf("calcMeanStdDev(%v) = %v, %v, want %v, %v", tt.values, mean, stdDev, tt.mean, tt.stdDev)
+		}
+	}
+}
+
+func TestCalcPercentile(t *testing.T) {
+	ten := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		sorted []int
+		p      int
+		want   int
+	}{
+		{ten, 0, 1},
+		{ten, 10, 1},
+		{ten, 11, 2},
+		{ten, 50, 5},
+		{ten, 90, 9},
+		{ten, 91, 10},
+		{ten, 100, 10},
+		{[]int{4}, 50, 4},
+		{[]int{1, 2, 3}, 50, 2},
+	}
+	for _, tt := range tests {
+		if got := calcPercentile(tt.sorted, tt.p); got != tt.want {
+			t.Errorf("calcPercentile(%v, %d) = %d, want %d", tt.sorted, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestCalcHistogram(t *testing.T) {
+	tests := []struct {
+		sorted  []int
+		buckets int
+		want    []Bucket
+	}{
+		{[]int{7, 7}, 10, []Bucket{{7, 7, 2}}},
+		{[]int{1, 1, 2, 5, 10}, 3, []Bucket{{1, 4, 3}, {5, 8, 1}, {9, 12, 1}}},
+		{[]int{1, 2, 3}, 10, []Bucket{{1, 1, 1}, {2, 2, 1}, {3, 3, 1}}},
+		{[]int{0, 9}, 5, []Bucket{{0, 1, 1}, {2, 3, 0}, {4, 5, 0}, {6, 7, 0}, {8, 9, 1}}},
+	}
+	for _, tt := range tests {
+		if got := calcHistogram(tt.sorted, tt.buckets); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("calcHistogram(%v, %d) = %v, want %v", tt.sorted, tt.buckets, got, tt.want)
+		}
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	stats := Stats{
+		Target: money.MustParse("$1.00"), Trials: 30,
+		Min: 4, Max: 11, Median: 6, P90: 9, Mean: 6.5, StdDev: 1.25,
+		MeanBalance: money.MustParse("$1.07"),
+		Histogram:   []Bucket{{4, 7, 20}, {8, 11, 10}},
+	}
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, stats); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"Цель:           $1.00",
+		"Опытов:         30",
+		"Монет до цели:  мин 4, медиана 6, 90% 9, макс 11",
+		"Среднее:        6.50 ± 1.25",
+		"Средний итог:   $1.07",
+		"    4-7     " + strings.Repeat("#", co.HistogramWidth) + " 20",
+		"    8-11    " + strings.Repeat("#", co.HistogramWidth/2) + strings.Repeat(" ", co.HistogramWidth/2) + " 10",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("WriteReport:\n%s\nwant:\n%s", got, want)
+	}
+}
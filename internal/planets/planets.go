@@ -0,0 +1,65 @@
+// Package planets считает вес и возраст человека на телах Солнечной системы.
+// Обобщает f4 и f5: вместо чисел 0.3783 и 687 используется таблица тел.
+package planets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	co "example/internal/constants"
+)
+
+// ErrUnknownBody возвращается, если тела нет в таблице.
+var ErrUnknownBody = errors.New("неизвестное небесное тело")
+
+// Body - небесное тело Солнечной системы.
+type Body struct {
+	Name          string  // название по-русски
+	EnglishName   string  // название по-английски
+	GravityRatio  float64 // ускорение свободного падения на поверхности относительно земного
+	OrbitalPeriod float64 // сидерический период обращения вокруг Солнца, земных суток
+}
+
+// bodies - тела в порядке удаления от Солнца.
+var bodies = []Body{
+	{"Меркурий", "Mercury", 0.3780, 87.969},
+	{"Венера", "Venus", 0.9070, 224.701},
+	{"Земля", "Earth", 1.0000, 365.256},
+	{"Марс", "Mars", 0.3783, 686.980},
+	{"Церера", "Ceres", 0.0290, 1_681.63},
+	{"Юпитер", "Jupiter", 2.5280, 4_332.59},
+	{"Сатурн", "Saturn", 1.0650, 10_759.22},
+	{"Уран", "Uranus", 0.8860, 30_688.5},
+	{"Нептун", "Neptune", 1.1370, 60_182.0},
+	{"Плутон", "Pluto", 0.0630, 90_560.0},
+}
+
+// GetBodies возвращает копию таблицы тел в порядке удаления от Солнца.
+func GetBodies() []Body {
+	return append([]Body(nil), bodies...)
+}
+
+// GetBody ищет тело по русскому или английскому названию без учета регистра.
+func GetBody(name string) (Body, error) {
+	for _, b := range bodies {
+		if strings.EqualFold(b.Name, name) || strings.EqualFold(b.EnglishName, name) {
+			return b, nil
+		}
+	}
+	return Body{}, fmt.Errorf("%w: %q", ErrUnknownBody, name)
+}
+
+// CalcWeight возвращает вес на поверхности тела b для человека весом earthWeight на Земле.
+// Как и в f4, "вес" здесь в килограммах: показание земных весов на другом теле.
+func CalcWeight(earthWeight float64, b Body) float64 {
+	return earthWeight * b.GravityRatio
+}
+
+// CalcAge возвращает возраст в годах тела b на момент now для родившегося birth:
+// сколько оборотов вокруг Солнца тело сделало за прожитое время.
+func CalcAge(birth, now time.Time, b Body) float64 {
+	days := now.Sub(birth).Hours() / co.HoursPerDay
+	return days / b.OrbitalPeriod
+}
@@ -0,0 +1,110 @@
+package planets
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func mustGetBody(t *testing.T, name string) Body {
+	t.Helper()
+	b, err := GetBody(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestGetBody(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Марс", "Марс"},
+		{"марс", "Марс"},
+		{"Mars", "Марс"},
+		{"MARS", "Марс"},
+		{"земля", "Земля"},
+		{"Earth", "Земля"},
+		{"плутон", "Плутон"},
+	}
+	for _, tt := range tests {
+		if got, err := GetBody(tt.name); err != nil || got.Name != tt.want {
+			t.Errorf("GetBody(%q) = %v, %v, want %s", tt.name, got, err, tt.want)
+		}
+	}
+	for _, name := range []string{"", "Вулкан", "Mars ", "Луна"} {
+		if got, err := GetBody(name); !errors.Is(err, ErrUnknownBody) || got != (Body{}) {
+			t.Errorf("GetBody(%q) = %v, %v, want ErrUnknownBody", name, got, err)
+		}
+	}
+}
+
+func TestGetBodies(t *testing.T) {
+	all := GetBodies()
+	if len(all) != 10 || all[0].Name != "Меркурий" || all[len(all)-1].Name != "Плутон" {
+		t.Fatalf("GetBodies = %v", all)
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].OrbitalPeriod <= all[i-1].OrbitalPeriod {
+			t.Errorf("%s обращается быстрее, чем %s, хотя дальше от Солнца", all[i].Name, all[i-1].Name)
+		}
+	}
+	all[0].Name = "изменено"
+	if GetBodies()[0].Name != "Меркурий" {
+		t.Error("GetBodies отдает таблицу, а не копию")
+	}
+}
+
+func TestCalcWeight(t *testing.T) {
+	tests := []struct {
+		weight float64
+		body   string
+		want   float64
+	}{
+		{75, "Земля", 75},
+		{0, "Земля", 0},
+		// f4: 55 кг на Марсе
+		{55, "Марс", 20.8065},
+		{100, "Марс", 37.83},
+		{100, "Юпитер", 252.8},
+		{100, "Церера", 2.9},
+	}
+	for _, tt := range tests {
+		if got := CalcWeight(tt.weight, mustGetBody(t, tt.body)); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("CalcWeight(%v, %s) = %v, want %v", tt.weight, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestCalcAge(t *testing.T) {
+	birth := time.Date(1990, time.March, 15, 6, 0, 0, 0, time.UTC)
+	days := func(n float64) time.Time { return birth.Add(time.Duration(n * 24 * float64(time.Hour))) }
+	tests := []struct {
+		now  time.Time
+		body string
+		want float64
+	}{
+		{birth, "Земля", 0},
+		{days(365.256), "Земля", 1},
+		{days(10 * 365.256), "Земля", 10},
+		{days(686.98), "Марс", 1},
+		// f5: 41 земной год на Марсе
+		{days(41 * 365.256), "Марс", 41 * 365.256 / 686.98},
+		{days(87.969 * 4), "Меркурий", 4},
+		{days(-365.256), "Земля", -1},
+	}
+	for _, tt := range tests {
+		if got := CalcAge(birth, tt.now, mustGetBody(t, tt.body)); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("CalcAge(%v, %s) = %v, want %v", tt.now.Sub(birth), tt.body, got, tt.want)
+		}
+	}
+
+	// на Марсе год почти вдвое длиннее земного
+	now := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	earth, mars := CalcAge(birth, now, mustGetBody(t, "Earth")), CalcAge(birth, now, mustGetBody(t, "Mars"))
+	if ratio := earth / mars; math.Abs(ratio-686.98/365.256) > 1e-9 {
+		t.Errorf("возраст на Земле / на Марсе = %v, want %v", ratio, 686.98/365.256)
+	}
+}
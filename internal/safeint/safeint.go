@@ -0,0 +1,115 @@
+// Package safeint - целочисленная арифметика с проверкой переполнения для примеров f63-f67.
+//
+// В Go целые числа при выходе из диапазона молча переполняются: 255 + 1 в uint8 дает 0 (f63),
+// 127 + 3 в int8 дает -126 (f65), а 65535 + 1 в uint16 - снова 0 (f67). Для счетчиков
+// и работы с байтами пакет предлагает три варианта каждой операции:
+//
+//   - Add, Sub, Mul, Neg и Convert возвращают ErrOverflow вместо неверного результата;
+//   - AddSat, SubSat, MulSat, NegSat и ConvertSat упираются в границу типа: 255 + 1 = 255;
+//   - AddWrap, SubWrap, MulWrap, NegWrap и ConvertWrap переполняются, как встроенные операторы,
+//     но по имени видно, что это сделано намеренно.
+//
+// Функции обобщенные и работают со всеми целыми типами, в том числе с типами на их основе.
+package safeint
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// ErrOverflow возвращается, если результат не помещается в тип.
+var ErrOverflow = errors.New("целочисленное переполнение")
+
+// Signed - знаковые целые типы.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned - беззнаковые целые типы.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer - все целые типы.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// BitSize возвращает размер типа T в битах: 8 для uint8, 64 для int на 64-битной платформе.
+func BitSize[T Integer]() int {
+	var zero T
+	return int(unsafe.Sizeof(zero)) * 8
+}
+
+// IsSigned проверяет, знаковый ли тип T.
+func IsSigned[T Integer]() bool {
+	var zero T
+	return zero-1 < 0 // у беззнакового типа 0 - 1 переполняется в наибольшее значение
+}
+
+// Min возвращает наименьшее значение типа T: -128 для int8, 0 для беззнаковых типов.
+func Min[T Integer]() T {
+	if !IsSigned[T]() {
+		return 0
+	}
+	return T(1) << (BitSize[T]() - 1) // 1000...0 в дополнительном коде
+}
+
+// Max возвращает наибольшее значение типа T: 127 для int8, 255 для uint8.
+func Max[T Integer]() T {
+	return ^Min[T]() // у беззнаковых типов ^0 - все единицы
+}
+
+// Add возвращает a + b или ErrOverflow, как для 255 + 1 в uint8.
+func Add[T Integer](a, b T) (T, error) {
+	sum := a + b
+	// при положительном b сумма должна вырасти, при отрицательном - уменьшиться
+	if (b >= 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, fmt.Errorf("%w: %v + %v в %T", ErrOverflow, a, b, a)
+	}
+	return sum, nil
+}
+
+// Sub возвращает a - b или ErrOverflow, как для 0 - 1 в uint8.
+func Sub[T Integer](a, b T) (T, error) {
+	diff := a - b
+	if (b >= 0 && diff > a) || (b < 0 && diff < a) {
+		return 0, fmt.Errorf("%w: %v - %v в %T", ErrOverflow, a, b, a)
+	}
+	return diff, nil
+}
+
+// Mul возвращает a * b или ErrOverflow.
+func Mul[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	product := a * b
+	// деление не замечает только одного переполнения: Min * -1 = Min, и Min / -1 тоже Min
+	isMinByMinusOne := IsSigned[T]() && ((a == ^T(0) && b == Min[T]()) || (b == ^T(0) && a == Min[T]()))
+	if product/b != a || isMinByMinusOne {
+		return 0, fmt.Errorf("%w: %v * %v в %T", ErrOverflow, a, b, a)
+	}
+	return product, nil
+}
+
+// Neg возвращает -a. Переполняется отрицание наименьшего знакового значения (-(-128) в int8)
+// и любого беззнакового, кроме нуля.
+func Neg[T Integer](a T) (T, error) {
+	if (IsSigned[T]() && a == Min[T]()) || (!IsSigned[T]() && a != 0) {
+		return 0, fmt.Errorf("%w: -(%v) в %T", ErrOverflow, a, a)
+	}
+	return -a, nil
+}
+
+// Convert переводит v в тип To или возвращает ErrOverflow, если значение в нем не помещается:
+// Convert[uint8](300) и Convert[uint32](-1) - ошибки, Convert[int8](100) - нет.
+func Convert[To, From Integer](v From) (To, error) {
+	r := To(v)
+	// значение не изменилось при переводе туда и обратно и не поменяло знак
+	if From(r) != v || (r < 0) != (v < 0) {
+		return 0, fmt.Errorf("%w: %v (%T) не помещается в %T", ErrOverflow, v, v, r)
+	}
+	return r, nil
+}
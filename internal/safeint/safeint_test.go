@@ -0,0 +1,390 @@
+package safeint
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+var isExhaustive = flag.Bool("exhaustive", false, "перебрать все 2^32 пары 16-битных чисел (около двух часов)")
+
+// check сравнивает результат операции с точным значением want, посчитанным в int:
+// если want помещается в T, ошибки быть не должно, иначе нужна ErrOverflow.
+// Возвращает описание расхождения или пустую строку; t.Helper в цикле на миллиарды пар слишком дорог.
+func check[T Integer](op string, a, b T, got T, err error, want int) string {
+	isFit := want >= int(Min[T]()) && want <= int(Max[T]())
+	switch {
+	case isFit && err != nil:
+		return fmt.Sprintf("%v %s %v в %T: ошибка %v, want %d", a, op, b, a, err, want)
+	case isFit && int(got) != want:
+		return fmt.Sprintf("%v %s %v в %T = %v, want %d", a, op, b, a, got, want)
+	case !isFit && !errors.Is(err, ErrOverflow):
+		return fmt.Sprintf("%v %s %v в %T = %v, %v, want ErrOverflow (точно %d)", a, op, b, a, got, err, want)
+	}
+	return ""
+}
+
+// checkOps проверяет Add, Sub и Mul для пары a, b по арифметике int.
+func checkOps[T Integer](a, b T) string {
+	sum, err := Add(a, b)
+	if msg := check("+", a, b, sum, err, int(a)+int(b)); msg != "" {
+		return msg
+	}
+	diff, err := Sub(a, b)
+	if msg := check("-", a, b, diff, err, int(a)-int(b)); msg != "" {
+		return msg
+	}
+	product, err := Mul(a, b)
+	return check("*", a, b, product, err, int(a)*int(b))
+}
+
+// sweep проверяет Add, Sub и Mul для всех пар as и bs.
+func sweep[T Integer](t *testing.T, as, bs []T) {
+	t.Helper()
+	for _, a := range as {
+		for _, b := range bs {
+			if msg := checkOps(a, b); msg != "" {
+				t.Fatal(msg)
+			}
+		}
+	}
+}
+
+// all возвращает все значения типа T; только для 8 и 16 бит.
+func all[T int8 | uint8 | int16 | uint16]() []T {
+	var values []T
+	for v := int(Min[T]()); v <= int(Max[T]()); v++ {
+		values = append(values, T(v))
+	}
+	return values
+}
+
+// dense возвращает часть значений 16-битного типа, на которой переполнение проверяется
+// для каждого первого операнда: числа у нуля, у границ, у половины границ и у ±256, а также каждое 4093-е.
+func dense[T int16 | uint16]() []T {
+	var values []T
+	for v := int(Min[T]()); v <= int(Max[T]()); v++ {
+		near := min(abs(v), abs(v-int(Min[T]())), abs(v-int(Max[T]())), abs(v-int(Max[T]()/2)), abs(v-int(Min[T]()/2)), abs(abs(v)-256))
+		if near <= 4 || v%4093 == 0 {
+			values = append(values, T(v))
+		}
+	}
+	return values
+}
+
+func abs(v int) int { return max(v, -v) }
+
+func TestExhaustive8(t *testing.T) {
+	sweep(t, all[int8](), all[int8]())
+	sweep(t, all[uint8](), all[uint8]())
+}
+
+// TestExhaustive16 проверяет каждое 16-битное число в паре с плотной выборкой вторых операндов,
+// а с флагом -exhaustive - все 2^32 пары: go test ./internal/safeint -run 16 -exhaustive -timeout 3h.
+func TestExhaustive16(t *testing.T) {
+	if testing.Short() {
+		sweep(t, boundary[int16](), boundary[int16]())
+		sweep(t, boundary[uint16](), boundary[uint16]())
+		return
+	}
+	if *isExhaustive {
+		sweep(t, all[int16](), all[int16]())
+		sweep(t, all[uint16](), all[uint16]())
+		return
+	}
+	sweep(t, all[int16](), dense[int16]())
+	sweep(t, dense[int16](), all[int16]())
+	sweep(t, all[uint16](), dense[uint16]())
+	sweep(t, dense[uint16](), all[uint16]())
+}
+
+// boundary возвращает значения типа T у нуля, у границ и у половины границ,
+// где переполняются сложение и умножение.
+func boundary[T Integer]() []T {
+	var values []T
+	add := func(center T) {
+		for d := T(0); d < 4; d++ {
+			if v, err := Sub(center, d); err == nil {
+				values = append(values, v)
+			}
+			if v, err := Add(center, d); err == nil && d > 0 {
+				values = append(values, v)
+			}
+		}
+	}
+	for _, center := range []T{0, Min[T](), Max[T](), Max[T]() / 2, Min[T]() / 2, 1 << (BitSize[T]()/2 - 1), 1 << (BitSize[T]() / 2)} {
+		add(center)
+	}
+	return values
+}
+
+// TestBoundary проверяет 32- и 64-битные типы на значениях у границ.
+// Произведение uint32 и результаты 64-битных операций не помещаются в int,
+// поэтому они проверяются в checkOps64.
+func TestBoundary(t *testing.T) {
+	sweep(t, boundary[int32](), boundary[int32]())
+
+	checkOps64(t, boundary[uint32]())
+	checkOps64(t, boundary[int64]())
+	checkOps64(t, boundary[uint64]())
+	checkOps64(t, boundary[int]())
+	checkOps64(t, boundary[uint]())
+}
+
+// toBig переводит значение любого целого типа в big.Int без потерь.
+func toBig[T Integer](v T) *big.Int {
+	if IsSigned[T]() {
+		return big.NewInt(int64(v))
+	}
+	return new(big.Int).SetUint64(uint64(v))
+}
+
+// checkOps64 проверяет Add, Sub и Mul для всех пар values, как check, но точное значение
+// считается в big.Int: для 64-битных типов его не вместить ни в int, ни в float64.
+func checkOps64[T uint32 | int64 | uint64 | int | uint](t *testing.T, values []T) {
+	t.Helper()
+	lo, hi := toBig(Min[T]()), toBig(Max[T]())
+	for _, a := range values {
+		for _, b := range values {
+			x, y := toBig(a), toBig(b)
+			sum, sumErr := Add(a, b)
+			diff, diffErr := Sub(a, b)
+			product, productErr := Mul(a, b)
+			for _, c := range []struct {
+				op    string
+				got   T
+				err   error
+				exact *big.Int
+			}{
+				{"+", sum, sumErr, new(big.Int).Add(x, y)},
+				{"-", diff, diffErr, new(big.Int).Sub(x, y)},
+				{"*", product, productErr, new(big.Int).Mul(x, y)},
+			} {
+				isFit := c.exact.Cmp(lo) >= 0 && c.exact.Cmp(hi) <= 0
+				switch {
+				case isFit && c.err != nil:
+					t.Fatalf("%v %s %v в %T: ошибка %v, want %v", a, c.op, b, a, c.err, c.exact)
+				case isFit && toBig(c.got).Cmp(c.exact) != 0:
+					t.Fatalf("%v %s %v в %T = %v, want %v", a, c.op, b, a, c.got, c.exact)
+				case !isFit && !errors.Is(c.err, ErrOverflow):
+					t.Fatalf("%v %s %v в %T = %v, %v, want ErrOverflow (точно %v)", a, c.op, b, a, c.got, c.err, c.exact)
+				}
+			}
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max any
+		wantMin  any
+		wantMax  any
+		bits     int
+		isSigned bool
+	}{
+		{"int8", Min[int8](), Max[int8](), int8(math.MinInt8), int8(math.MaxInt8), 8, true},
+		{"uint8", Min[uint8](), Max[uint8](), uint8(0), uint8(math.MaxUint8), 8, false},
+		{"int16", Min[int16](), Max[int16](), int16(math.MinInt16), int16(math.MaxInt16), 16, true},
+		{"uint16", Min[uint16](), Max[uint16](), uint16(0), uint16(math.MaxUint16), 16, false},
+		{"int32", Min[int32](), Max[int32](), int32(math.MinInt32), int32(math.MaxInt32), 32, true},
+		{"uint32", Min[uint32](), Max[uint32](), uint32(0), uint32(math.MaxUint32), 32, false},
+		{"int64", Min[int64](), Max[int64](), int64(math.MinInt64), int64(math.MaxInt64), 64, true},
+		{"uint64", Min[uint64](), Max[uint64](), uint64(0), uint64(math.MaxUint64), 64, false},
+	}
+	sizes := map[string]struct {
+		bits     int
+		isSigned bool
+	}{
+		"int8": {BitSize[int8](), IsSigned[int8]()}, "uint8": {BitSize[uint8](), IsSigned[uint8]()},
+		"int16": {BitSize[int16](), IsSigned[int16]()}, "uint16": {BitSize[uint16](), IsSigned[uint16]()},
+		"int32": {BitSize[int32](), IsSigned[int32]()}, "uint32": {BitSize[uint32](), IsSigned[uint32]()},
+		"int64": {BitSize[int64](), IsSigned[int64]()}, "uint64": {BitSize[uint64](), IsSigned[uint64]()},
+	}
+	for _, tt := range tests {
+		if tt.min != tt.wantMin || tt.max != tt.wantMax {
+			t.Errorf("%s: Min, Max = %v, %v, want %v, %v", tt.name, tt.min, tt.max, tt.wantMin, tt.wantMax)
+		}
+		if s := sizes[tt.name]; s.bits != tt.bits || s.isSigned != tt.isSigned {
+			t.Errorf("%s: BitSize, IsSigned = %d, %t, want %d, %t", tt.name, s.bits, s.isSigned, tt.bits, tt.isSigned)
+		}
+	}
+}
+
+// TestNeg проверяет отрицание всех 8- и 16-битных значений.
+func TestNeg(t *testing.T) {
+	testNeg(t, all[int8]())
+	testNeg(t, all[uint8]())
+	testNeg(t, all[int16]())
+	testNeg(t, all[uint16]())
+	testNeg(t, boundary[int64]())
+	testNeg(t, boundary[uint64]())
+}
+
+func testNeg[T Integer](t *testing.T, values []T) {
+	t.Helper()
+	for _, a := range values {
+		neg, err := Neg(a)
+		isOverflow := (IsSigned[T]() && a == Min[T]()) || (!IsSigned[T]() && a != 0)
+		if isOverflow {
+			if !errors.Is(err, ErrOverflow) {
+				t.Fatalf("Neg(%v) в %T = %v, %v, want ErrOverflow", a, a, neg, err)
+			}
+		} else if err != nil || neg != -a || (a != 0 && (neg < 0) == (a < 0)) {
+			t.Fatalf("Neg(%v) в %T = %v, %v", a, a, neg, err)
+		}
+
+		if got := NegWrap(a); got != -a {
+			t.Fatalf("NegWrap(%v) = %v, want %v", a, got, -a)
+		}
+		want := neg
+		if isOverflow {
+			want = Max[T]()
+			if !IsSigned[T]() {
+				want = 0
+			}
+		}
+		if got := NegSat(a); got != want {
+			t.Fatalf("NegSat(%v) в %T = %v, want %v", a, a, got, want)
+		}
+	}
+}
+
+// TestConvert переводит все 16-битные значения во все 8- и 16-битные типы и обратно.
+func TestConvert(t *testing.T) {
+	for v := math.MinInt16; v <= math.MaxUint16; v++ {
+		testConvert[int8](t, v)
+		testConvert[uint8](t, v)
+		testConvert[int16](t, v)
+		testConvert[uint16](t, v)
+	}
+}
+
+// testConvert переводит v из int и из 64-битных типов в To и сравнивает с проверкой диапазона в int.
+func testConvert[To int8 | uint8 | int16 | uint16](t *testing.T, v int) {
+	t.Helper()
+	lo, hi := int(Min[To]()), int(Max[To]())
+	isFit := v >= lo && v <= hi
+	wantSat := To(min(max(v, lo), hi))
+
+	got, err := Convert[To](v)
+	if isFit != (err == nil) || (isFit && int(got) != v) || (!isFit && !errors.Is(err, ErrOverflow)) {
+		t.Fatalf("Convert[%T](%d) = %v, %v", got, v, got, err)
+	}
+	if sat := ConvertSat[To](v); sat != wantSat {
+		t.Fatalf("ConvertSat[%T](%d) = %v, want %v", sat, v, sat, wantSat)
+	}
+	if wrap := ConvertWrap[To](v); wrap != To(v) {
+		t.Fatalf("ConvertWrap[%T](%d) = %v, want %v", wrap, v, wrap, To(v))
+	}
+
+	if v >= 0 {
+		got, err := Convert[To](uint64(v))
+		if isFit != (err == nil) || (isFit && int(got) != v) {
+			t.Fatalf("Convert[%T](uint64(%d)) = %v, %v", got, v, got, err)
+		}
+	}
+}
+
+func TestConvert64(t *testing.T) {
+	tests := []struct {
+		name   string
+		conv   func() (any, error)
+		want   any
+		isFail bool
+	}{
+		{"uint64 max to int64", func() (any, error) { return Convert[int64](uint64(math.MaxUint64)) }, nil, true},
+		{"int64 max to uint64", func() (any, error) { return Convert[uint64](int64(math.MaxInt64)) }, uint64(math.MaxInt64), false},
+		{"int64 -1 to uint64", func() (any, error) { return Convert[uint64](int64(-1)) }, nil, true},
+		{"int64 min to int32", func() (any, error) { return Convert[int32](int64(math.MinInt64)) }, nil, true},
+		{"int32 min to int64", func() (any, error) { return Convert[int64](int32(math.MinInt32)) }, int64(math.MinInt32), false},
+		{"uint32 max to int32", func() (any, error) { return Convert[int32](uint32(math.MaxUint32)) }, nil, true},
+		{"uint32 max to int64", func() (any, error) { return Convert[int64](uint32(math.MaxUint32)) }, int64(math.MaxUint32), false},
+		{"1<<63 to int64", func() (any, error) { return Convert[int64](uint64(1 << 63)) }, nil, true},
+		{"sat uint64 max to int64", func() (any, error) { return ConvertSat[int64](uint64(math.MaxUint64)), nil }, int64(math.MaxInt64), false},
+		{"sat int64 min to uint32", func() (any, error) { return ConvertSat[uint32](int64(math.MinInt64)), nil }, uint32(0), false},
+		{"wrap uint64 max to int64", func() (any, error) { return ConvertWrap[int64](uint64(math.MaxUint64)), nil }, int64(-1), false},
+	}
+	for _, tt := range tests {
+		got, err := tt.conv()
+		if tt.isFail {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("%s: %v, %v, want ErrOverflow", tt.name, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s: %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+// TestSatWrap проверяет операции с насыщением и по модулю на всех 8-битных парах и у границ.
+func TestSatWrap(t *testing.T) {
+	testSatWrap(t, all[int8]())
+	testSatWrap(t, all[uint8]())
+	testSatWrap(t, boundary[int16]())
+	testSatWrap(t, boundary[uint16]())
+	testSatWrap(t, boundary[int32]())
+}
+
+// testSatWrap сравнивает результаты с точными значениями в int64, поэтому uint32 в нем нет:
+// произведение двух больших uint32 в int64 не помещается.
+func testSatWrap[T int8 | uint8 | int16 | uint16 | int32](t *testing.T, values []T) {
+	t.Helper()
+	lo, hi := int64(Min[T]()), int64(Max[T]())
+	clamp := func(v int64) T { return T(min(max(v, lo), hi)) }
+	for _, a := range values {
+		for _, b := range values {
+			x, y := int64(a), int64(b)
+			for _, c := range []struct {
+				op        string
+				sat, wrap T
+				exact     int64
+			}{
+				{"+", AddSat(a, b), AddWrap(a, b), x + y},
+				{"-", SubSat(a, b), SubWrap(a, b), x - y},
+				{"*", MulSat(a, b), MulWrap(a, b), x * y},
+			} {
+				if want := clamp(c.exact); c.sat != want {
+					t.Fatalf("%v %s %v в %T с насыщением = %v, want %v", a, c.op, b, a, c.sat, want)
+				}
+				if want := T(c.exact); c.wrap != want {
+					t.Fatalf("%v %s %v в %T по модулю = %v, want %v", a, c.op, b, a, c.wrap, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSat64(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want any
+	}{
+		{"AddSat max+1", AddSat[int64](math.MaxInt64, 1), int64(math.MaxInt64)},
+		{"AddSat min+min", AddSat[int64](math.MinInt64, math.MinInt64), int64(math.MinInt64)},
+		{"SubSat min-1", SubSat[int64](math.MinInt64, 1), int64(math.MinInt64)},
+		{"SubSat 0-min", SubSat[int64](0, math.MinInt64), int64(math.MaxInt64)},
+		{"MulSat min*-1", MulSat[int64](math.MinInt64, -1), int64(math.MaxInt64)},
+		{"MulSat max*-2", MulSat[int64](math.MaxInt64, -2), int64(math.MinInt64)},
+		{"MulSat -max*-max", MulSat[int64](-math.MaxInt64, -math.MaxInt64), int64(math.MaxInt64)},
+		{"AddSat uint64", AddSat[uint64](math.MaxUint64, 1), uint64(math.MaxUint64)},
+		{"SubSat uint64", SubSat[uint64](0, 1), uint64(0)},
+		{"MulSat uint64", MulSat[uint64](1<<32, 1<<32), uint64(math.MaxUint64)},
+		{"MulSat uint32", MulSat[uint32](math.MaxUint32, math.MaxUint32), uint32(math.MaxUint32)},
+		{"SubSat uint32", SubSat[uint32](1, math.MaxUint32), uint32(0)},
+		{"MulWrap uint32", MulWrap[uint32](math.MaxUint32, math.MaxUint32), uint32(1)},
+		{"AddWrap max+1", AddWrap[int64](math.MaxInt64, 1), int64(math.MinInt64)},
+		{"MulWrap uint64", MulWrap[uint64](1<<32, 1<<32), uint64(0)},
+		{"NegWrap min", NegWrap[int64](math.MinInt64), int64(math.MinInt64)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package safeint
+
+// Операции с насыщением: при переполнении результат упирается в ближайшую границу типа.
+// Так обычно считают счетчики и яркость пикселей: 255 + 1 остается 255, а не превращается в 0.
+
+// AddSat возвращает a + b, ограниченное границами типа T.
+func AddSat[T Integer](a, b T) T {
+	if sum, err := Add(a, b); err == nil {
+		return sum
+	}
+	if b > 0 {
+		return Max[T]()
+	}
+	return Min[T]()
+}
+
+// SubSat возвращает a - b, ограниченное границами типа T: для беззнаковых 0 - 1 = 0.
+func SubSat[T Integer](a, b T) T {
+	if diff, err := Sub(a, b); err == nil {
+		return diff
+	}
+	if b > 0 {
+		return Min[T]()
+	}
+	return Max[T]()
+}
+
+// MulSat возвращает a * b, ограниченное границами типа T.
+func MulSat[T Integer](a, b T) T {
+	if product, err := Mul(a, b); err == nil {
+		return product
+	}
+	if (a < 0) != (b < 0) {
+		return Min[T]()
+	}
+	return Max[T]()
+}
+
+// NegSat возвращает -a, ограниченное границами типа T: -(-128) в int8 = 127, а у беззнаковых - 0.
+func NegSat[T Integer](a T) T {
+	if neg, err := Neg(a); err == nil {
+		return neg
+	}
+	if IsSigned[T]() {
+		return Max[T]()
+	}
+	return 0
+}
+
+// ConvertSat переводит v в тип To, ограничивая его границами To: ConvertSat[uint8](300) = 255.
+func ConvertSat[To, From Integer](v From) To {
+	if r, err := Convert[To](v); err == nil {
+		return r
+	}
+	if v < 0 {
+		return Min[To]()
+	}
+	return Max[To]()
+}
+
+// Операции с переполнением по модулю 2^n, как у встроенных операторов в f63-f67.
+// Они нужны там, где переполнение ожидается: в хешах, контрольных суммах и кольцевых счетчиках.
+
+// AddWrap возвращает a + b по модулю 2^n: AddWrap[uint8](255, 1) = 0.
+func AddWrap[T Integer](a, b T) T { return a + b }
+
+// SubWrap возвращает a - b по модулю 2^n: SubWrap[uint8](0, 1) = 255.
+func SubWrap[T Integer](a, b T) T { return a - b }
+
+// MulWrap возвращает a * b по модулю 2^n.
+func MulWrap[T Integer](a, b T) T { return a * b }
+
+// NegWrap возвращает -a по модулю 2^n: NegWrap[int8](-128) = -128.
+func NegWrap[T Integer](a T) T { return -a }
+
+// ConvertWrap переводит v в тип To с отбрасыванием старших битов: ConvertWrap[uint8](300) = 44.
+func ConvertWrap[To, From Integer](v From) To { return To(v) }
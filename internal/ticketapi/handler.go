@@ -0,0 +1,150 @@
+// Package ticketapi - HTTP API для билетов на Марс поверх генератора из internal/tickets.
+//
+//	GET  /tickets?count=N&trip=round&departure=2020-10-13  случайные билеты в JSON
+//	GET  /spacelines  список компаний
+//	POST /quotes      расчет цены для заданных компании, скорости и типа поездки
+package ticketapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"example/internal/tickets"
+)
+
+const (
+	defaultTicketCount = 10   // билетов в ответе /tickets без параметра count, как в f43
+	maxTicketCount     = 1000 // ограничение count, чтобы один запрос не занимал сервер надолго
+)
+
+// Handler обслуживает запросы API. Генератор билетов не потокобезопасен,
+// поэтому все обращения к нему идут под мьютексом.
+type Handler struct {
+	mu  sync.Mutex
+	gen *tickets.Generator
+}
+
+// NewHandler создает обработчики API поверх генератора gen.
+func NewHandler(gen *tickets.Generator) *Handler {
+	return &Handler{gen: gen}
+}
+
+// QuoteRequest - тело запроса POST /quotes.
+type QuoteRequest struct {
+	Spaceline string       `json:"spaceline"`
+	Speed     int          `json:"speed"` // км/с
+	Trip      tickets.Trip `json:"trip"`
+}
+
+// errorResponse - тело ответа с ошибкой.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Tickets обрабатывает GET /tickets: count - количество билетов (по умолчанию 10),
+// trip - тип поездки (one или round); без trip тип выбирается случайно.
+// departure - дата отправления в формате 2006-01-02: расстояние до Марса и длительность
+// полета считаются на эту дату; без departure используется дата генератора.
+func (h *Handler) Tickets(w http.ResponseWriter, r *http.Request) {
+	count := defaultTicketCount
+	if s := r.URL.Query().Get("count"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 || n > maxTicketCount {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("count должен быть числом от 0 до %d", maxTicketCount))
+			return
+		}
+		count = n
+	}
+
+	var trip *tickets.Trip
+	if s := r.URL.Query().Get("trip"); s != "" {
+		t, err := tickets.ParseTrip(s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		trip = &t
+	}
+
+	var departure time.Time
+	if s := r.URL.Query().Get("departure"); s != "" {
+		t, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("departure должен быть датой в формате %s", time.DateOnly))
+			return
+		}
+		departure = t
+	}
+
+	h.mu.Lock()
+	gen := h.gen
+	if !departure.IsZero() {
+		gen = gen.WithDeparture(departure)
+	}
+	var list []tickets.Ticket
+	var err error
+	if trip == nil {
+		list, err = gen.Generate(count)
+	} else {
+		list, err = gen.GenerateWithTrip(count, *trip)
+	}
+	h.mu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+// Spacelines обрабатывает GET /spacelines: список компаний и диапазонов их скоростей.
+func (h *Handler) Spacelines(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	fleet := append([]tickets.Spaceline(nil), h.gen.Fleet...)
+	h.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, fleet)
+}
+
+// Quotes обрабатывает POST /quotes: рассчитывает цену по телу QuoteRequest.
+// Неизвестная компания - 404, неверные скорость или тип поездки - 400.
+func (h *Handler) Quotes(w http.ResponseWriter, r *http.Request) {
+	var req QuoteRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("неверное тело запроса: %w", err))
+		return
+	}
+
+	h.mu.Lock()
+	quote, err := h.gen.CalcQuote(req.Spaceline, req.Speed, req.Trip)
+	h.mu.Unlock()
+	switch {
+	case errors.Is(err, tickets.ErrUnknownSpaceline):
+		writeError(w, http.StatusNotFound, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, quote)
+}
+
+// writeJSON отправляет v в формате JSON с кодом status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError отправляет ошибку в формате {"error": "..."} с кодом status.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
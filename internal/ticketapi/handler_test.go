@@ -0,0 +1,244 @@
+package ticketapi
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example/internal/tickets"
+)
+
+// newTestHandler создает обработчики поверх генератора f43 с фиксированным seed.
+func newTestHandler() *Handler {
+	return NewHandler(tickets.NewGenerator(rand.New(rand.NewSource(1))))
+}
+
+// serve выполняет запрос через роутер со сжатием и логированием, как в команде serve.
+func serve(t *testing.T, r *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	NewRouter(newTestHandler(), slog.New(slog.NewTextHandler(io.Discard, nil))).ServeHTTP(w, r)
+	return w
+}
+
+// decode разбирает JSON-ответ в v и проверяет Content-Type.
+func decode(t *testing.T, w *httptest.ResponseRecorder, v any) {
+	t.Helper()
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type = %q, want application/json", ct)
+	}
+	if err := json.NewDecoder(w.Body).Decode(v); err != nil {
+		t.Fatalf("decode %q: %v", w.Body.String(), err)
+	}
+}
+
+func TestTickets(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantCount int
+		wantTrip  string // пусто - тип поездки случайный
+	}{
+		{"default", "", defaultTicketCount, ""},
+		{"count", "?count=3", 3, ""},
+		{"zero", "?count=0", 0, ""},
+		{"trip", "?count=5&trip=round", 5, "Round-trip"},
+		{"departure", "?count=2&trip=one&departure=2022-12-08", 2, "One-way"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, httptest.NewRequest(http.MethodGet, "/tickets"+tt.query, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+			}
+			var list []struct {
+				Spaceline string `json:"spaceline"`
+				Trip      string `json:"trip"`
+				Days      int    `json:"days"`
+			}
+			decode(t, w, &list)
+			if len(list) != tt.wantCount {
+				t.Fatalf("len = %d, want %d", len(list), tt.wantCount)
+			}
+			for _, ticket := range list {
+				if ticket.Spaceline == "" || ticket.Days <= 0 {
+					t.Errorf("bad ticket %+v", ticket)
+				}
+				if tt.wantTrip != "" && ticket.Trip != tt.wantTrip {
+					t.Errorf("trip = %q, want %q", ticket.Trip, tt.wantTrip)
+				}
+			}
+		})
+	}
+}
+
+func TestTicketsBadQuery(t *testing.T) {
+	for _, query := range []string{
+		"count=abc",
+		"count=-1",
+		"count=1001",
+		"trip=there",
+		"departure=13.10.2020",
+	} {
+		t.Run(query, func(t *testing.T) {
+			w := serve(t, httptest.NewRequest(http.MethodGet, "/tickets?"+query, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", w.Code)
+			}
+			var resp errorResponse
+			decode(t, w, &resp)
+			if resp.Error == "" {
+				t.Error("empty error message")
+			}
+		})
+	}
+}
+
+func TestSpacelines(t *testing.T) {
+	w := serve(t, httptest.NewRequest(http.MethodGet, "/spacelines", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var fleet []tickets.Spaceline
+	decode(t, w, &fleet)
+	want := tickets.DefaultFleet()
+	if len(fleet) != len(want) {
+		t.Fatalf("got %d spacelines, want %d", len(fleet), len(want))
+	}
+	for i := range want {
+		if fleet[i] != want[i] {
+			t.Errorf("spaceline %d = %+v, want %+v", i, fleet[i], want[i])
+		}
+	}
+}
+
+func TestQuotes(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"ok", `{"spaceline":"SpaceX","speed":20,"trip":"round"}`, http.StatusOK},
+		{"unknown spaceline", `{"spaceline":"Aeroflot","speed":20,"trip":"one"}`, http.StatusNotFound},
+		{"slow", `{"spaceline":"SpaceX","speed":0,"trip":"one"}`, http.StatusBadRequest},
+		{"fast", `{"spaceline":"SpaceX","speed":31,"trip":"one"}`, http.StatusBadRequest},
+		{"bad trip", `{"spaceline":"SpaceX","speed":20,"trip":"there"}`, http.StatusBadRequest},
+		{"unknown field", `{"spaceline":"SpaceX","speed":20,"trip":"one","class":"vip"}`, http.StatusBadRequest},
+		{"not json", `spaceline=SpaceX`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/quotes", strings.NewReader(tt.body))
+			w := serve(t, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var quote tickets.Quote
+			decode(t, w, &quote)
+			if quote.Spaceline != "SpaceX" || quote.Speed != 20 || quote.Trip != tickets.RoundTrip || quote.Days <= 0 {
+				t.Errorf("quote = %+v", quote)
+			}
+		})
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	w := serve(t, httptest.NewRequest(http.MethodPost, "/tickets", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want 405", w.Code)
+	}
+}
+
+func TestGzip(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		isGzip         bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"gzip, deflate", true},
+		{"br;q=1.0, gzip;q=0.8", true},
+		{"gzip;q=0", false},
+		{"gzip; q=0", false},
+		{"gzip;q=0.0", false},
+		{"gzip; q=0.000", false},
+		{"br, gzip ; Q=0.00", false},
+		{"gzip;q=0.001", true},
+		{"gzip;q=1", true},
+		{"GZIP", true},
+		{"gzip;level=1;q=0.5", true},
+		{"gzip;q=abc", false},
+		{"gzip;q=2", false},
+		{"deflate, br", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.acceptEncoding, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/spacelines", nil)
+			if tt.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := serve(t, r)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", w.Code)
+			}
+			if vary := w.Header().Get("Vary"); vary != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", vary)
+			}
+			isGzip := w.Header().Get("Content-Encoding") == "gzip"
+			if isGzip != tt.isGzip {
+				t.Fatalf("gzip = %t, want %t", isGzip, tt.isGzip)
+			}
+
+			body := io.Reader(w.Body)
+			if isGzip {
+				gz, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body = gz
+			}
+			var fleet []tickets.Spaceline
+			if err := json.NewDecoder(body).Decode(&fleet); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(fleet) != len(tickets.DefaultFleet()) {
+				t.Errorf("got %d spacelines", len(fleet))
+			}
+		})
+	}
+}
+
+// TestServer проверяет API через настоящий HTTP-сервер: клиент net/http сам
+// запрашивает gzip и распаковывает ответ.
+func TestServer(t *testing.T) {
+	srv := httptest.NewServer(NewRouter(newTestHandler(), slog.New(slog.NewTextHandler(io.Discard, nil))))
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/tickets?count=4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if !resp.Uncompressed {
+		t.Error("response was not gzip-compressed")
+	}
+	var list []tickets.Ticket
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 4 {
+		t.Errorf("len = %d, want 4", len(list))
+	}
+}
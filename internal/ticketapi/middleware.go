@@ -0,0 +1,136 @@
+package ticketapi
+
+import (
+	"compress/gzip"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Middleware принимает обработчик и возвращает обработчик с дополнительной логикой.
+type Middleware func(http.Handler) http.Handler
+
+// Conveyor оборачивает h в middlewares по порядку: последний middleware выполняется первым.
+func Conveyor(h http.Handler, middlewares ...Middleware) http.Handler {
+	for _, middleware := range middlewares {
+		h = middleware(h)
+	}
+	return h
+}
+
+type (
+	// responseData - сведения об ответе для лога.
+	responseData struct {
+		status int
+		size   int
+	}
+
+	// loggingResponseWriter перехватывает код статуса и размер ответа.
+	loggingResponseWriter struct {
+		http.ResponseWriter
+		responseData *responseData
+	}
+)
+
+// Write записывает ответ и запоминает его размер.
+func (r *loggingResponseWriter) Write(b []byte) (int, error) {
+	size, err := r.ResponseWriter.Write(b)
+	r.responseData.size += size
+	return size, err
+}
+
+// WriteHeader записывает код статуса и запоминает его.
+func (r *loggingResponseWriter) WriteHeader(statusCode int) {
+	r.ResponseWriter.WriteHeader(statusCode)
+	r.responseData.status = statusCode
+}
+
+// WithLogging пишет в logger метод, URI, статус, длительность и размер каждого ответа.
+func WithLogging(logger *slog.Logger) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			// статус 200 по умолчанию: обработчик может не вызывать WriteHeader явно
+			responseData := &responseData{status: http.StatusOK}
+			lw := loggingResponseWriter{ResponseWriter: w, responseData: responseData}
+			h.ServeHTTP(&lw, r)
+
+			logger.Info("request",
+				"uri", r.RequestURI,
+				"method", r.Method,
+				"status", responseData.status,
+				"duration", time.Since(start),
+				"size", responseData.size,
+			)
+		})
+	}
+}
+
+// gzipWriter подменяет Write, чтобы тело ответа проходило через gzip.Writer.
+type gzipWriter struct {
+	http.ResponseWriter
+	Writer io.Writer
+}
+
+// Write пишет сжатые данные.
+func (w gzipWriter) Write(b []byte) (int, error) {
+	return w.Writer.Write(b)
+}
+
+// WithGzip сжимает ответ, если клиент прислал Accept-Encoding: gzip.
+func WithGzip(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !isGzipAccepted(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		gz, err := gzip.NewWriterLevel(w, gzip.BestSpeed)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		defer gz.Close()
+
+		w.Header().Set("Content-Encoding", "gzip")
+		h.ServeHTTP(gzipWriter{ResponseWriter: w, Writer: gz}, r)
+	})
+}
+
+// isGzipAccepted проверяет все значения Accept-Encoding на наличие gzip
+// с ненулевым q, например "gzip, deflate" или "br;q=1.0, gzip;q=0.8".
+// Любая запись нуля ("q=0", "q=0.0", "Q=0.000") означает отказ от gzip.
+func isGzipAccepted(r *http.Request) bool {
+	for _, header := range r.Header.Values("Accept-Encoding") {
+		for _, part := range strings.Split(header, ",") {
+			coding, params, _ := strings.Cut(part, ";")
+			if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+				continue
+			}
+			return calcQuality(params) > 0
+		}
+	}
+	return false
+}
+
+// calcQuality возвращает вес q из параметров кодировки, по умолчанию 1.
+// Непонятный вес считается нулевым: сжимать ответ, не зная, примет ли его клиент, нельзя.
+func calcQuality(params string) float64 {
+	for _, param := range strings.Split(params, ";") {
+		name, value, _ := strings.Cut(param, "=")
+		if !strings.EqualFold(strings.TrimSpace(name), "q") {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || q < 0 || q > 1 {
+			return 0
+		}
+		return q
+	}
+	return 1
+}
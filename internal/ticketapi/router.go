@@ -0,0 +1,16 @@
+package ticketapi
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// NewRouter регистрирует маршруты API и оборачивает их в сжатие и логирование.
+func NewRouter(h *Handler, logger *slog.Logger) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tickets", h.Tickets)
+	mux.HandleFunc("GET /spacelines", h.Spacelines)
+	mux.HandleFunc("POST /quotes", h.Quotes)
+
+	return Conveyor(mux, WithGzip, WithLogging(logger))
+}
@@ -0,0 +1,129 @@
+package tickets
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	co "example/internal/constants"
+	"example/internal/orbit"
+)
+
+// Generator выдает случайные билеты и расчеты цены для флота Fleet по тарифу Policy.
+// Не является потокобезопасным: *rand.Rand нельзя использовать из нескольких горутин.
+type Generator struct {
+	Fleet     []Spaceline
+	Policy    PricingPolicy
+	Departure time.Time  // дата отправления всех билетов
+	Distance  int        // км от Земли до Марса в день отправления
+	Rand      *rand.Rand // источник случайности для выбора компании, скорости и типа поездки
+}
+
+// NewGenerator создает генератор с настройками f43: три компании, тариф по умолчанию,
+// отправление 13 октября 2020 года на расстояние 62 100 000 км.
+func NewGenerator(r *rand.Rand) *Generator {
+	return &Generator{
+		Fleet:     DefaultFleet(),
+		Policy:    DefaultPricing(),
+		Departure: time.Date(2020, time.October, 13, 0, 0, 0, 0, time.UTC),
+		Distance:  co.MarsDistance20201013,
+		Rand:      r,
+	}
+}
+
+// WithDeparture возвращает копию генератора с датой отправления departure
+// и расстоянием до Марса на эту дату по модели орбит из internal/orbit.
+// Копия использует тот же *rand.Rand, что и исходный генератор.
+func (g *Generator) WithDeparture(departure time.Time) *Generator {
+	next := *g
+	next.Departure = departure
+	next.Distance = int(math.Round(orbit.Distance(departure)))
+	return &next
+}
+
+// GetSpaceline возвращает компанию флота по названию.
+func (g *Generator) GetSpaceline(name string) (Spaceline, error) {
+	for _, line := range g.Fleet {
+		if line.Name == name {
+			return line, line.Validate()
+		}
+	}
+	return Spaceline{}, fmt.Errorf("%w: %q", ErrUnknownSpaceline, name)
+}
+
+// CalcQuote рассчитывает цену и длительность полета компанией name со скоростью speed.
+// Возвращает ErrUnknownSpaceline, ErrInvalidSpeed или ErrInvalidTrip для неверных аргументов.
+func (g *Generator) CalcQuote(name string, speed int, trip Trip) (Quote, error) {
+	line, err := g.GetSpaceline(name)
+	if err != nil {
+		return Quote{}, err
+	}
+	if !line.HasSpeed(speed) {
+		return Quote{}, fmt.Errorf("%w: %d км/с, у %s %d-%d км/с",
+			ErrInvalidSpeed, speed, line.Name, line.MinSpeed, line.MaxSpeed)
+	}
+	if !trip.IsValid() {
+		return Quote{}, fmt.Errorf("%w: %d", ErrInvalidTrip, int(trip))
+	}
+
+	price, err := g.Policy.CalcPrice(line, speed, trip)
+	if err != nil {
+		return Quote{}, fmt.Errorf("цена билета %s: %w", line.Name, err)
+	}
+	days, err := CalcDays(g.Distance, speed)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	return Quote{
+		Spaceline: line.Name,
+		Speed:     speed,
+		Trip:      trip,
+		Days:      days,
+		Price:     price,
+	}, nil
+}
+
+// Generate возвращает count случайных билетов: компания, скорость в ее диапазоне
+// и тип поездки выбираются случайно, как в f43.
+func (g *Generator) Generate(count int) ([]Ticket, error) {
+	return g.generate(count, func() Trip {
+		if g.Rand.Intn(2) == 1 {
+			return RoundTrip
+		}
+		return OneWay
+	})
+}
+
+// GenerateWithTrip возвращает count случайных билетов с заданным типом поездки.
+func (g *Generator) GenerateWithTrip(count int, trip Trip) ([]Ticket, error) {
+	if !trip.IsValid() {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidTrip, int(trip))
+	}
+	return g.generate(count, func() Trip { return trip })
+}
+
+// generate выбирает случайные компанию и скорость, а тип поездки берет из pickTrip.
+// Флот проверяется до выбора скорости: для min > max rand.Intn вызвал бы панику.
+func (g *Generator) generate(count int, pickTrip func() Trip) ([]Ticket, error) {
+	if err := ValidateFleet(g.Fleet); err != nil {
+		return nil, err
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidCount, count)
+	}
+
+	tickets := make([]Ticket, 0, count)
+	for i := 0; i < count; i++ {
+		line := g.Fleet[g.Rand.Intn(len(g.Fleet))]
+		speed := g.Rand.Intn(line.MaxSpeed-line.MinSpeed+1) + line.MinSpeed
+
+		quote, err := g.CalcQuote(line.Name, speed, pickTrip())
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, Ticket{Quote: quote, Departure: g.Departure, Distance: g.Distance})
+	}
+	return tickets, nil
+}
@@ -0,0 +1,104 @@
+package tickets
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestValidateFleet(t *testing.T) {
+	tests := []struct {
+		name    string
+		fleet   []Spaceline
+		wantErr error
+	}{
+		{"флот f43", DefaultFleet(), nil},
+		{"пустой флот", nil, ErrEmptyFleet},
+		{"min > max", []Spaceline{{Name: "x", MinSpeed: 30, MaxSpeed: 16}}, ErrInvalidSpeed},
+		{"нулевая скорость", []Spaceline{{Name: "x", MinSpeed: 0, MaxSpeed: 16}}, ErrInvalidSpeed},
+		{"без названия", []Spaceline{{MinSpeed: 16, MaxSpeed: 30}}, ErrUnknownSpaceline},
+		{"повтор", append(DefaultFleet(), Spaceline{Name: "SpaceX", MinSpeed: 1, MaxSpeed: 2}), ErrDuplicateSpaceline},
+	}
+	for _, tt := range tests {
+		if err := ValidateFleet(tt.fleet); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: ValidateFleet = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// TestGenerateInvalidFleet проверяет, что неверный флот - ошибка, а не паника в rand.Intn.
+func TestGenerateInvalidFleet(t *testing.T) {
+	g := NewGenerator(rand.New(rand.NewSource(1)))
+	g.Fleet = []Spaceline{{Name: "x", MinSpeed: 30, MaxSpeed: 16}}
+	if _, err := g.Generate(1); !errors.Is(err, ErrInvalidSpeed) {
+		t.Errorf("Generate с min > max: err = %v, want ErrInvalidSpeed", err)
+	}
+	g.Fleet = append(DefaultFleet(), DefaultFleet()[0])
+	if _, err := g.GenerateWithTrip(1, OneWay); !errors.Is(err, ErrDuplicateSpaceline) {
+		t.Errorf("GenerateWithTrip с повтором: err = %v, want ErrDuplicateSpaceline", err)
+	}
+	g.Fleet = nil
+	if _, err := g.Generate(1); !errors.Is(err, ErrEmptyFleet) {
+		t.Errorf("Generate без флота: err = %v, want ErrEmptyFleet", err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	g := NewGenerator(rand.New(rand.NewSource(1)))
+	list, err := g.Generate(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 100 {
+		t.Fatalf("билетов %d, want 100", len(list))
+	}
+	for _, ticket := range list {
+		line, err := g.GetSpaceline(ticket.Spaceline)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !line.HasSpeed(ticket.Speed) {
+			t.Errorf("%v: скорость %d вне %d-%d", ticket.Spaceline, ticket.Speed, line.MinSpeed, line.MaxSpeed)
+		}
+		want, err := g.CalcQuote(ticket.Spaceline, ticket.Speed, ticket.Trip)
+		if err != nil || want != ticket.Quote {
+			t.Errorf("билет %+v, want %+v, %v", ticket.Quote, want, err)
+		}
+	}
+	if _, err := g.Generate(-1); !errors.Is(err, ErrInvalidCount) {
+		t.Errorf("Generate(-1): err = %v, want ErrInvalidCount", err)
+	}
+	if _, err := g.GenerateWithTrip(1, Trip(7)); !errors.Is(err, ErrInvalidTrip) {
+		t.Errorf("GenerateWithTrip(7): err = %v, want ErrInvalidTrip", err)
+	}
+}
+
+func TestCalcQuote(t *testing.T) {
+	g := NewGenerator(rand.New(rand.NewSource(1)))
+	q, err := g.CalcQuote("SpaceX", 30, RoundTrip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 62 100 000 км / 30 км/с / 86 400 с = 23.96 суток
+	if q.Days != 23 || q.Price.String() != "$100000000.00" {
+		t.Errorf("CalcQuote(SpaceX, 30, туда-обратно) = %d дн., %v, want 23 дн., $100000000.00", q.Days, q.Price)
+	}
+	for _, tt := range []struct {
+		name    string
+		speed   int
+		trip    Trip
+		wantErr error
+	}{
+		{"Blue Origin", 20, OneWay, ErrUnknownSpaceline},
+		{"SpaceX", 31, OneWay, ErrInvalidSpeed},
+		{"SpaceX", 15, OneWay, ErrInvalidSpeed},
+		{"SpaceX", 20, Trip(7), ErrInvalidTrip},
+	} {
+		if _, err := g.CalcQuote(tt.name, tt.speed, tt.trip); !errors.Is(err, tt.wantErr) {
+			t.Errorf("CalcQuote(%s, %d, %d): err = %v, want %v", tt.name, tt.speed, tt.trip, err, tt.wantErr)
+		}
+	}
+	if _, err := CalcDays(1000, 0); !errors.Is(err, ErrInvalidSpeed) {
+		t.Errorf("CalcDays(1000, 0): err = %v, want ErrInvalidSpeed", err)
+	}
+}
@@ -0,0 +1,69 @@
+package tickets
+
+import (
+	co "example/internal/constants"
+	"example/internal/money"
+)
+
+// PricingPolicy - правило расчета цены билета.
+// Реализации можно подменять, чтобы сравнивать разные тарифы на одном и том же флоте.
+type PricingPolicy interface {
+	// CalcPrice возвращает цену билета для компании line,
+	// скорости speed (км/с) и типа поездки trip.
+	// Ошибка возвращается при переполнении денежной суммы.
+	CalcPrice(line Spaceline, speed int, trip Trip) (money.Money, error)
+}
+
+// LinearPricing - тариф из f43: базовая цена плюс надбавка за каждый км/с скорости,
+// для поездки туда-обратно цена умножается на RoundTripMultiplier.
+type LinearPricing struct {
+	BasePrice           money.Money            // базовая цена для компаний без своей цены
+	LineBasePrices      map[string]money.Money // базовая цена по названию компании
+	SpeedPremium        money.Money            // надбавка за каждый км/с
+	RoundTripMultiplier float64                // множитель цены для поездки туда-обратно
+}
+
+// DefaultPricing возвращает тариф f43: $20 млн + $1 млн за км/с, туда-обратно вдвое дороже.
+func DefaultPricing() LinearPricing {
+	// значения из constants заведомо помещаются в int64, поэтому ошибки FromMajor не проверяются
+	base, _ := money.FromMajor(co.BaseTicketPrice, money.USD)
+	premium, _ := money.FromMajor(co.SpeedPremium, money.USD)
+	return LinearPricing{
+		BasePrice:           base,
+		SpeedPremium:        premium,
+		RoundTripMultiplier: co.RoundTripMultiplier,
+	}
+}
+
+// GetBasePrice возвращает базовую цену компании line или общую базовую цену.
+func (p LinearPricing) GetBasePrice(line Spaceline) money.Money {
+	if price, ok := p.LineBasePrices[line.Name]; ok {
+		return price
+	}
+	return p.BasePrice
+}
+
+// CalcPrice считает цену билета по линейному тарифу.
+// Множитель туда-обратно применяется с банковским округлением до цента.
+func (p LinearPricing) CalcPrice(line Spaceline, speed int, trip Trip) (money.Money, error) {
+	premium, err := p.SpeedPremium.Mul(int64(speed))
+	if err != nil {
+		return money.Money{}, err
+	}
+	price, err := p.GetBasePrice(line).Add(premium)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if trip == RoundTrip {
+		return price.MulFloat(p.RoundTripMultiplier)
+	}
+	return price, nil
+}
+
+// PricingFunc позволяет использовать обычную функцию как PricingPolicy.
+type PricingFunc func(line Spaceline, speed int, trip Trip) (money.Money, error)
+
+// CalcPrice вызывает f(line, speed, trip).
+func (f PricingFunc) CalcPrice(line Spaceline, speed int, trip Trip) (money.Money, error) {
+	return f(line, speed, trip)
+}
@@ -0,0 +1,87 @@
+package tickets
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"example/internal/money"
+)
+
+func TestLinearPricing(t *testing.T) {
+	spaceX := Spaceline{Name: "SpaceX", MinSpeed: 16, MaxSpeed: 30}
+	virgin := Spaceline{Name: "Virgin Galactic", MinSpeed: 16, MaxSpeed: 30}
+	custom := DefaultPricing()
+	custom.LineBasePrices = map[string]money.Money{"SpaceX": money.MustParse("$10000000.00")}
+	custom.RoundTripMultiplier = 1.5
+
+	tests := []struct {
+		name   string
+		policy PricingPolicy
+		line   Spaceline
+		speed  int
+		trip   Trip
+		want   string
+	}{
+		{"f43: 16 км/с", DefaultPricing(), spaceX, 16, OneWay, "$36000000.00"},
+		{"f43: 30 км/с", DefaultPricing(), spaceX, 30, OneWay, "$50000000.00"},
+		{"f43: туда-обратно", DefaultPricing(), spaceX, 30, RoundTrip, "$100000000.00"},
+		{"своя базовая цена", custom, spaceX, 20, OneWay, "$30000000.00"},
+		{"общая базовая цена", custom, virgin, 20, OneWay, "$40000000.00"},
+		{"множитель 1.5", custom, virgin, 21, RoundTrip, "$61500000.00"},
+		{"PricingFunc", PricingFunc(func(line Spaceline, speed int, trip Trip) (money.Money, error) {
+			return money.FromMajor(int64(speed), money.USD)
+		}), spaceX, 25, OneWay, "$25.00"},
+	}
+	for _, tt := range tests {
+		got, err := tt.policy.CalcPrice(tt.line, tt.speed, tt.trip)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("%s: CalcPrice = %v, %v, want %s", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+// TestLinearPricingRounding проверяет банковское округление множителя до цента.
+func TestLinearPricingRounding(t *testing.T) {
+	p := LinearPricing{
+		BasePrice:           money.MustParse("$0.05"),
+		SpeedPremium:        money.MustParse("$0.00"),
+		RoundTripMultiplier: 2.5,
+	}
+	line := Spaceline{Name: "x", MinSpeed: 1, MaxSpeed: 1}
+	// 0.05 × 2.5 = 0.125 -> 0.12: половина округляется к четному
+	if got, err := p.CalcPrice(line, 1, RoundTrip); err != nil || got.String() != "$0.12" {
+		t.Errorf("0.05 × 2.5 = %v, %v, want $0.12", got, err)
+	}
+	p.BasePrice = money.MustParse("$0.07")
+	// 0.07 × 2.5 = 0.175 -> 0.18
+	if got, err := p.CalcPrice(line, 1, RoundTrip); err != nil || got.String() != "$0.18" {
+		t.Errorf("0.07 × 2.5 = %v, %v, want $0.18", got, err)
+	}
+}
+
+func TestLinearPricingOverflow(t *testing.T) {
+	huge := money.New(math.MaxInt64/2, money.USD)
+	line := Spaceline{Name: "x", MinSpeed: 1, MaxSpeed: 100}
+	tests := []struct {
+		name  string
+		p     LinearPricing
+		speed int
+		trip  Trip
+	}{
+		{"надбавка × скорость", LinearPricing{BasePrice: money.New(0, money.USD), SpeedPremium: huge, RoundTripMultiplier: 2}, 3, OneWay},
+		{"база + надбавка", LinearPricing{BasePrice: huge, SpeedPremium: huge, RoundTripMultiplier: 2}, 2, OneWay},
+		{"туда-обратно", LinearPricing{BasePrice: huge, SpeedPremium: money.New(1, money.USD), RoundTripMultiplier: 2}, 1, RoundTrip},
+	}
+	for _, tt := range tests {
+		if got, err := tt.p.CalcPrice(line, tt.speed, tt.trip); !errors.Is(err, money.ErrOverflow) {
+			t.Errorf("%s: CalcPrice = %v, %v, want ErrOverflow", tt.name, got, err)
+		}
+	}
+
+	euro := DefaultPricing()
+	euro.SpeedPremium = money.MustParse("€1.00")
+	if got, err := euro.CalcPrice(line, 1, OneWay); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("доллары + евро = %v, %v, want ErrCurrencyMismatch", got, err)
+	}
+}
@@ -0,0 +1,22 @@
+package tickets
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteTable печатает билеты таблицей в формате f43, но с полной ценой в долларах.
+func WriteTable(w io.Writer, tickets []Ticket) error {
+	if _, err := fmt.Fprintln(w, "Spaceline        Days Trip type            Price"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "================================================"); err != nil {
+		return err
+	}
+	for _, t := range tickets {
+		if _, err := fmt.Fprintf(w, "%-16v %4v %-10v %16v\n", t.Spaceline, t.Days, t.Trip, t.Price); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Package tickets рассчитывает цены билетов на Марс.
+// Это библиотечная версия примера f43: вместо печати таблицы
+// генератор возвращает билеты, а правила цены задаются через PricingPolicy.
+package tickets
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	co "example/internal/constants"
+	"example/internal/money"
+)
+
+var (
+	// ErrUnknownSpaceline возвращается, если компании нет во флоте.
+	ErrUnknownSpaceline = errors.New("неизвестная космическая компания")
+	// ErrInvalidSpeed возвращается, если скорость вне диапазона компании.
+	ErrInvalidSpeed = errors.New("скорость вне допустимого диапазона")
+	// ErrInvalidTrip возвращается для неизвестного типа поездки.
+	ErrInvalidTrip = errors.New("неизвестный тип поездки")
+	// ErrEmptyFleet возвращается, если во флоте генератора нет ни одной компании.
+	ErrEmptyFleet = errors.New("флот пуст")
+	// ErrDuplicateSpaceline возвращается, если во флоте две компании с одним названием.
+	ErrDuplicateSpaceline = errors.New("компания во флоте повторяется")
+	// ErrInvalidCount возвращается при отрицательном количестве билетов.
+	ErrInvalidCount = errors.New("количество билетов не может быть отрицательным")
+)
+
+// Trip - тип поездки: в один конец или туда-обратно.
+type Trip int
+
+const (
+	OneWay    Trip = iota // в один конец
+	RoundTrip             // туда-обратно
+)
+
+// String возвращает название типа поездки как в таблице f43.
+func (t Trip) String() string {
+	switch t {
+	case OneWay:
+		return "One-way"
+	case RoundTrip:
+		return "Round-trip"
+	default:
+		return fmt.Sprintf("Trip(%d)", int(t))
+	}
+}
+
+// IsValid проверяет, что тип поездки известен.
+func (t Trip) IsValid() bool {
+	return t == OneWay || t == RoundTrip
+}
+
+// ParseTrip разбирает тип поездки: "one-way"/"oneway"/"one" или "round-trip"/"roundtrip"/"round".
+func ParseTrip(s string) (Trip, error) {
+	switch s {
+	case "One-way", "one-way", "oneway", "one":
+		return OneWay, nil
+	case "Round-trip", "round-trip", "roundtrip", "round":
+		return RoundTrip, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidTrip, s)
+}
+
+// MarshalText позволяет хранить тип поездки в JSON строкой.
+func (t Trip) MarshalText() ([]byte, error) {
+	if !t.IsValid() {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidTrip, int(t))
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText разбирает тип поездки из строки, см. ParseTrip.
+func (t *Trip) UnmarshalText(text []byte) error {
+	parsed, err := ParseTrip(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// Spaceline - космическая компания и диапазон скоростей ее кораблей.
+type Spaceline struct {
+	Name     string `json:"name"`      // название, например "SpaceX"
+	MinSpeed int    `json:"min_speed"` // км/с, минимальная скорость корабля
+	MaxSpeed int    `json:"max_speed"` // км/с, максимальная скорость корабля
+}
+
+// Validate проверяет, что у компании есть название, а скорости положительные
+// и минимальная не больше максимальной: с нулевой скоростью полет не кончится.
+func (s Spaceline) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: пустое название", ErrUnknownSpaceline)
+	}
+	if s.MinSpeed <= 0 || s.MinSpeed > s.MaxSpeed {
+		return fmt.Errorf("%w: у %s %d-%d км/с, нужно 0 < min <= max", ErrInvalidSpeed, s.Name, s.MinSpeed, s.MaxSpeed)
+	}
+	return nil
+}
+
+// HasSpeed проверяет, может ли корабль компании лететь со скоростью speed.
+func (s Spaceline) HasSpeed(speed int) bool {
+	return speed >= s.MinSpeed && speed <= s.MaxSpeed
+}
+
+// ValidateFleet проверяет каждую компанию флота и что названия не повторяются:
+// компания ищется по названию, поэтому вторая с тем же названием была бы недоступна.
+func ValidateFleet(fleet []Spaceline) error {
+	if len(fleet) == 0 {
+		return ErrEmptyFleet
+	}
+	names := make(map[string]bool, len(fleet))
+	for _, line := range fleet {
+		if err := line.Validate(); err != nil {
+			return err
+		}
+		if names[line.Name] {
+			return fmt.Errorf("%w: %q", ErrDuplicateSpaceline, line.Name)
+		}
+		names[line.Name] = true
+	}
+	return nil
+}
+
+// DefaultFleet возвращает три компании из f43 со скоростями 16-30 км/с.
+func DefaultFleet() []Spaceline {
+	return []Spaceline{
+		{Name: "Space Adventures", MinSpeed: co.MinShipSpeed, MaxSpeed: co.MaxShipSpeed},
+		{Name: "SpaceX", MinSpeed: co.MinShipSpeed, MaxSpeed: co.MaxShipSpeed},
+		{Name: "Virgin Galactic", MinSpeed: co.MinShipSpeed, MaxSpeed: co.MaxShipSpeed},
+	}
+}
+
+// Quote - расчет цены полета: компания, скорость, тип поездки, длительность и цена.
+type Quote struct {
+	Spaceline string      `json:"spaceline"`
+	Speed     int         `json:"speed"` // км/с
+	Trip      Trip        `json:"trip"`
+	Days      int         `json:"days"` // длительность полета в один конец
+	Price     money.Money `json:"price"`
+}
+
+// Ticket - билет: расчет цены на конкретную дату отправления и расстояние.
+type Ticket struct {
+	Quote
+	Departure time.Time `json:"departure"`
+	Distance  int       `json:"distance"` // км от Земли до Марса в день отправления
+}
+
+// CalcDays считает длительность полета в один конец в сутках (с округлением вниз), как в f43.
+// Возвращает ErrInvalidSpeed, если скорость не положительная.
+func CalcDays(distance, speed int) (int, error) {
+	if speed <= 0 {
+		return 0, fmt.Errorf("%w: %d км/с, скорость должна быть больше 0", ErrInvalidSpeed, speed)
+	}
+	return distance / speed / co.SecondsPerDay, nil
+}
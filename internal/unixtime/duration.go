@@ -0,0 +1,58 @@
+package unixtime
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"example/internal/safeint"
+)
+
+// Длительность time.Duration - int64 наносекунд, то есть от -292 до 292 лет.
+// Стандартная библиотека обращается с ее границами по-разному: t.Sub(u) насыщается
+// до math.MaxInt64 наносекунд, а умножение и сложение длительностей молча переполняются,
+// так что 300 лет, сложенные из дней, превращаются в отрицательную длительность.
+
+// Between возвращает длительность от from до to, как to.Sub(from), но вместо
+// насыщения возвращает ErrOverflow, если моменты дальше друг от друга, чем на 292 года.
+func Between(from, to time.Time) (time.Duration, error) {
+	d := to.Sub(from)
+	if !from.Add(d).Equal(to) {
+		return 0, fmt.Errorf("%w: от %v до %v больше %v", ErrOverflow, from, to, time.Duration(math.MaxInt64))
+	}
+	return d, nil
+}
+
+// Scale возвращает n единиц unit, например Scale(300*365, 24*time.Hour) для 300 лет.
+func Scale(n int64, unit time.Duration) (time.Duration, error) {
+	d, err := safeint.Mul(time.Duration(n), unit)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %d × %v не помещается в time.Duration", ErrOverflow, n, unit)
+	}
+	return d, nil
+}
+
+// Sum возвращает сумму длительностей.
+func Sum(durations ...time.Duration) (time.Duration, error) {
+	var sum time.Duration
+	for _, d := range durations {
+		next, err := safeint.Add(sum, d)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v + %v не помещается в time.Duration", ErrOverflow, sum, d)
+		}
+		sum = next
+	}
+	return sum, nil
+}
+
+// Add возвращает t + d. В отличие от t.Add, ошибка возвращается, если результат
+// не помещается в time.Time: t.Add у границы насыщается или переходит через нее,
+// и тогда от t до результата оказывается не d, а дата уходит за Second.GetRange.
+func Add(t time.Time, d time.Duration) (time.Time, error) {
+	result := t.Add(d)
+	first, last := Second.GetRange()
+	if result.Sub(t) != d || result.Before(first) || result.After(last) {
+		return time.Time{}, fmt.Errorf("%w: %v + %v не помещается в time.Time", ErrOverflow, t, d)
+	}
+	return result, nil
+}
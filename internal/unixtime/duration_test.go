@@ -0,0 +1,128 @@
+package unixtime
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+const maxDuration = time.Duration(math.MaxInt64)
+
+func TestBetween(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	tests := []struct {
+		from, to time.Time
+		want     time.Duration
+		isError  bool
+	}{
+		{from: epoch, to: epoch.Add(maxDuration), want: maxDuration},
+		{from: epoch.Add(maxDuration), to: epoch, want: -maxDuration},
+		{from: epoch, to: epoch.Add(-maxDuration - 1), want: -maxDuration - 1},
+		{from: epoch, to: epoch.Add(maxDuration).Add(1), isError: true},
+		{from: epoch, to: epoch.Add(-maxDuration - 1).Add(-1), isError: true},
+		{from: time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), to: time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC), isError: true},
+	}
+	for _, tt := range tests {
+		got, err := Between(tt.from, tt.to)
+		if tt.isError {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("Between(%v, %v) = %v, %v, want ErrOverflow", tt.from, tt.to, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Between(%v, %v) = %v, %v, want %v", tt.from, tt.to, got, err, tt.want)
+		}
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		n       int64
+		unit    time.Duration
+		want    time.Duration
+		isError bool
+	}{
+		{n: 100 * 365, unit: 24 * time.Hour, want: 100 * 365 * 24 * time.Hour},
+		{n: 300 * 365, unit: 24 * time.Hour, isError: true},
+		{n: math.MaxInt64, unit: time.Nanosecond, want: maxDuration},
+		{n: math.MinInt64, unit: time.Nanosecond, want: -maxDuration - 1},
+		{n: math.MinInt64, unit: -time.Nanosecond, isError: true},
+		{n: math.MaxInt64/2 + 1, unit: 2, isError: true},
+		{n: -1, unit: maxDuration, want: -maxDuration},
+	}
+	for _, tt := range tests {
+		got, err := Scale(tt.n, tt.unit)
+		if tt.isError {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("Scale(%d, %v) = %v, %v, want ErrOverflow", tt.n, tt.unit, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Scale(%d, %v) = %v, %v, want %v", tt.n, tt.unit, got, err, tt.want)
+		}
+	}
+}
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		durations []time.Duration
+		want      time.Duration
+		isError   bool
+	}{
+		{durations: nil, want: 0},
+		{durations: []time.Duration{maxDuration - 1, 1}, want: maxDuration},
+		{durations: []time.Duration{maxDuration, 1}, isError: true},
+		{durations: []time.Duration{-maxDuration, -1}, want: -maxDuration - 1},
+		{durations: []time.Duration{-maxDuration, -2}, isError: true},
+		// промежуточная сумма переполняется, хотя итог поместился бы
+		{durations: []time.Duration{maxDuration, 1, -2}, isError: true},
+	}
+	for _, tt := range tests {
+		got, err := Sum(tt.durations...)
+		if tt.isError {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("Sum(%v) = %v, %v, want ErrOverflow", tt.durations, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Sum(%v) = %v, %v, want %v", tt.durations, got, err, tt.want)
+		}
+	}
+}
+
+// TestAdd проверяет Add у границ Second.GetRange, где t.Add насыщается или переходит через границу.
+func TestAdd(t *testing.T) {
+	first, last := Second.GetRange()
+	tests := []struct {
+		t       time.Time
+		d       time.Duration
+		isError bool
+	}{
+		{t: time.Unix(0, 0), d: maxDuration},
+		{t: time.Unix(0, 0), d: -maxDuration - 1},
+		{t: last.Add(-time.Hour), d: time.Hour},
+		{t: first.Add(time.Hour), d: -time.Hour},
+		{t: last, d: 0},
+		{t: last, d: 1, isError: true},
+		{t: first, d: -1, isError: true},
+		{t: time.Unix(maxUnixSeconds-10, 0), d: time.Hour, isError: true},
+		{t: time.Unix(minUnixSeconds+10, 0), d: -time.Hour, isError: true},
+		{t: time.Unix(maxUnixSeconds, 0), d: maxDuration, isError: true},
+	}
+	for _, tt := range tests {
+		got, err := Add(tt.t, tt.d)
+		if tt.isError {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("Add(%v, %v) = %v, %v, want ErrOverflow", tt.t, tt.d, got, err)
+			}
+			continue
+		}
+		if err != nil || got.Sub(tt.t) != tt.d {
+			t.Errorf("Add(%v, %v) = %v, %v, want на %v позже", tt.t, tt.d, got, err, tt.d)
+		}
+	}
+}
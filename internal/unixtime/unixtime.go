@@ -0,0 +1,173 @@
+// Package unixtime - перевод времени Unix в time.Time и time.Duration с проверкой переполнения.
+//
+// f68 показывает, что time.Unix в int64 секунд доживет до 2370 года и дальше. Но другие
+// представления того же времени переполняются гораздо раньше, причем молча, как uint8 в f63:
+//
+//   - UnixNano помещается в int64 только с 1677 по 2262 год, за пределами возвращает мусор;
+//   - time.Duration - тоже int64 наносекунд, то есть не больше 292 лет, а t.Sub(u)
+//     для более далеких моментов молча возвращает наибольшую длительность;
+//   - 32-битный time_t старых систем и форматов файлов заканчивается 19 января 2038 года.
+//
+// Функции пакета возвращают ErrOverflow вместо неверного числа.
+package unixtime
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrOverflow возвращается, если время или длительность не помещаются в тип результата.
+	ErrOverflow = errors.New("переполнение времени")
+	// ErrY2038 дополняет ErrOverflow, если время не помещается в 32-битный time_t.
+	ErrY2038 = errors.New("проблема 2038 года: время вне 32-битного time_t")
+	// ErrUnknownUnit возвращается для неизвестной единицы времени Unix.
+	ErrUnknownUnit = errors.New("неизвестная единица времени")
+)
+
+// Границы времени Unix в секундах задает не int64, а сам time.Time.
+const (
+	// unixToInternal - секунд от 1 января 1 года до 1 января 1970 года. time.Time хранит секунды
+	// от 1 года в int64, поэтому время Unix в секундах не может быть больше math.MaxInt64 - unixToInternal.
+	unixToInternal = 62_135_596_800
+	maxUnixSeconds = math.MaxInt64 - unixToInternal
+	// minUnixSeconds - 1 марта -292277022400 года: для более ранних моментов time.Time
+	// хранит секунды, но календарная дата переполняется и получается положительный год.
+	minUnixSeconds = -9_223_372_028_741_760_000
+)
+
+// Unit - единица, в которой время Unix хранится в int64.
+type Unit time.Duration
+
+const (
+	Second = Unit(time.Second)
+	Milli  = Unit(time.Millisecond)
+	Micro  = Unit(time.Microsecond)
+	Nano   = Unit(time.Nanosecond)
+)
+
+// GetUnits возвращает единицы от секунд до наносекунд.
+func GetUnits() []Unit { return []Unit{Second, Milli, Micro, Nano} }
+
+// String возвращает обозначение единицы: s, ms, us или ns.
+func (u Unit) String() string {
+	switch u {
+	case Second:
+		return "s"
+	case Milli:
+		return "ms"
+	case Micro:
+		return "us"
+	case Nano:
+		return "ns"
+	}
+	return fmt.Sprintf("Unit(%d)", int64(u))
+}
+
+// ParseUnit разбирает обозначение единицы: s, ms, us (или µs) и ns.
+func ParseUnit(s string) (Unit, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "s", "sec":
+		return Second, nil
+	case "ms", "milli":
+		return Milli, nil
+	case "us", "µs", "micro":
+		return Micro, nil
+	case "ns", "nano":
+		return Nano, nil
+	}
+	return 0, fmt.Errorf("%w: %q, есть s, ms, us и ns", ErrUnknownUnit, s)
+}
+
+// validate проверяет, что u - одна из единиц GetUnits.
+func (u Unit) validate() error {
+	switch u {
+	case Second, Milli, Micro, Nano:
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrUnknownUnit, u)
+}
+
+// GetRange возвращает первый и последний момент, время Unix которых в единицах u помещается в int64.
+// Для наносекунд это 1677-09-21 и 2262-04-11, для секунд - сотни миллиардов лет,
+// но уже не весь int64, а только моменты, которые может представить time.Time.
+func (u Unit) GetRange() (first, last time.Time) {
+	if u == Second {
+		return time.Unix(minUnixSeconds, 0).UTC(), time.Unix(maxUnixSeconds, int64(time.Second)-1).UTC()
+	}
+	// последний момент - начало наибольшего числа единиц плюс почти целая единица, которая отбрасывается
+	return fromUnix(math.MinInt64, u).UTC(), fromUnix(math.MaxInt64, u).Add(time.Duration(u) - 1).UTC()
+}
+
+// fromUnix переводит n единиц u в time.Time без проверок.
+func fromUnix(n int64, u Unit) time.Time {
+	switch u {
+	case Second:
+		return time.Unix(n, 0)
+	case Milli:
+		return time.UnixMilli(n)
+	case Micro:
+		return time.UnixMicro(n)
+	}
+	return time.Unix(0, n)
+}
+
+// ToUnix возвращает время Unix момента t в единицах u, как t.Unix, t.UnixMilli, t.UnixMicro и t.UnixNano.
+// Дробная часть единицы отбрасывается. Если результат не помещается в int64, возвращается ErrOverflow,
+// а не молча переполненное число, как у t.UnixNano() для 2263 года.
+func ToUnix(t time.Time, u Unit) (int64, error) {
+	if err := u.validate(); err != nil {
+		return 0, err
+	}
+	first, last := u.GetRange()
+	if t.Before(first) || t.After(last) {
+		return 0, fmt.Errorf("%w: %v в %v не помещается в int64, допустимо с %v по %v", ErrOverflow, t, u, first, last)
+	}
+	switch u {
+	case Second:
+		return t.Unix(), nil
+	case Milli:
+		return t.UnixMilli(), nil
+	case Micro:
+		return t.UnixMicro(), nil
+	}
+	return t.UnixNano(), nil
+}
+
+// FromUnix возвращает момент, отстоящий на n единиц u от 1 января 1970 года UTC.
+// Миллисекунды, микросекунды и наносекунды из int64 всегда помещаются в time.Time,
+// а секунды - только в пределах Second.GetRange: за ними time.Unix молча переполняется.
+func FromUnix(n int64, u Unit) (time.Time, error) {
+	if err := u.validate(); err != nil {
+		return time.Time{}, err
+	}
+	if u == Second && (n < minUnixSeconds || n > maxUnixSeconds) {
+		return time.Time{}, fmt.Errorf("%w: %d с не помещается в time.Time, допустимо от %d до %d",
+			ErrOverflow, n, int64(minUnixSeconds), int64(maxUnixSeconds))
+	}
+	return fromUnix(n, u).UTC(), nil
+}
+
+var (
+	// MinTimeT32 - первый момент 32-битного time_t: 13 декабря 1901 года.
+	MinTimeT32 = time.Unix(math.MinInt32, 0).UTC()
+	// MaxTimeT32 - последний момент 32-битного time_t: 19 января 2038 года, 03:14:07 UTC.
+	MaxTimeT32 = time.Unix(math.MaxInt32, 0).UTC()
+)
+
+// ToTimeT32 возвращает время Unix в секундах для 32-битного time_t.
+// После MaxTimeT32 такой счетчик переполняется и показывает 1901 год.
+func ToTimeT32(t time.Time) (int32, error) {
+	if t.Before(MinTimeT32) || t.After(MaxTimeT32.Add(time.Second-1)) {
+		return 0, fmt.Errorf("%w: %w: %v, допустимо с %v по %v", ErrOverflow, ErrY2038, t, MinTimeT32, MaxTimeT32)
+	}
+	return int32(t.Unix()), nil
+}
+
+// FromTimeT32 возвращает момент 32-битного времени Unix.
+func FromTimeT32(n int32) time.Time {
+	return time.Unix(int64(n), 0).UTC()
+}
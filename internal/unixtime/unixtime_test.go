@@ -0,0 +1,126 @@
+package unixtime
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetRange(t *testing.T) {
+	tests := []struct {
+		u           Unit
+		first, last string
+	}{
+		{Nano, "1677-09-21T00:12:43.145224192Z", "2262-04-11T23:47:16.854775807Z"},
+		{Micro, "-290308-12-21T19:59:05.224192Z", "294247-01-10T04:00:54.775807999Z"},
+		{Second, "-292277022400-03-01T00:00:00Z", "292277024627-12-06T15:30:07.999999999Z"},
+	}
+	for _, tt := range tests {
+		first, last := tt.u.GetRange()
+		if got := first.Format(time.RFC3339Nano); got != tt.first {
+			t.Errorf("%v: first = %s, want %s", tt.u, got, tt.first)
+		}
+		if got := last.Format(time.RFC3339Nano); got != tt.last {
+			t.Errorf("%v: last = %s, want %s", tt.u, got, tt.last)
+		}
+	}
+}
+
+// TestToUnix проверяет ToUnix на границах GetRange каждой единицы и за ними.
+func TestToUnix(t *testing.T) {
+	tests := []struct {
+		u               Unit
+		first, last     int64
+		isAfterLastTime bool // есть ли time.Time после last
+	}{
+		{Nano, math.MinInt64, math.MaxInt64, true},
+		{Micro, math.MinInt64, math.MaxInt64, true},
+		{Milli, math.MinInt64, math.MaxInt64, true},
+		// секунды ограничены не int64, а time.Time: после last моментов нет
+		{Second, minUnixSeconds, maxUnixSeconds, false},
+	}
+	for _, tt := range tests {
+		first, last := tt.u.GetRange()
+		if got, err := ToUnix(first, tt.u); err != nil || got != tt.first {
+			t.Errorf("ToUnix(%v, %v) = %d, %v, want %d", first, tt.u, got, err, tt.first)
+		}
+		if got, err := ToUnix(last, tt.u); err != nil || got != tt.last {
+			t.Errorf("ToUnix(%v, %v) = %d, %v, want %d", last, tt.u, got, err, tt.last)
+		}
+		if got, err := ToUnix(first.Add(-1), tt.u); !errors.Is(err, ErrOverflow) {
+			t.Errorf("ToUnix(%v, %v) = %d, %v, want ErrOverflow", first.Add(-1), tt.u, got, err)
+		}
+		if tt.isAfterLastTime {
+			if got, err := ToUnix(last.Add(1), tt.u); !errors.Is(err, ErrOverflow) {
+				t.Errorf("ToUnix(%v, %v) = %d, %v, want ErrOverflow", last.Add(1), tt.u, got, err)
+			}
+		}
+		if got, err := ToUnix(time.Unix(0, 0), tt.u); err != nil || got != 0 {
+			t.Errorf("ToUnix(1970, %v) = %d, %v, want 0", tt.u, got, err)
+		}
+	}
+	if _, err := ToUnix(time.Unix(0, 0), Unit(time.Minute)); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("ToUnix в минутах: err = %v, want ErrUnknownUnit", err)
+	}
+}
+
+func TestFromUnix(t *testing.T) {
+	tests := []struct {
+		n       int64
+		u       Unit
+		want    string
+		isError bool
+	}{
+		{n: 0, u: Second, want: "1970-01-01T00:00:00Z"},
+		{n: math.MaxInt64, u: Nano, want: "2262-04-11T23:47:16.854775807Z"},
+		{n: math.MinInt64, u: Nano, want: "1677-09-21T00:12:43.145224192Z"},
+		{n: math.MaxInt64, u: Milli, want: "292278994-08-17T07:12:55.807Z"},
+		{n: maxUnixSeconds, u: Second, want: "292277024627-12-06T15:30:07Z"},
+		{n: minUnixSeconds, u: Second, want: "-292277022400-03-01T00:00:00Z"},
+		{n: maxUnixSeconds + 1, u: Second, isError: true},
+		{n: minUnixSeconds - 1, u: Second, isError: true},
+		{n: math.MaxInt64, u: Second, isError: true},
+	}
+	for _, tt := range tests {
+		got, err := FromUnix(tt.n, tt.u)
+		if tt.isError {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("FromUnix(%d, %v) = %v, %v, want ErrOverflow", tt.n, tt.u, got, err)
+			}
+			continue
+		}
+		if err != nil || got.Format(time.RFC3339Nano) != tt.want {
+			t.Errorf("FromUnix(%d, %v) = %v, %v, want %s", tt.n, tt.u, got.Format(time.RFC3339Nano), err, tt.want)
+		}
+	}
+}
+
+func TestToTimeT32(t *testing.T) {
+	tests := []struct {
+		t       time.Time
+		want    int32
+		isError bool
+	}{
+		{t: MaxTimeT32, want: math.MaxInt32},
+		{t: MaxTimeT32.Add(time.Second - 1), want: math.MaxInt32},
+		{t: MinTimeT32, want: math.MinInt32},
+		{t: MaxTimeT32.Add(time.Second), isError: true},
+		{t: MinTimeT32.Add(-1), isError: true},
+	}
+	for _, tt := range tests {
+		got, err := ToTimeT32(tt.t)
+		if tt.isError {
+			if !errors.Is(err, ErrOverflow) || !errors.Is(err, ErrY2038) {
+				t.Errorf("ToTimeT32(%v) = %d, %v, want ErrOverflow и ErrY2038", tt.t, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ToTimeT32(%v) = %d, %v, want %d", tt.t, got, err, tt.want)
+		}
+		if back := FromTimeT32(got); !back.Equal(tt.t.Truncate(time.Second)) {
+			t.Errorf("FromTimeT32(%d) = %v, want %v", got, back, tt.t)
+		}
+	}
+}
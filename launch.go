@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"example/internal/clock"
+	co "example/internal/constants"
+	"example/internal/countdown"
+	"example/internal/launch"
+)
+
+// runLaunch проводит предстартовую подготовку: отсчет из f25, на каждом шаге которого
+// подсистемы в своих горутинах сообщают о готовности, и печатает ход подготовки.
+func runLaunch(args []string) error {
+	fs := flag.NewFlagSet("launch", flag.ContinueOnError)
+	from := fs.Int("from", co.CountdownFrom, "с какого числа начинать отсчет")
+	interval := fs.Duration("interval", time.Second, "шаг отсчета")
+	seed := fs.Int64("seed", 0, "зерно генераторов случайных чисел (0 - случайное)")
+	isFake := fs.Bool("fake", false, "поддельные часы: подготовка выполняется мгновенно")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+		fmt.Fprintf(os.Stderr, "seed: %d\n", *seed)
+	}
+	var c clock.Clock = clock.Real{}
+	if *isFake {
+		c = clock.NewFake(time.Now())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	timeline, err := launch.Simulate(ctx, c, launch.Config{
+		From:       *from,
+		Interval:   *interval,
+		Subsystems: launch.DefaultSubsystems(),
+		Seed:       *seed,
+	})
+	if timeline == nil {
+		return err
+	}
+	if err := launch.WriteTimeline(os.Stdout, timeline); err != nil {
+		return err
+	}
+	if errors.Is(err, countdown.ErrAborted) {
+		fmt.Println("Запуск отменяется.")
+	}
+	return err
+}
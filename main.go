@@ -6,14 +6,18 @@ package main
 import (
 	"fmt" // Делает пакет fmt (формат) доступным для использования
 	"math"
-	"math/rand"
+	"os"
 	"strings"
 	"time"
+
+	"example/internal/calendar"
+	"example/internal/money"
+	"example/internal/orbit"
 )
 
-func f1() {
+func f1(env *Env) {
 	// Выводит текст Hello, playground на экран
-	fmt.Println("Hello, playground")
+	fmt.Fprintln(env.Out, "Hello, playground")
 }
 
 /*
@@ -22,97 +26,102 @@ func f2() // отсутствует тело функции
 } // новая строка перед {
 */
 
-func f3() {
-	fmt.Println("Hello, Nathan")
-	fmt.Println("こんにちは Здравствуйте Hola")
+func f3(env *Env) {
+	fmt.Fprintln(env.Out, "Hello, Nathan")
+	fmt.Fprintln(env.Out, "こんにちは Здравствуйте Hola")
 }
 
-func f4() {
-	fmt.Print("Мой вес на поверхности Марса равен ")
-	fmt.Print(55.0 * 0.3783) // В результате 20.8065
-	fmt.Println(" килограммам, а мой возраст равен", 41*365/687, "годам.")
+func f4(env *Env) {
+	fmt.Fprint(env.Out, "Мой вес на поверхности Марса равен ")
+	fmt.Fprint(env.Out, 55.0*0.3783) // В результате 20.8065
+	fmt.Fprintln(env.Out, " килограммам, а мой возраст равен", 41*365/687, "годам.")
 }
 
-func f5() {
+func f5(env *Env) {
 	// Выводит: Мой вес на поверхности Марса равен 20.8065 килограммам,
-	fmt.Printf("Мой вес на поверхности Марса равен %v килограммам, ", 55.0*0.3783)
+	fmt.Fprintf(env.Out, "Мой вес на поверхности Марса равен %v килограммам, ", 55.0*0.3783)
 	// Выводит: а мой возраст равен 21 годам.
-	fmt.Printf("а мой возраст равен %v годам.\n", 41*365/687)
+	fmt.Fprintf(env.Out, "а мой возраст равен %v годам.\n", 41*365/687)
 }
 
-func f6() {
+func f6(env *Env) {
 	// Выводит: Мой вес на поверхности Земли равен 55 килограммам.
-	fmt.Printf("Мой вес на поверхности %v равен %v килограммам.\n", "Земли", 55)
+	fmt.Fprintf(env.Out, "Мой вес на поверхности %v равен %v килограммам.\n", "Земли", 55)
 }
 
-func f7() {
-	fmt.Printf("%-15v $%4v\n", "SpaceX", 94)
-	fmt.Printf("%-15v $%4v\n", "Virgin Galactic", 100)
+func f7(env *Env) {
+	fmt.Fprintf(env.Out, "%-15v $%4v\n", "SpaceX", 94)
+	fmt.Fprintf(env.Out, "%-15v $%4v\n", "Virgin Galactic", 100)
 }
 
-func f8() {
+func f8(env *Env) {
 	const lightSpeed = 299792 // км/с
 	var distance = 56000000   // км
 
-	fmt.Println(distance/lightSpeed, "секунд") // В результате 186 секунд
+	fmt.Fprintln(env.Out, distance/lightSpeed, "секунд") // В результате 186 секунд
 
 	distance = 401000000
-	fmt.Println(distance/lightSpeed, "секунд") // В результате 1337 секунд
+	fmt.Fprintln(env.Out, distance/lightSpeed, "секунд") // В результате 1337 секунд
 }
 
-func f9() {
+func f9(env *Env) {
 	var distance1 = "1"
 	var speed1 = 1
 
-	fmt.Printf("%v %v", distance1, speed1)
+	fmt.Fprintf(env.Out, "%v %v", distance1, speed1)
 
 	var (
 		distance2 = "2"
 		speed2    = 2
 	)
 
-	fmt.Printf("%v %v", distance2, speed2)
+	fmt.Fprintf(env.Out, "%v %v", distance2, speed2)
 
 	var distance3, speed3 = "3", 3
 
-	fmt.Printf("%v %v", distance3, speed3)
+	fmt.Fprintf(env.Out, "%v %v", distance3, speed3)
 
 }
 
-func f10() {
+func f10(env *Env) {
 	var weight = 149.0
-	fmt.Println(weight)
+	fmt.Fprintln(env.Out, weight)
 	weight = weight * 0.3783
-	fmt.Println(weight)
+	fmt.Fprintln(env.Out, weight)
 	weight *= 0.3783
-	fmt.Println(weight)
+	fmt.Fprintln(env.Out, weight)
 
 	var age = 41
-	fmt.Println(age)
+	fmt.Fprintln(env.Out, age)
 	age = age + 1 // С днем рождения!
-	fmt.Println(age)
+	fmt.Fprintln(env.Out, age)
 	age += 1
-	fmt.Println(age)
+	fmt.Fprintln(env.Out, age)
 	age++
-	fmt.Println(age)
+	fmt.Fprintln(env.Out, age)
 }
 
-func f11() {
-	var num = rand.Intn(10) + 1
-	fmt.Println(num)
+func f11(env *Env) {
+	var num = env.Rand.Intn(10) + 1
+	fmt.Fprintln(env.Out, num)
 
-	num = rand.Intn(10) + 1
-	fmt.Println(num)
+	num = env.Rand.Intn(10) + 1
+	fmt.Fprintln(env.Out, num)
 }
 
 /*
 Расстояние между Землей и Марсом в разное время отличается и зависит от того,
 где планеты в данный конкретный момент времени находятся на орбите Солнца.
 Напишите программу для генерации случайного расстояния в промежутке от 56 000 000 до 401 000 000 км.
+
+Вместо случайного числа расстояние считается по модели орбит из internal/orbit
+на текущий момент часов env.Clock. Обычно оно попадает в этот промежуток, но не всегда:
+в великие противостояния Марс подходит ближе 56 000 000 км, например 27 августа 2003 года
+модель дает 55 800 409 км.
 */
-func f12() {
-	var distance = rand.Intn(401_000_000-56_000_000) + 56_000_000
-	fmt.Println(distance)
+func f12(env *Env) {
+	var distance = int(math.Round(orbit.Distance(env.Clock.Now())))
+	fmt.Fprintln(env.Out, distance)
 }
 
 /*
@@ -120,30 +129,30 @@ func f12() {
 чтобы добраться до Марса за 28 дней.
 Предположим, что расстояние от Земли до Марса равно 56 000 000 км.
 */
-func f13() {
+func f13(env *Env) {
 	const hoursPerDay = 24
 
 	var days = 28
 	var distance = 56_000_000 // km
 
-	fmt.Println(distance/(days*hoursPerDay), "км/ч")
+	fmt.Fprintln(env.Out, distance/(days*hoursPerDay), "км/ч")
 }
 
-func f14() {
+func f14(env *Env) {
 	var (
 		walkOutside     = true
 		takeTheBluePill = false
 	)
-	fmt.Printf("%v %v", walkOutside, takeTheBluePill)
+	fmt.Fprintf(env.Out, "%v %v", walkOutside, takeTheBluePill)
 }
 
-func f15() {
-	fmt.Println("Вы находитесь в темной пещере.")
+func f15(env *Env) {
+	fmt.Fprintln(env.Out, "Вы находитесь в темной пещере.")
 
 	var command = "выйти наружу"
 	var exit = strings.Contains(command, "наружу")
 
-	fmt.Println("Вы покидаете пещеру:", exit) // Выводит: Вы покидаете пещеру: true
+	fmt.Fprintln(env.Out, "Вы покидаете пещеру:", exit) // Выводит: Вы покидаете пещеру: true
 }
 
 /*
@@ -155,30 +164,30 @@ func f15() {
 >= больше или равно
 */
 
-func f16() {
-	fmt.Println("На знаке снаружи написано 'Несовершеннолетним вход запрещен'.")
+func f16(env *Env) {
+	fmt.Fprintln(env.Out, "На знаке снаружи написано 'Несовершеннолетним вход запрещен'.")
 
 	var age = 41
 	var adult = age >= 18
 
-	fmt.Printf("В возрасте %v, я совершеннолетний? %v\n", age, adult)
+	fmt.Fprintf(env.Out, "В возрасте %v, я совершеннолетний? %v\n", age, adult)
 }
 
-func f17() {
-	fmt.Println("яблоко" > "банан")
+func f17(env *Env) {
+	fmt.Fprintln(env.Out, "яблоко" > "банан")
 }
 
-func f18() {
+func f18(env *Env) {
 	var room = "пещера"
 
 	if room == "пещера" {
-		fmt.Println("Вы находитесь в тускло освещенной пещере.")
+		fmt.Fprintln(env.Out, "Вы находитесь в тускло освещенной пещере.")
 	} else if room == "вход" {
-		fmt.Println("Здесь есть вход в пещеру и путь на восток.")
+		fmt.Fprintln(env.Out, "Здесь есть вход в пещеру и путь на восток.")
 	} else if room == "гора" {
-		fmt.Println("Здесь крутой утес. Тропа ведет к подножью горы.")
+		fmt.Fprintln(env.Out, "Здесь крутой утес. Тропа ведет к подножью горы.")
 	} else {
-		fmt.Println("Здесь ничего нет.")
+		fmt.Fprintln(env.Out, "Здесь ничего нет.")
 	}
 }
 
@@ -193,79 +202,79 @@ else if и else являются опциональными.
 - Любой год, что делится без остатка на четыре, но не делится без остатка на 100;
 - Или любой год, что делится без остатка на 400.
 */
-func f19() {
-	fmt.Println("На дворе 2100 год. Он високосный?")
+func f19(env *Env) {
+	fmt.Fprintln(env.Out, "На дворе 2100 год. Он високосный?")
 
 	var year = 2100
-	var leap = year%400 == 0 || (year%4 == 0 && year%100 != 0)
+	var leap = calendar.IsLeap(year) // year%400 == 0 || (year%4 == 0 && year%100 != 0)
 
 	if leap {
-		fmt.Println("Этот год високосный!")
+		fmt.Fprintln(env.Out, "Этот год високосный!")
 	} else {
-		fmt.Println("К сожалению, нет. Этот год не високосный.")
+		fmt.Fprintln(env.Out, "К сожалению, нет. Этот год не високосный.")
 	}
 }
 
-func f20() {
+func f20(env *Env) {
 	var haveTorch = true
 	var litTorch = false
 
 	if !haveTorch || !litTorch {
-		fmt.Println("Ничего не видно.") // Вывод: Ничего не видно.
+		fmt.Fprintln(env.Out, "Ничего не видно.") // Вывод: Ничего не видно.
 	}
 }
 
-func f21() {
-	fmt.Println("Здесь вход в пещеру и путь на восток.")
+func f21(env *Env) {
+	fmt.Fprintln(env.Out, "Здесь вход в пещеру и путь на восток.")
 	var command = "зайти внутрь"
 
 	switch command { // Сравнивает case с command
 	case "идти на восток":
-		fmt.Println("Вы направляетесь к горе.")
+		fmt.Fprintln(env.Out, "Вы направляетесь к горе.")
 	case "зайти в пещеру", "зайти внутрь": // Запятая разделяет список возможных значений
-		fmt.Println("Вы находитесь в тускло освещенной пещере.")
+		fmt.Fprintln(env.Out, "Вы находитесь в тускло освещенной пещере.")
 	case "прочитать знак":
-		fmt.Println("На знаке написано 'Несовершеннолетним вход запрещен'.")
+		fmt.Fprintln(env.Out, "На знаке написано 'Несовершеннолетним вход запрещен'.")
 	default:
-		fmt.Println("Пока не совсем понятно.")
+		fmt.Fprintln(env.Out, "Пока не совсем понятно.")
 	}
 }
 
-func f22() {
+func f22(env *Env) {
 	var room = "озеро"
 
 	switch { // Выражения для каждого случая
 	case room == "пещера":
-		fmt.Println("Вы находитесь в тускло освещенной пещере.")
+		fmt.Fprintln(env.Out, "Вы находитесь в тускло освещенной пещере.")
 	case room == "озеро":
-		fmt.Println("Лед кажется достаточно крепким.")
+		fmt.Fprintln(env.Out, "Лед кажется достаточно крепким.")
 		fallthrough // Переходит на следующий случай бкз сравнения!
 	case room == "глубина":
-		fmt.Println("Вода такая холодная, что сводит кости.")
+		fmt.Fprintln(env.Out, "Вода такая холодная, что сводит кости.")
 	}
 }
 
-func f23() {
+func f23(env *Env) {
 	var count = 10 // Объявление и инициализация
 
 	for count > 0 { // Условие
-		fmt.Println(count)
-		time.Sleep(time.Second)
+		fmt.Fprintln(env.Out, count)
+		env.Clock.Sleep(time.Second)
 		count-- // Обратный отсчет; в противном случае цикл будет длиться вечно
 	}
-	fmt.Println("Запуск!")
+	fmt.Fprintln(env.Out, "Запуск!")
 }
 
-func f24() {
+func f24(env *Env) {
 	var degrees = 0
 
 	for {
-		fmt.Println(degrees)
+		fmt.Fprintln(env.Out, degrees)
 
 		degrees++
 		if degrees >= 360 {
 			degrees = 0
-			if rand.Intn(10) == 0 {
+			if env.Rand.Intn(10) == 0 {
 				break
 			}
 		}
@@ -277,104 +286,104 @@ func f24() {
 Реализуйте обратный отсчет, где на каждую секунду приходится шанс 1 к 100,
 что ввиду определенных обстоятельств запуск прервется, и счетчик остановится.
 */
-func f25() {
+func f25(env *Env) {
 	var count = 10
 
 	for count > 0 {
-		fmt.Println(count)
-		time.Sleep(time.Second)
-		if rand.Intn(100) == 0 {
+		fmt.Fprintln(env.Out, count)
+		env.Clock.Sleep(time.Second)
+		if env.Rand.Intn(100) == 0 {
 			break
 		}
 		count--
 
 	}
 	if count == 0 {
-		fmt.Println("Запуск!")
+		fmt.Fprintln(env.Out, "Запуск!")
 	} else {
-		fmt.Println("Запуск отменяется.")
+		fmt.Fprintln(env.Out, "Запуск отменяется.")
 	}
 }
 
-func f26() {
-	switch time.Now().Weekday() {
+func f26(env *Env) {
+	switch env.Clock.Now().Weekday() {
 
 	case time.Monday:
-		fmt.Println("Сегодня понедельник.")
+		fmt.Fprintln(env.Out, "Сегодня понедельник.")
 
 	case time.Tuesday:
-		fmt.Println("Сегодня вторник.")
+		fmt.Fprintln(env.Out, "Сегодня вторник.")
 
 	case time.Wednesday:
-		fmt.Println("Сегодня среда.")
+		fmt.Fprintln(env.Out, "Сегодня среда.")
 
 	case time.Thursday:
-		fmt.Println("Сегодня четверг.")
+		fmt.Fprintln(env.Out, "Сегодня четверг.")
 
 	case time.Friday:
-		fmt.Println("Сегодня пятница.")
+		fmt.Fprintln(env.Out, "Сегодня пятница.")
 
 	case time.Saturday:
-		fmt.Println("Сегодня суббота.")
+		fmt.Fprintln(env.Out, "Сегодня суббота.")
 
 	case time.Sunday:
-		fmt.Println("Сегодня воскресенье.")
+		fmt.Fprintln(env.Out, "Сегодня воскресенье.")
 	}
 }
 
-func f27() {
-	switch time.Now().Weekday() {
+func f27(env *Env) {
+	switch env.Clock.Now().Weekday() {
 
 	case time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday:
-		fmt.Println("будний день")
+		fmt.Fprintln(env.Out, "будний день")
 	case time.Saturday, time.Sunday:
-		fmt.Println("выходные дни")
+		fmt.Fprintln(env.Out, "выходные дни")
 	}
 }
 
-func f28() {
+func f28(env *Env) {
 	size := "XXXL"
 
 	switch size {
 
 	case "XXS":
-		fmt.Println("очень очень маленький")
+		fmt.Fprintln(env.Out, "очень очень маленький")
 
 	case "XS":
-		fmt.Println("очень маленький")
+		fmt.Fprintln(env.Out, "очень маленький")
 
 	case "S":
-		fmt.Println("маленький")
+		fmt.Fprintln(env.Out, "маленький")
 
 	case "M":
-		fmt.Println("средний")
+		fmt.Fprintln(env.Out, "средний")
 
 	case "L":
-		fmt.Println("большой")
+		fmt.Fprintln(env.Out, "большой")
 
 	case "XL":
-		fmt.Println("очень большой")
+		fmt.Fprintln(env.Out, "очень большой")
 
 	case "XXL":
-		fmt.Println("очень очень большой")
+		fmt.Fprintln(env.Out, "очень очень большой")
 
 	default:
-		fmt.Println("неизвестно")
+		fmt.Fprintln(env.Out, "неизвестно")
 	}
 }
 
-func f29() {
+func f29(env *Env) {
 	switch num := 6; num%2 == 0 {
 
 	case true:
-		fmt.Println("even value")
+		fmt.Fprintln(env.Out, "even value")
 
 	case false:
-		fmt.Println("odd value")
+		fmt.Fprintln(env.Out, "odd value")
 	}
 }
 
-func f30() {
+func f30(env *Env) {
 	w := "a b c\td\nefg hi"
 
 	for _, e := range w {
@@ -383,56 +392,56 @@ func f30() {
 		case ' ', '\t', '\n':
 			break // заканчивает switch
 		default:
-			fmt.Printf("%c\n", e)
+			fmt.Fprintf(env.Out, "%c\n", e)
 		}
 	}
 }
 
-func f31() {
+func f31(env *Env) {
 
-	now := time.Now()
+	now := env.Clock.Now()
 
 	switch {
 	case now.Hour() < 12:
-		fmt.Println("AM")
+		fmt.Fprintln(env.Out, "AM")
 
 	default:
-		fmt.Println("PM")
+		fmt.Fprintln(env.Out, "PM")
 	}
 }
 
 // A -> B -> C -> D -> E
 
-func f32() {
+func f32(env *Env) {
 
 	nextstop := "B"
 
-	fmt.Println("Stops ahead of us:")
+	fmt.Fprintln(env.Out, "Stops ahead of us:")
 
 	switch nextstop {
 
 	case "A":
-		fmt.Println("A")
+		fmt.Fprintln(env.Out, "A")
 		fallthrough
 
 	case "B":
-		fmt.Println("B")
+		fmt.Fprintln(env.Out, "B")
 		fallthrough
 
 	case "C":
-		fmt.Println("C")
+		fmt.Fprintln(env.Out, "C")
 		fallthrough
 
 	case "D":
-		fmt.Println("D")
+		fmt.Fprintln(env.Out, "D")
 		fallthrough
 
 	case "E":
-		fmt.Println("E")
+		fmt.Fprintln(env.Out, "E")
 	}
 }
 
-func f33() {
+func f33(env *Env) {
 
 	var data interface{}
 
@@ -441,22 +450,22 @@ func f33() {
 	switch mytype := data.(type) {
 
 	case string:
-		fmt.Println("string")
+		fmt.Fprintln(env.Out, "string")
 
 	case bool:
-		fmt.Println("boolean")
+		fmt.Fprintln(env.Out, "boolean")
 
 	case float64:
-		fmt.Println("float64 type")
+		fmt.Fprintln(env.Out, "float64 type")
 
 	case float32:
-		fmt.Println("float32 type")
+		fmt.Fprintln(env.Out, "float32 type")
 
 	case int:
-		fmt.Println("int")
+		fmt.Fprintln(env.Out, "int")
 
 	default:
-		fmt.Printf("%T", mytype)
+		fmt.Fprintf(env.Out, "%T", mytype)
 	}
 }
 
@@ -465,12 +474,12 @@ func f33() {
 В следующей программе функция main начинает область видимости,
 а вместе с циклом for стартует вложенная область.
 */
-func f34() {
+func f34(env *Env) {
 	var count = 0
 
 	for count < 10 { // Начало области видимости
-		var num = rand.Intn(10) + 1
-		fmt.Println(num)
+		var num = env.Rand.Intn(10) + 1
+		fmt.Fprintln(env.Out, num)
 
 		count++
 	} // Конец области видимости
@@ -489,12 +498,12 @@ func f34() {
 */
 
 // краткое объявление переменных в go
-func f35() {
+func f35(env *Env) {
 	var count1 = 10
-	fmt.Println(count1)
+	fmt.Fprintln(env.Out, count1)
 	// аналогичная запись
 	count2 := 10
-	fmt.Println(count2)
+	fmt.Fprintln(env.Out, count2)
 }
 
 /*
@@ -507,14 +516,14 @@ func f35() {
 При использовании данной формы цикла for очень важен порядок: инициализация, условие, операция.
 */
 
-func f36() {
+func f36(env *Env) {
 	var count = 0
 
 	for count = 10; count > 0; count-- {
-		fmt.Println(count)
+		fmt.Fprintln(env.Out, count)
 	}
 
-	fmt.Println(count) // count остается в области видимости
+	fmt.Fprintln(env.Out, count) // count остается в области видимости
 }
 
 /*
@@ -528,9 +537,9 @@ func f36() {
 тогда компилятор Go выдал бы ошибку undefined: count.
 */
 
-func f37() {
+func f37(env *Env) {
 	for count := 10; count > 0; count-- {
-		fmt.Println(count)
+		fmt.Fprintln(env.Out, count)
 	} // count больше не в области видимости
 }
 
@@ -539,28 +548,28 @@ func f37() {
 В следующем коде переменная num может использовать в любом ответвлении оператора if.
 */
 
-func f38() {
-	if num := rand.Intn(3); num == 0 {
-		fmt.Println("Space Adventures")
+func f38(env *Env) {
+	if num := env.Rand.Intn(3); num == 0 {
+		fmt.Fprintln(env.Out, "Space Adventures")
 	} else if num == 1 {
-		fmt.Println("SpaceX")
+		fmt.Fprintln(env.Out, "SpaceX")
 	} else {
-		fmt.Println("Virgin Galactic")
+		fmt.Fprintln(env.Out, "Virgin Galactic")
 	} //num больше не в области видимости
 }
 
 // Краткое объявление может использоваться с оператором switch, как показано в следующей программе:
 
-func f39() {
-	switch num := rand.Intn(10); num {
+func f39(env *Env) {
+	switch num := env.Rand.Intn(10); num {
 	case 0:
-		fmt.Println("Space Adventures")
+		fmt.Fprintln(env.Out, "Space Adventures")
 	case 1:
-		fmt.Println("SpaceX")
+		fmt.Fprintln(env.Out, "SpaceX")
 	case 2:
-		fmt.Println("Virgin Galactic")
+		fmt.Fprintln(env.Out, "Virgin Galactic")
 	default:
-		fmt.Println("Random spaceline #", num)
+		fmt.Fprintln(env.Out, "Random spaceline #", num)
 	}
 }
 
@@ -572,27 +581,27 @@ func f39() {
 почему особенно важно задуматься об области видимости во время объявления переменной.
 */
 
-var era = "AD" // переменная era доступна через пакет
+var era = calendar.AD // переменная era доступна через пакет; вместо строки "AD" - эра из internal/calendar, печатается так же
 
 /*
 На заметку:
 Краткое объявление недоступно для переменных, объявленных в области видимости пакета,
-поэтому переменную era нельзя объявить через era := "AD" в ее текущей позиции.
+поэтому переменную era нельзя объявить через era := calendar.AD в ее текущей позиции.
 */
 
-func f40() {
+func f40(env *Env) {
 	year := 2018 // переменные era и year находятся в области видимости
 
-	switch month := rand.Intn(12) + 1; month { // переменные era, year и month в области видимости
+	switch month := env.Rand.Intn(12) + 1; month { // переменные era, year и month в области видимости
 	case 2:
-		day := rand.Intn(28) + 1 // новый день
-		fmt.Println(era, year, month, day)
+		day := env.Rand.Intn(28) + 1 // новый день
+		fmt.Fprintln(env.Out, era, year, month, day)
 	case 4, 6, 9, 11:
-		day := rand.Intn(30) + 1
-		fmt.Println(era, year, month, day)
+		day := env.Rand.Intn(30) + 1
+		fmt.Fprintln(env.Out, era, year, month, day)
 	default:
-		day := rand.Intn(31) + 1
-		fmt.Println(era, year, month, day)
+		day := env.Rand.Intn(31) + 1
+		fmt.Fprintln(env.Out, era, year, month, day)
 	} // month и day за пределами области видимости
 } // year за пределами области видимости
 
@@ -634,20 +643,13 @@ func f40() {
 Следующая программа по-прежнему выводит случайную дату.
 */
 
-func f41() {
+func f41(env *Env) {
 	year := 2018
-	month := rand.Intn(12) + 1
-	daysInMonth := 31
-
-	switch month {
-	case 2:
-		daysInMonth = 28
-	case 4, 6, 9, 11:
-		daysInMonth = 30
-	}
+	month := env.Rand.Intn(12) + 1
+	daysInMonth := calendar.DaysInMonth(year, time.Month(month)) // 28 в феврале, 30 в апреле, июне, сентябре и ноябре
 
-	day := rand.Intn(daysInMonth) + 1
-	fmt.Println(era, year, month, day)
+	day := env.Rand.Intn(daysInMonth) + 1
+	fmt.Fprintln(env.Out, era, year, month, day)
 
 }
 
@@ -667,25 +669,14 @@ func f41() {
 - Используйте цикл for для генерации и отображения 10 случайных дат.
 */
 
-func f42() {
+func f42(env *Env) {
 	for count := 0; count < 10; count++ {
-		year := 2018 + rand.Intn(10)
-		leap := year%400 == 0 || (year%4 == 0 && year%100 != 0)
-		month := rand.Intn(12) + 1
-
-		daysInMonth := 31
-		switch month {
-		case 2:
-			daysInMonth = 28
-			if leap {
-				daysInMonth = 29
-			}
-		case 4, 6, 9, 11:
-			daysInMonth = 30
-		}
+		year := 2018 + env.Rand.Intn(10)
+		month := env.Rand.Intn(12) + 1
+		daysInMonth := calendar.DaysInMonth(year, time.Month(month)) // в високосный год в феврале 29 дней
 
-		day := rand.Intn(daysInMonth) + 1
-		fmt.Println(era, year, month, day)
+		day := env.Rand.Intn(daysInMonth) + 1
+		fmt.Fprintln(env.Out, era, year, month, day)
 	}
 }
 
@@ -730,16 +721,16 @@ func f42() {
 
 const secondsPerDay = 86400
 
-func f43() {
+func f43(env *Env) {
 	distance := 62_100_000
 	company := ""
 	trip := ""
 
-	fmt.Println("Spaceline        Days Trip type  Price")
-	fmt.Println("======================================")
+	fmt.Fprintln(env.Out, "Spaceline        Days Trip type  Price")
+	fmt.Fprintln(env.Out, "======================================")
 
 	for count := 0; count < 10; count++ {
-		switch rand.Intn(3) {
+		switch env.Rand.Intn(3) {
 		case 0:
 			company = "Space Adventures"
 		case 1:
@@ -748,18 +739,18 @@ func f43() {
 			company = "Virgin Galactic"
 		}
 
-		speed := rand.Intn(15) + 16                  // 16-30 km/s
+		speed := env.Rand.Intn(15) + 16              // 16-30 km/s
 		duration := distance / speed / secondsPerDay // days
 		price := 20.0 + speed                        // millions
 
-		if rand.Intn(2) == 1 {
+		if env.Rand.Intn(2) == 1 {
 			trip = "Round-trip"
 			price = price * 2
 		} else {
 			trip = "One-way"
 		}
 
-		fmt.Printf("%-16v %4v %-10v $%4v\n", company, duration, trip, price)
+		fmt.Fprintf(env.Out, "%-16v %4v %-10v $%4v\n", company, duration, trip, price)
 	}
 }
 
@@ -785,12 +776,12 @@ func f43() {
 Следующие три строки кода эквивалентны, так как компилятор Go отнесет переменную days к типу float64 даже без дополнительного уточнения:
 */
 
-func f44() {
+func f44(env *Env) {
 	days1 := 365.2425 // краткое объявление
 	var days2 = 365.2425
 	var days3 float64 = 365.2425
 
-	fmt.Printf("%v %v %v", days1, days2, days3)
+	fmt.Fprintf(env.Out, "%v %v %v", days1, days2, days3)
 }
 
 /*
@@ -802,9 +793,9 @@ func f44() {
 При инициализации переменной с целым числом Go не будет знать, что вам требуется тип с плавающей запятой, пока вы не уточните данный тип с плавающей запятой:
 */
 
-func f45() {
+func f45(env *Env) {
 	var answer float64 = 42
-	fmt.Print(answer)
+	fmt.Fprint(env.Out, answer)
 }
 
 //Числа одинарной точности float32
@@ -820,12 +811,12 @@ func f45() {
 В следующем коде показан пример использования float32:
 */
 
-func f46() {
+func f46(env *Env) {
 	var pi64 = math.Pi
 	var pi32 float32 = math.Pi
 
-	fmt.Println(pi64) // Выводит: 3.141592653589793
-	fmt.Println(pi32) // Выводит: 3.1415927
+	fmt.Fprintln(env.Out, pi64) // Выводит: 3.141592653589793
+	fmt.Fprintln(env.Out, pi32) // Выводит: 3.1415927
 }
 
 //Нулевое значение в Golang
@@ -835,9 +826,9 @@ func f46() {
 Значение по умолчанию присваивается при объявлении переменной, которая не инициализируется конкретным значением.
 */
 
-func f47() {
-	var price float64  // price := 0.0
-	fmt.Println(price) // Выводит: 0
+func f47(env *Env) {
+	var price float64            // price := 0.0
+	fmt.Fprintln(env.Out, price) // Выводит: 0
 }
 
 //Отображение типа чисел с плавающей запятой в Golang
@@ -847,13 +838,13 @@ func f47() {
 Если вам это не нужно, используйте Printf с символом для форматирования %f для уточнения количества чисел после запятой.
 */
 
-func f48() {
+func f48(env *Env) {
 	third := 1.0 / 3
-	fmt.Println(third)           // Выводит: 0.3333333333333333
-	fmt.Printf("%v\n", third)    // Выводит: 0.3333333333333333
-	fmt.Printf("%f\n", third)    // Выводит: 0.333333
-	fmt.Printf("%.3f\n", third)  // Выводит: 0.333
-	fmt.Printf("%4.2f\n", third) // Выводит: 0.33
+	fmt.Fprintln(env.Out, third)           // Выводит: 0.3333333333333333
+	fmt.Fprintf(env.Out, "%v\n", third)    // Выводит: 0.3333333333333333
+	fmt.Fprintf(env.Out, "%f\n", third)    // Выводит: 0.333333
+	fmt.Fprintf(env.Out, "%.3f\n", third)  // Выводит: 0.333
+	fmt.Fprintf(env.Out, "%4.2f\n", third) // Выводит: 0.33
 }
 
 /*
@@ -865,9 +856,9 @@ func f48() {
 Для заполнения пропуском нулями вместо пробелов требуется добавить в префикс ширины ноль
 */
 
-func f49() {
+func f49(env *Env) {
 	third := 1.0 / 3
-	fmt.Printf("%05.2f\n", third) // Выводит: 00.33
+	fmt.Fprintf(env.Out, "%05.2f\n", third) // Выводит: 00.33
 }
 
 //Точность чисел с плавающей запятой в Go
@@ -884,13 +875,13 @@ func f49() {
 В результате компьютеры могут точно передать значение 1/3, но с другими числами могут быть вызваны ошибки округления.
 */
 
-func f50() {
+func f50(env *Env) {
 	third := 1.0 / 3.0
-	fmt.Println(third + third + third) // Выводит: 1
+	fmt.Fprintln(env.Out, third+third+third) // Выводит: 1
 
 	piggyBank := 0.1
 	piggyBank += 0.2
-	fmt.Println(piggyBank) // Выводит: 0.30000000000000004
+	fmt.Fprintln(env.Out, piggyBank) // Выводит: 0.30000000000000004
 }
 
 /*
@@ -906,13 +897,13 @@ func f50() {
 Это показано  в примерах ниже на примере конвертера температуры:
 */
 
-func f51() {
+func f51(env *Env) {
 	celsius := 21.0
-	fmt.Print((celsius/5.0*9.0)+32, "° F\n")
-	fmt.Print((9.0/5.0*celsius)+32, "° F\n")
+	fmt.Fprint(env.Out, (celsius/5.0*9.0)+32, "° F\n")
+	fmt.Fprint(env.Out, (9.0/5.0*celsius)+32, "° F\n")
 	// В выводе: 69.80000000000001° F
 	fahrenheit := (celsius * 9.0 / 5.0) + 32.0
-	fmt.Print(fahrenheit, "° F") // Выводит: 69.8° F
+	fmt.Fprint(env.Out, fahrenheit, "° F") // Выводит: 69.8° F
 }
 
 //Сравнение чисел с плавающей запятой
@@ -922,10 +913,10 @@ func f51() {
 Имейте это в виду, когда решите сравнить числа с плавающей запятой:
 */
 
-func f52() {
+func f52(env *Env) {
 	piggyBank := 0.1
 	piggyBank += 0.2
-	fmt.Println(piggyBank == 0.3) // Выводит: false
+	fmt.Fprintln(env.Out, piggyBank == 0.3) // Выводит: false
 }
 
 /*
@@ -934,10 +925,10 @@ func f52() {
 Для принятия абсолютного значения float64 в пакете math есть функция Abs:
 */
 
-func f53() {
+func f53(env *Env) {
 	piggyBank := 0.1
 	piggyBank += 0.2
-	fmt.Println(math.Abs(piggyBank-0.3) < 0.000_1) // Выводит: false
+	fmt.Fprintln(env.Out, math.Abs(piggyBank-0.3) < 0.000_1) // Выводит: true
 }
 
 /*
@@ -954,19 +945,29 @@ func f53() {
 Пускай после каждого пополнения копилки текущий баланс отображается на экране, отформатированный с нужной шириной и точностью.
 */
 
-func f54() {
-	piggyBank := 0.0
+// Сумма в копилке хранится в money.Money, то есть в целых центах: на float64
+// пятицентовики копятся с ошибкой округления, и сравнение с $20.00 ненадежно, как в f52.
+func f54(env *Env) {
+	coins := []money.Money{
+		money.MustParse("$0.05"),
+		money.MustParse("$0.10"),
+		money.MustParse("$0.25"),
+	}
+	goal := money.MustParse("$20.00")
+	piggyBank := money.New(0, money.USD)
 
-	for piggyBank < 20.00 {
-		switch rand.Intn(3) {
-		case 0:
-			piggyBank += 0.05
-		case 1:
-			piggyBank += 0.10
-		case 2:
-			piggyBank += 0.25
+	for {
+		if cmp, _ := piggyBank.Cmp(goal); cmp >= 0 {
+			break
+		}
+
+		var err error
+		piggyBank, err = piggyBank.Add(coins[env.Rand.Intn(len(coins))])
+		if err != nil {
+			fmt.Fprintln(env.Out, err)
+			return
 		}
-		fmt.Printf("$%5.2f\n", piggyBank)
+		fmt.Fprintf(env.Out, "%6v\n", piggyBank)
 	}
 }
 
@@ -986,9 +987,9 @@ func f54() {
 Самым популярным знаковым типом целых чисел является int:
 */
 
-func f55() {
+func f55(env *Env) {
 	var year int = 2018
-	fmt.Print(year)
+	fmt.Fprint(env.Out, year)
 }
 
 /*
@@ -996,9 +997,9 @@ func f55() {
 Для неподписанных целых чисел используется аббревиатура uint
 */
 
-func f56() {
+func f56(env *Env) {
 	var month uint = 2
-	fmt.Print(month)
+	fmt.Fprint(env.Out, month)
 }
 
 /*
@@ -1006,11 +1007,11 @@ func f56() {
 Следующие три строки кода эквиваленты:
 */
 
-func f57() {
+func f57(env *Env) {
 	year1 := 2018
 	var year2 = 2018
 	var year3 int = 2018
-	fmt.Printf("%v %v %v", year1, year2, year3)
+	fmt.Fprintf(env.Out, "%v %v %v", year1, year2, year3)
 }
 
 //Тип целого числа integer для каждого случая Golang
@@ -1054,9 +1055,9 @@ Go Playground, Raspberry Pi 2 и более старые мобильные ус
 У нее есть специальный символ %T, что выводит тип переменной.
 */
 
-func f58() {
+func f58(env *Env) {
 	year := 2018
-	fmt.Printf("Type %T for %v\n", year, year) // Выводит: Type int for 2018
+	fmt.Fprintf(env.Out, "Type %T for %v\n", year, year) // Выводит: Type int for 2018
 }
 
 /*
@@ -1064,9 +1065,9 @@ func f58() {
 чтобы тот использовал первый аргумент [1] для второго специального символа для форматирования:
 */
 
-func f59() {
+func f59(env *Env) {
 	days := 365.2425
-	fmt.Printf("Type %T for %[1]v\n", days) // Выводит: Type float64 for 365.2425
+	fmt.Fprintf(env.Out, "Type %T for %[1]v\n", days) // Выводит: Type float64 for 365.2425
 }
 
 /*
@@ -1075,18 +1076,18 @@ func f59() {
 Запустите программу и посмотрите, к какому типу Go отнесет каждую переменную.
 */
 
-func f60() {
+func f60(env *Env) {
 	a := "text"
-	fmt.Printf("Type %T for %[1]v\n", a) // Выводит: Type string for text
+	fmt.Fprintf(env.Out, "Type %T for %[1]v\n", a) // Выводит: Type string for text
 
 	b := 42
-	fmt.Printf("Type %T for %[1]v\n", b) // Выводит: Type int for 42
+	fmt.Fprintf(env.Out, "Type %T for %[1]v\n", b) // Выводит: Type int for 42
 
 	c := 3.14
-	fmt.Printf("Type %T for %[1]v\n", c) // Выводит: Type float64 for 3.14
+	fmt.Fprintf(env.Out, "Type %T for %[1]v\n", c) // Выводит: Type float64 for 3.14
 
 	d := true
-	fmt.Printf("Type %T for %[1]v\n", d) // Выводит: Type bool for true
+	fmt.Fprintf(env.Out, "Type %T for %[1]v\n", d) // Выводит: Type bool for true
 }
 
 //Шестнадцатеричные значения в Go
@@ -1120,12 +1121,12 @@ var red, green, blue uint8 = 0x00, 0x8d, 0xd5
 Для отображения чисел в шестнадцатеричной системе можно использовать специальные символы %x или %X с Printf:
 */
 
-func f61() {
+func f61(env *Env) {
 	var red1, green1, blue1 uint8 = 0, 141, 213
 	var red2, green2, blue2 uint8 = 0x00, 0x8d, 0xd5
-	fmt.Printf("%x %x %x", red1, green1, blue1) // Выводит: 0 8d d5
-	fmt.Println()
-	fmt.Printf("%x %x %x", red2, green2, blue2) // Выводит: 0 8d d5
+	fmt.Fprintf(env.Out, "%x %x %x", red1, green1, blue1) // Выводит: 0 8d d5
+	fmt.Fprintln(env.Out)
+	fmt.Fprintf(env.Out, "%x %x %x", red2, green2, blue2) // Выводит: 0 8d d5
 }
 
 /*
@@ -1133,9 +1134,9 @@ func f61() {
 С помощью специальных символов %v и %f можно уточнить минимальное количество знаков [2] и нулевой отступ с %02х:
 */
 
-func f62() {
+func f62(env *Env) {
 	var red, green, blue uint8 = 0x00, 0x8d, 0xd5
-	fmt.Printf("color: #%02x%02x%02x;", red, green, blue) // Выводит: color #008dd5;
+	fmt.Fprintf(env.Out, "color: #%02x%02x%02x;", red, green, blue) // Выводит: color: #008dd5;
 }
 
 //Целочисленное переполнение в Go
@@ -1151,14 +1152,14 @@ func f62() {
 что в конечном итоге приводит к целочисленному переполнению.
 */
 
-func f63() {
+func f63(env *Env) {
 	var red uint8 = 255
 	red++
-	fmt.Println(red) // Выводит: 0
+	fmt.Fprintln(env.Out, red) // Выводит: 0
 
 	var number int8 = 127
 	number += 10
-	fmt.Println(number) // Выводит: -128
+	fmt.Fprintln(env.Out, number) // Выводит: -119
 }
 
 //Биты целочисленных значений
@@ -1169,11 +1170,11 @@ func f63() {
 Как и другие специальные символы %b может задействовать нулевой отступ с минимальной длиной:
 */
 
-func f64() {
+func f64(env *Env) {
 	var green uint8 = 3
-	fmt.Printf("%08b\n", green) // Выводит: 00000011
+	fmt.Fprintf(env.Out, "%08b\n", green) // Выводит: 00000011
 	green++
-	fmt.Printf("%08b\n", green) // Выводит: 00000100
+	fmt.Fprintf(env.Out, "%08b\n", green) // Выводит: 00000100
 }
 
 /*
@@ -1187,33 +1188,33 @@ func f64() {
 Что произойдет при объявлении uint16, присвоенного к максимальному значению 65535, а затем уменьшенному на 1?
 */
 
-func f65() {
+func f65(env *Env) {
 	// добавление числа больше, чем 1
 	var red uint8 = 255
 	red += 2
-	fmt.Println(red) // Выводит: 1
+	fmt.Fprintln(env.Out, red) // Выводит: 1
 
 	var number int8 = 127
 	number += 3
-	fmt.Println(number)
+	fmt.Fprintln(env.Out, number) // Выводит: -126
 }
 
-func f66() {
+func f66(env *Env) {
 	// переполнение с другой стороны
-	var red = 0
+	var red = 0 // тип int, а не uint8, поэтому переполнения нет
 	red--
-	fmt.Println(red) // Выводит: 255
+	fmt.Fprintln(env.Out, red) // Выводит: -1
 
-	var number = -128
+	var number = -128 // тоже int, а не int8
 	number--
-	fmt.Println(number)
+	fmt.Fprintln(env.Out, number) // Выводит: -129
 }
 
-func f67() {
+func f67(env *Env) {
 	// переполнения 16-битного неподписанного целого числа
 	var green uint16 = 65535
 	green++
-	fmt.Println(green) // Выводит: 0
+	fmt.Fprintln(env.Out, green) // Выводит: 0
 }
 
 /*
@@ -1234,9 +1235,9 @@ func f67() {
 Использование подходящего крупного значения (более 12 миллиардов) демонстрирует, что датами после 2038 года можно будет оперировать в Go.
 */
 
-func f68() {
+func f68(env *Env) {
 	future := time.Unix(12_622_780_800, 0)
-	fmt.Println(future) // Выводит: 2370-01-01 00:00:00 +0000 UTC
+	fmt.Fprintln(env.Out, future) // Выводит: 2370-01-01 00:00:00 +0000 UTC
 }
 
 /*
@@ -1249,11 +1250,11 @@ func f68() {
 При необходимости найти остаток от деления двух чисел используйте оператор модуля %.
 */
 
-func f69() {
+func f69(env *Env) {
 	piggyBank := 0
 
 	for piggyBank < 2000 {
-		switch rand.Intn(3) {
+		switch env.Rand.Intn(3) {
 		case 0:
 			piggyBank += 5
 		case 1:
@@ -1264,18 +1265,22 @@ func f69() {
 
 		dollars := piggyBank / 100
 		cents := piggyBank % 100
-		fmt.Printf("$%d.%02d\n", dollars, cents)
+		fmt.Fprintf(env.Out, "$%d.%02d\n", dollars, cents)
 	}
 }
 
-func f() {
+func f(env *Env) {
 	var s string = "123"
 	var r rune = 123
-	fmt.Printf("%v", s)
-	fmt.Printf("%v", r)
+	fmt.Fprintf(env.Out, "%v", s)
+	fmt.Fprintf(env.Out, "%v", r)
 }
 
-// main является функцией, с которой все начинается
+// main является функцией, с которой все начинается.
+// Какой пример запустить, определяется аргументами командной строки (см. cli.go).
 func main() {
-	f()
+	if err := runCLI(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
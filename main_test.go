@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"testing"
+	"time"
+)
+
+var isUpdate = flag.Bool("update", false, "перезаписать эталоны testdata/golden текущим выводом")
+
+// TestGolden сверяет вывод каждого примера с эталоном, как команда golden:
+// go test -run TestGolden -update перезаписывает эталоны.
+func TestGolden(t *testing.T) {
+	// time.Unix печатает время в локальной зоне, поэтому эталоны пишутся в UTC
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	for _, e := range GetExamplesByTag("") {
+		t.Run(e.Name, func(t *testing.T) {
+			res, err := checkGolden(e, goldenDir, *isUpdate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Status == "FAIL" {
+				t.Error(res.Detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	co "example/internal/constants"
+	"example/internal/money"
+	"example/internal/piggybank"
+)
+
+// runPiggy много раз повторяет копилку из f54 и печатает распределение числа монет до цели.
+func runPiggy(args []string) error {
+	fs := flag.NewFlagSet("piggy", flag.ContinueOnError)
+	currency := fs.String("currency", "USD", "валюта копилки: USD, EUR или RUB")
+	coins := fs.String("coins", "", "номиналы монет через запятую, например $0.05,$0.10 (по умолчанию набор валюты)")
+	target := fs.String("target", "", "цель, например $20 (по умолчанию 20 основных единиц валюты)")
+	trials := fs.Int("trials", co.PiggyBankTrials, "число опытов")
+	seed := fs.Int64("seed", 0, "зерно генератора случайных чисел (0 - случайное)")
+	rates := fs.String("rates", "", "JSON-файл курсов для перевода итогов в другие валюты")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, err := money.GetCurrency(*currency)
+	if err != nil {
+		return fmt.Errorf("флаг -currency: %w", err)
+	}
+	set, err := parseCoinSet(c, *coins)
+	if err != nil {
+		return fmt.Errorf("флаг -coins: %w", err)
+	}
+	goal, err := money.FromMajor(co.PiggyBankTarget, c)
+	if err != nil {
+		return err
+	}
+	if *target != "" {
+		if goal, err = money.Parse(*target); err != nil {
+			return fmt.Errorf("флаг -target: %w", err)
+		}
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+		fmt.Fprintf(os.Stderr, "seed: %d\n", *seed)
+	}
+	stats, err := piggybank.Simulate(rand.New(rand.NewSource(*seed)), set, goal, *trials)
+	if err != nil {
+		return err
+	}
+	if err := piggybank.WriteReport(os.Stdout, stats); err != nil {
+		return err
+	}
+
+	if *rates == "" {
+		return nil
+	}
+	return printConverted(*rates, stats)
+}
+
+// parseCoinSet возвращает набор монет из флага -coins или набор валюты по умолчанию.
+func parseCoinSet(c money.Currency, coins string) (piggybank.CoinSet, error) {
+	if coins == "" {
+		return piggybank.GetCoinSet(c)
+	}
+	set := piggybank.CoinSet{Currency: c}
+	for _, value := range strings.Split(coins, ",") {
+		coin, err := money.Parse(value)
+		if err != nil {
+			return piggybank.CoinSet{}, err
+		}
+		set.Coins = append(set.Coins, coin)
+	}
+	return set, set.Validate()
+}
+
+// printConverted переводит цель и средний итог копилки во все валюты из файла курсов.
+func printConverted(path string, stats piggybank.Stats) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	table, err := money.LoadRates(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	fmt.Printf("\nКурсы: %v\n", table)
+	for _, c := range []money.Currency{money.USD, money.EUR, money.RUB} {
+		target, err := table.Convert(stats.Target, c)
+		if errors.Is(err, money.ErrUnknownRate) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		balance, err := table.Convert(stats.MeanBalance, c)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%v: цель %v, средний итог %v\n", c.Code, target, balance)
+	}
+	return nil
+}
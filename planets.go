@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"example/internal/planets"
+)
+
+// runPlanets печатает вес и возраст на всех телах Солнечной системы, как в f4-f7.
+func runPlanets(args []string) error {
+	fs := flag.NewFlagSet("planets", flag.ContinueOnError)
+	weight := fs.Float64("weight", 55, "вес на Земле, кг")
+	birth := fs.String("birth", "", "дата рождения (2006-01-02)")
+	now := fs.String("now", "", "дата, на которую считается возраст (2006-01-02, по умолчанию сегодня)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *birth == "" {
+		return errors.New("укажите дату рождения флагом -birth")
+	}
+	birthDate, err := time.Parse(time.DateOnly, *birth)
+	if err != nil {
+		return fmt.Errorf("флаг -birth: %w", err)
+	}
+	nowDate := time.Now()
+	if *now != "" {
+		if nowDate, err = time.Parse(time.DateOnly, *now); err != nil {
+			return fmt.Errorf("флаг -now: %w", err)
+		}
+	}
+	if nowDate.Before(birthDate) {
+		return fmt.Errorf("дата рождения %s позже %s", *birth, nowDate.Format(time.DateOnly))
+	}
+
+	fmt.Printf("%-10v %10v %10v\n", "Тело", "Вес, кг", "Возраст")
+	for _, b := range planets.GetBodies() {
+		fmt.Printf("%-10v %10.2f %10.2f\n", b.Name, planets.CalcWeight(*weight, b), planets.CalcAge(birthDate, nowDate, b))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import "strings"
+
+// Example описывает один пример из main.go: имя функции, темы и краткое описание.
+type Example struct {
+	Name        string         // имя функции, например "f43"
+	Tags        []string       // темы примера: floats, integers, switch, scope, time...
+	Description string         // краткое описание того, что показывает пример
+	Run         func(env *Env) // сама функция примера
+}
+
+// HasTag проверяет, относится ли пример к теме tag.
+func (e Example) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// examples - реестр всех примеров в порядке их объявления в main.go.
+// f2 отсутствует: это закомментированный пример синтаксической ошибки.
+var examples = []Example{
+	{"f1", []string{"print"}, "Hello, playground", f1},
+	{"f3", []string{"print", "unicode"}, "приветствие на нескольких языках", f3},
+	{"f4", []string{"print", "floats", "planets"}, "вес и возраст на Марсе через Print", f4},
+	{"f5", []string{"print", "format", "planets"}, "вес и возраст на Марсе через Printf", f5},
+	{"f6", []string{"print", "format"}, "Printf с несколькими %v", f6},
+	{"f7", []string{"print", "format"}, "выравнивание таблицы через ширину %-15v", f7},
+	{"f8", []string{"constants", "integers"}, "время полета света до Марса", f8},
+	{"f9", []string{"variables"}, "способы объявления переменных", f9},
+	{"f10", []string{"variables", "operators"}, "операторы присваивания и инкремент", f10},
+	{"f11", []string{"rand"}, "случайные числа от 1 до 10", f11},
+	{"f12", []string{"rand", "mars"}, "случайное расстояние до Марса", f12},
+	{"f13", []string{"integers", "mars"}, "скорость ракеты для полета на Марс за 28 дней", f13},
+	{"f14", []string{"bool"}, "булевы переменные", f14},
+	{"f15", []string{"strings", "bool", "cave"}, "strings.Contains для команды в пещере", f15},
+	{"f16", []string{"comparison", "bool", "cave"}, "проверка совершеннолетия", f16},
+	{"f17", []string{"comparison", "strings"}, "сравнение строк", f17},
+	{"f18", []string{"if", "cave"}, "комнаты пещеры через if/else if", f18},
+	{"f19", []string{"if", "leap", "calendar"}, "високосный ли 2100 год", f19},
+	{"f20", []string{"bool", "cave"}, "логические операторы и факел", f20},
+	{"f21", []string{"switch", "cave"}, "команды у входа в пещеру через switch", f21},
+	{"f22", []string{"switch", "fallthrough", "cave"}, "fallthrough: озеро и глубина", f22},
+	{"f23", []string{"loops", "time", "countdown"}, "обратный отсчет с time.Sleep", f23},
+	{"f24", []string{"loops", "rand"}, "бесконечный цикл с break", f24},
+	{"f25", []string{"loops", "rand", "time", "countdown"}, "обратный отсчет со случайной отменой", f25},
+	{"f26", []string{"switch", "time"}, "день недели по-русски", f26},
+	{"f27", []string{"switch", "time"}, "будний или выходной день", f27},
+	{"f28", []string{"switch"}, "размер одежды через switch", f28},
+	{"f29", []string{"switch"}, "switch с инициализацией", f29},
+	{"f30", []string{"switch", "loops", "strings"}, "break внутри switch в цикле", f30},
+	{"f31", []string{"switch", "time"}, "AM или PM", f31},
+	{"f32", []string{"switch", "fallthrough"}, "остановки маршрута через fallthrough", f32},
+	{"f33", []string{"switch", "types"}, "type switch", f33},
+	{"f34", []string{"scope", "loops", "rand"}, "область видимости цикла for", f34},
+	{"f35", []string{"scope", "variables"}, "краткое объявление переменных", f35},
+	{"f36", []string{"scope", "loops"}, "переменная цикла вне for", f36},
+	{"f37", []string{"scope", "loops"}, "переменная цикла внутри for", f37},
+	{"f38", []string{"scope", "if", "rand"}, "краткое объявление в if", f38},
+	{"f39", []string{"scope", "switch", "rand"}, "краткое объявление в switch", f39},
+	{"f40", []string{"scope", "switch", "rand", "calendar"}, "случайная дата с дублированием кода", f40},
+	{"f41", []string{"scope", "rand", "calendar"}, "случайная дата после рефакторинга", f41},
+	{"f42", []string{"scope", "rand", "leap", "calendar"}, "десять случайных дат с учетом високосных лет", f42},
+	{"f43", []string{"rand", "tickets", "mars", "format"}, "генератор билетов на Марс", f43},
+	{"f44", []string{"floats", "variables"}, "объявление float64", f44},
+	{"f45", []string{"floats"}, "целое значение в float64", f45},
+	{"f46", []string{"floats"}, "float32 против float64", f46},
+	{"f47", []string{"floats"}, "нулевое значение float64", f47},
+	{"f48", []string{"floats", "format"}, "точность и ширина в Printf", f48},
+	{"f49", []string{"floats", "format"}, "отступ нулями", f49},
+	{"f50", []string{"floats", "money"}, "ошибка округления 0.1 + 0.2", f50},
+	{"f51", []string{"floats"}, "порядок умножения и деления", f51},
+	{"f52", []string{"floats", "comparison", "money"}, "прямое сравнение float", f52},
+	{"f53", []string{"floats", "comparison", "money"}, "сравнение float с допуском", f53},
+	{"f54", []string{"rand", "money"}, "копилка на money.Money", f54},
+	{"f55", []string{"integers"}, "тип int", f55},
+	{"f56", []string{"integers"}, "тип uint", f56},
+	{"f57", []string{"integers", "variables"}, "вывод типа int", f57},
+	{"f58", []string{"integers", "types", "format"}, "%T для int", f58},
+	{"f59", []string{"floats", "types", "format"}, "%T и индекс аргумента %[1]v", f59},
+	{"f60", []string{"types", "format"}, "типы по умолчанию для литералов", f60},
+	{"f61", []string{"integers", "hex", "format"}, "шестнадцатеричные литералы", f61},
+	{"f62", []string{"integers", "hex", "format"}, "цвет CSS через %02x", f62},
+	{"f63", []string{"integers", "overflow"}, "переполнение uint8 и int8", f63},
+	{"f64", []string{"integers", "bits", "format"}, "биты uint8 через %08b", f64},
+	{"f65", []string{"integers", "overflow"}, "переполнение при сложении больше 1", f65},
+	{"f66", []string{"integers", "overflow"}, "переполнение при уменьшении", f66},
+	{"f67", []string{"integers", "overflow"}, "переполнение uint16", f67},
+	{"f68", []string{"integers", "time", "overflow"}, "Unix-время после 2038 года", f68},
+	{"f69", []string{"integers", "rand", "money"}, "копилка в центах на int", f69},
+	{"f", []string{"strings", "types"}, "string и rune через %v", f},
+}
+
+// GetExample возвращает пример по имени функции.
+func GetExample(name string) (Example, bool) {
+	for _, e := range examples {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Example{}, false
+}
+
+// GetExamplesByTag возвращает примеры темы tag в порядке реестра.
+// Пустой tag означает все примеры.
+func GetExamplesByTag(tag string) []Example {
+	if tag == "" {
+		return examples
+	}
+	var found []Example
+	for _, e := range examples {
+		if e.HasTag(tag) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
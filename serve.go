@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"os"
+	"time"
+
+	"example/internal/ticketapi"
+	"example/internal/tickets"
+)
+
+// runServe запускает HTTP API билетов на Марс (см. internal/ticketapi).
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "адрес HTTP-сервера")
+	seed := fs.Int64("seed", 0, "зерно генератора билетов (0 - случайное)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	gen := tickets.NewGenerator(rand.New(rand.NewSource(*seed)))
+	router := ticketapi.NewRouter(ticketapi.NewHandler(gen), logger)
+
+	logger.Info("starting server", "addr", *addr, "seed", *seed)
+	return http.ListenAndServe(*addr, router)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"example/internal/unixtime"
+)
+
+// runUnix продолжает f68: показывает момент во всех представлениях времени Unix
+// и вместо молча переполненных чисел печатает ошибку с допустимым промежутком.
+func runUnix(args []string) error {
+	fs := flag.NewFlagSet("unix", flag.ContinueOnError)
+	ts := fs.String("ts", "", "время Unix, например 12622780800 из f68")
+	unitName := fs.String("unit", "s", "единица -ts: s, ms, us или ns")
+	at := fs.String("at", "", "момент \"ГГГГ-ММ-ДД ЧЧ:ММ\" в местном времени вместо -ts (по умолчанию сейчас)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *ts != "" && *at != "" {
+		return fmt.Errorf("флаги -ts и -at нельзя задать вместе")
+	}
+
+	t := time.Now()
+	switch {
+	case *ts != "":
+		unit, err := unixtime.ParseUnit(*unitName)
+		if err != nil {
+			return fmt.Errorf("флаг -unit: %w", err)
+		}
+		n, err := strconv.ParseInt(*ts, 10, 64)
+		if err != nil {
+			return fmt.Errorf("флаг -ts: %w", err)
+		}
+		if t, err = unixtime.FromUnix(n, unit); err != nil {
+			return fmt.Errorf("флаг -ts: %w", err)
+		}
+	case *at != "":
+		var err error
+		if t, err = parseMoment(*at); err != nil {
+			return fmt.Errorf("флаг -at: %w", err)
+		}
+	}
+
+	fmt.Printf("%-17s %v\n", "Момент:", t.UTC())
+	for _, u := range unixtime.GetUnits() {
+		n, err := unixtime.ToUnix(t, u)
+		fmt.Printf("%-17s %s\n", u.String()+":", describeResult(n, err))
+	}
+	n32, err := unixtime.ToTimeT32(t)
+	fmt.Printf("%-17s %s\n", "time_t (32 бита):", describeResult(n32, err))
+	d, err := unixtime.Between(time.Unix(0, 0), t)
+	fmt.Printf("%-17s %s\n", "От 1970 года:", describeResult(d, err))
+	return nil
+}
+
+// describeResult возвращает значение или текст ошибки, если преобразование не удалось.
+func describeResult[T any](value T, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprint(value)
+}
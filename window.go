@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"example/internal/launchwindow"
+	"example/internal/tickets"
+)
+
+// runWindow ищет окна запуска к Марсу в диапазоне дат и печатает их таблицей или JSON.
+func runWindow(args []string) error {
+	fs := flag.NewFlagSet("window", flag.ContinueOnError)
+	from := fs.String("from", "2020-01-01", "начало диапазона дат отправления (2006-01-02)")
+	to := fs.String("to", "2030-12-31", "конец диапазона дат отправления (2006-01-02)")
+	step := fs.Duration("step", 24*time.Hour, "шаг перебора дат")
+	top := fs.Int("top", 3, "сколько лучших окон показать для каждой компании")
+	isJSON := fs.Bool("json", false, "вывести результат в JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	fromDate, err := time.Parse(time.DateOnly, *from)
+	if err != nil {
+		return fmt.Errorf("флаг -from: %w", err)
+	}
+	toDate, err := time.Parse(time.DateOnly, *to)
+	if err != nil {
+		return fmt.Errorf("флаг -to: %w", err)
+	}
+
+	windows, err := launchwindow.Find(tickets.DefaultFleet(), launchwindow.Search{
+		From: fromDate,
+		To:   toDate,
+		Step: *step,
+		Top:  *top,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *isJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(windows)
+	}
+	return launchwindow.WriteTable(os.Stdout, windows)
+}
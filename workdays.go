@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"example/internal/calendar"
+)
+
+// runWorkdays отвечает на вопрос f27 "будний день или выходной" по производственному календарю:
+// учитывает праздники и переносы, считает рабочие дни между датами и прибавляет их к дате.
+func runWorkdays(args []string) error {
+	fs := flag.NewFlagSet("workdays", flag.ContinueOnError)
+	from := fs.String("from", "", "дата ГГГГ-ММ-ДД (по умолчанию сегодня)")
+	to := fs.String("to", "", "посчитать рабочие дни с -from до этой даты, не включая ее")
+	add := fs.Int("add", 0, "найти дату через N рабочих дней после -from (N < 0 - до)")
+	holidays := fs.String("holidays", "", "JSON-файл календаря (по умолчанию производственный календарь России)")
+	weekends := fs.String("weekends", "", "выходные дни недели через запятую вместо указанных в календаре, например fri,sat")
+	lang := fs.String("lang", "ru", "язык вывода: ru или en (названия праздников берутся из календаря)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	names, err := calendar.GetNames(*lang)
+	if err != nil {
+		return fmt.Errorf("флаг -lang: %w", err)
+	}
+	text, ok := workdayTexts[names.Language]
+	if !ok {
+		return fmt.Errorf("флаг -lang: %w: %q", calendar.ErrUnknownLanguage, *lang)
+	}
+	c := calendar.NewRussianCalendar()
+	if *holidays != "" {
+		if c, err = calendar.LoadBusinessCalendarFile(*holidays); err != nil {
+			return err
+		}
+	}
+	if *weekends != "" {
+		if c, err = replaceWeekends(c, *weekends); err != nil {
+			return fmt.Errorf("флаг -weekends: %w", err)
+		}
+	}
+
+	start := calendar.FromTime(time.Now())
+	if *from != "" {
+		if start, err = parseValidDate(*from); err != nil {
+			return fmt.Errorf("флаг -from: %w", err)
+		}
+	}
+	description, err := describeWorkday(c, start, text)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%v: %s\n", describeDate(start, names), description)
+
+	if *to != "" {
+		end, err := parseValidDate(*to)
+		if err != nil {
+			return fmt.Errorf("флаг -to: %w", err)
+		}
+		days, err := c.WorkingDaysBetween(start, end)
+		if err != nil {
+			return err
+		}
+		fmt.Printf(text.DaysUntil+"\n", describeDate(end, names), days)
+	}
+	if *add != 0 {
+		d, err := c.AddWorkingDays(start, *add)
+		if err != nil {
+			return err
+		}
+		fmt.Printf(text.DaysLater+"\n", *add, describeDate(d, names))
+	}
+	return nil
+}
+
+// replaceWeekends возвращает календарь c с выходными днями недели из списка list.
+// Праздники и переносы календаря сохраняются.
+func replaceWeekends(c *calendar.BusinessCalendar, list string) (*calendar.BusinessCalendar, error) {
+	var days []time.Weekday
+	for _, name := range strings.Split(list, ",") {
+		w, err := calendar.ParseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, w)
+	}
+	return c.WithWeekends(days...)
+}
+
+// parseValidDate разбирает дату ГГГГ-ММ-ДД и проверяет ее.
+func parseValidDate(s string) (calendar.Date, error) {
+	d, err := calendar.ParseDate(s)
+	if err != nil {
+		return calendar.Date{}, err
+	}
+	return d, d.Validate()
+}
+
+// describeDate возвращает дату с днем недели: "2024-04-27 (суббота)".
+func describeDate(d calendar.Date, names calendar.Names) string {
+	return fmt.Sprintf("%v (%s)", d, names.GetWeekday(d.Weekday()))
+}
+
+// workdayText - тексты команды workdays на одном языке.
+type workdayText struct {
+	Workday   string // рабочий день
+	DayOff    string // выходной
+	DaysUntil string // формат для числа рабочих дней до даты: дата, число
+	DaysLater string // формат для даты через N рабочих дней: N, дата
+}
+
+// workdayTexts - тексты команды workdays по языкам calendar.Names.
+var workdayTexts = map[string]workdayText{
+	"ru": {Workday: "рабочий день", DayOff: "выходной", DaysUntil: "Рабочих дней до %v: %d", DaysLater: "Через %d раб. дн.: %v"},
+	"en": {Workday: "working day", DayOff: "day off", DaysUntil: "Working days until %v: %d", DaysLater: "%d working days later: %v"},
+}
+
+// describeWorkday объясняет на языке text, рабочий ли день d и почему.
+// Для года, переносов которого календарь не знает, возвращает ошибку.
+func describeWorkday(c *calendar.BusinessCalendar, d calendar.Date, text workdayText) (string, error) {
+	if err := c.CheckYear(d); err != nil {
+		return "", err
+	}
+	if name, ok := c.GetHoliday(d); ok {
+		return text.DayOff + ", " + name, nil
+	}
+	if name, ok := c.GetWorkday(d); ok {
+		return text.Workday + ", " + name, nil
+	}
+	if c.IsWeekend(d) {
+		return text.DayOff, nil
+	}
+	return text.Workday, nil
+}